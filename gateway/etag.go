@@ -0,0 +1,80 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errdetails"
+)
+
+const (
+	// ETagTarget is the target of error details of ETagMismatchError.
+	ETagTarget = "etag"
+
+	etagMetaKey = runtime.MetadataPrefix + "etag"
+)
+
+// SetETag sets the entity tag of the resource returned by an RPC as gRPC metadata,
+// ForwardResponseMessage emits it as the ETag HTTP header.
+func SetETag(ctx context.Context, etag string) error {
+	return grpc.SetHeader(ctx, metadata.Pairs(etagMetaKey, etag))
+}
+
+// IfMatch returns the entity tags of the If-Match HTTP header that travels as gRPC metadata.
+// Returns (nil, false) if the header is not set or is "*", i.e. matches any version of a resource.
+func IfMatch(ctx context.Context) ([]string, bool) {
+	vals, ok := HeaderN(ctx, "If-Match", -1)
+	if !ok {
+		return nil, false
+	}
+	var etags []string
+	for _, v := range vals {
+		for _, tag := range strings.Split(v, ",") {
+			tag = strings.TrimSpace(tag)
+			if tag == "*" {
+				return nil, false
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+			if tag = strings.Trim(tag, `"`); tag != "" {
+				etags = append(etags, tag)
+			}
+		}
+	}
+	return etags, len(etags) > 0
+}
+
+// ETagMismatchError returns codes.FailedPrecondition error that reports a resource has been
+// modified since the version the client is aware of. HTTPStatus maps it to HTTP 412 Precondition Failed.
+func ETagMismatchError(msg string) error {
+	st := status.New(codes.FailedPrecondition, msg)
+	if dst, err := st.WithDetails(errdetails.New(codes.FailedPrecondition, ETagTarget, msg)); err == nil {
+		st = dst
+	}
+	return st.Err()
+}
+
+// IsETagMismatch reports whether st is a status of ETagMismatchError.
+func IsETagMismatch(st *status.Status) bool {
+	if st.Code() != codes.FailedPrecondition {
+		return false
+	}
+	for _, d := range st.Details() {
+		if ti, ok := d.(*errdetails.TargetInfo); ok && ti.GetTarget() == ETagTarget {
+			return true
+		}
+	}
+	return false
+}
+
+func handleForwardResponseETag(w http.ResponseWriter, md runtime.ServerMetadata) {
+	if vs := md.HeaderMD.Get(etagMetaKey); len(vs) > 0 {
+		w.Header().Set("ETag", `"`+vs[0]+`"`)
+	}
+}
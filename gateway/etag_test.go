@@ -0,0 +1,70 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	gateway_test "github.com/infobloxopen/atlas-app-toolkit/v2/gateway/internal"
+)
+
+func TestIfMatch(t *testing.T) {
+	for header, expected := range map[string][]string{
+		`"1"`:          {"1"},
+		`W/"1", "2"`:   {"1", "2"},
+		`*`:            nil,
+		`"1", *`:       nil,
+		``:             nil,
+		`"2023-01-01"`: {"2023-01-01"},
+	} {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(runtime.MetadataPrefix+"if-match", header))
+		etags, ok := IfMatch(ctx)
+		if !reflect.DeepEqual(etags, expected) || ok != (expected != nil) {
+			t.Errorf("IfMatch for %q = %v, %t - expected: %v", header, etags, ok, expected)
+		}
+	}
+
+	if _, ok := IfMatch(context.Background()); ok {
+		t.Error("unexpected If-Match header")
+	}
+}
+
+func TestETagMismatchError(t *testing.T) {
+	st := status.Convert(ETagMismatchError("contact has been modified"))
+	if st.Code() != codes.FailedPrecondition || !IsETagMismatch(st) {
+		t.Errorf("invalid status %v", st)
+	}
+	if code, name := HTTPStatus(context.Background(), st); code != http.StatusPreconditionFailed || name != "FAILED_PRECONDITION" {
+		t.Errorf("invalid http status %d %s - expected: %d", code, name, http.StatusPreconditionFailed)
+	}
+
+	st = status.New(codes.FailedPrecondition, "not ready")
+	if IsETagMismatch(st) {
+		t.Errorf("unexpected etag mismatch %v", st)
+	}
+	if code, _ := HTTPStatus(context.Background(), st); code != http.StatusBadRequest {
+		t.Errorf("invalid http status %d - expected: %d", code, http.StatusBadRequest)
+	}
+	if IsETagMismatch(status.Convert(errors.New("failure"))) {
+		t.Error("unexpected etag mismatch")
+	}
+}
+
+func TestForwardResponseMessage_ETag(t *testing.T) {
+	md := runtime.ServerMetadata{HeaderMD: metadata.Pairs(etagMetaKey, "42")}
+	ctx := runtime.NewServerMetadataContext(context.Background(), md)
+	rw := httptest.NewRecorder()
+	ForwardResponseMessage(ctx, nil, &runtime.JSONBuiltin{}, rw, nil, &gateway_test.User{Name: "Poe", Age: 209})
+
+	if etag := rw.Header().Get("ETag"); etag != `"42"` {
+		t.Errorf("invalid ETag header %s - expected: %s", etag, `"42"`)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
@@ -30,10 +31,25 @@ type gateway struct {
 	endpoints         map[string][]registerFunc
 	mux               *http.ServeMux
 	gatewayMuxOptions []runtime.ServeMuxOption
+	filteringLimits   query.FilteringLimits
 }
 
 // ClientUnaryInterceptor parse collection operators and stores in corresponding message fields
 func ClientUnaryInterceptor(parentCtx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return clientUnaryInterceptor(parentCtx, query.ParseFiltering, method, req, reply, cc, invoker, opts...)
+}
+
+// ClientUnaryInterceptorWithLimits returns ClientUnaryInterceptor that parses filtering expressions
+// with limits l, so that expressions exceeding them are rejected with codes.InvalidArgument
+// as soon as the parser reaches the limit, see query.NewFilteringParserWithLimits.
+func ClientUnaryInterceptorWithLimits(l query.FilteringLimits) grpc.UnaryClientInterceptor {
+	parser := query.NewFilteringParserWithLimits(l)
+	return func(parentCtx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return clientUnaryInterceptor(parentCtx, parser.Parse, method, req, reply, cc, invoker, opts...)
+	}
+}
+
+func clientUnaryInterceptor(parentCtx context.Context, parseFiltering func(string) (*query.Filtering, error), method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 	raw, ok := Header(parentCtx, query_url)
 	if ok {
 		request, err := url.Parse(raw)
@@ -61,13 +77,28 @@ func ClientUnaryInterceptor(parentCtx context.Context, method string, req, reply
 			}
 		}
 
-		// extracts "_filter" parameters from request
+		// extracts "_filter" and "_include_deleted" parameters from request
+		var f *query.Filtering
 		if v := vals.Get(filterQueryKey); v != "" {
-			f, err := query.ParseFiltering(v)
+			f, err = parseFiltering(v)
+			if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+				return err
+			}
 			if err != nil {
 				return status.Error(codes.InvalidArgument, err.Error())
 			}
-
+		}
+		if v := vals.Get(includeDeletedQueryKey); v != "" {
+			includeDeleted, err := strconv.ParseBool(v)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "filtering: include_deleted - %s", err.(*strconv.NumError).Err)
+			}
+			if f == nil {
+				f = &query.Filtering{}
+			}
+			f.IncludeDeleted = includeDeleted
+		}
+		if f != nil {
 			err = SetCollectionOps(req, f)
 			if err != nil {
 				return err
@@ -107,15 +138,17 @@ func ClientUnaryInterceptor(parentCtx context.Context, method string, req, reply
 func NewGateway(options ...Option) (*http.ServeMux, error) {
 	// configure gateway defaults
 	g := gateway{
-		serverAddress:     DefaultServerAddress,
-		endpoints:         make(map[string][]registerFunc),
-		serverDialOptions: []grpc.DialOption{grpc.WithInsecure(), grpc.WithUnaryInterceptor(ClientUnaryInterceptor)},
-		mux:               http.NewServeMux(),
+		serverAddress: DefaultServerAddress,
+		endpoints:     make(map[string][]registerFunc),
+		mux:           http.NewServeMux(),
 	}
 	// apply functional options
 	for _, opt := range options {
 		opt(&g)
 	}
+	if g.serverDialOptions == nil {
+		g.serverDialOptions = []grpc.DialOption{grpc.WithInsecure(), grpc.WithUnaryInterceptor(ClientUnaryInterceptorWithLimits(g.filteringLimits))}
+	}
 	return g.registerEndpoints()
 }
 
@@ -178,3 +211,13 @@ func WithGatewayOptions(opt ...runtime.ServeMuxOption) Option {
 		g.gatewayMuxOptions = append(g.gatewayMuxOptions, opt...)
 	}
 }
+
+// WithGatewayFilteringLimits makes the REST gateway reject filtering expressions
+// that exceed limits l while they are parsed, see ClientUnaryInterceptorWithLimits.
+// The limits are not applied if gRPC dial options are replaced by WithDialOptions,
+// use ClientUnaryInterceptorWithLimits in the given options instead.
+func WithGatewayFilteringLimits(l query.FilteringLimits) Option {
+	return func(g *gateway) {
+		g.filteringLimits = l
+	}
+}
@@ -3,9 +3,11 @@ package gateway
 import (
 	"context"
 	"net/http"
+	"net/url"
 	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -244,3 +246,75 @@ func TestFilitering(t *testing.T) {
 		t.Fatalf("invalid error: %s, for CollectionOperationsInterceptor", err)
 	}
 }
+
+func TestIncludeDeleted(t *testing.T) {
+	for rawQuery, expected := range map[string]*query.Filtering{
+		"_include_deleted=true":                   {IncludeDeleted: true},
+		"_include_deleted=false":                  {},
+		"_filter=name=='John'&_include_deleted=1": {IncludeDeleted: true, Root: &query.Filtering_StringCondition{StringCondition: &query.StringCondition{FieldPath: []string{"name"}, Value: "John"}}},
+		"_filter=name=='John'":                    {Root: &query.Filtering_StringCondition{StringCondition: &query.StringCondition{FieldPath: []string{"name"}, Value: "John"}}},
+		"someparam=1":                             nil,
+	} {
+		hreq, err := http.NewRequest(http.MethodGet, "http://app.com?"+rawQuery, nil)
+		if err != nil {
+			t.Fatalf("failed to build new http testRequest: %s", err)
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), MetadataAnnotator(context.Background(), hreq))
+
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			if f := req.(*testRequest).Filtering; !proto.Equal(f, expected) {
+				t.Errorf("unexpected filtering %v for %q - expected: %v", f, rawQuery, expected)
+			}
+			return nil
+		}
+		if err := ClientUnaryInterceptor(ctx, hreq.Method, &testRequest{}, &testResponse{}, nil, invoker); err != nil {
+			t.Fatalf("invalid error: %s, for CollectionOperationsInterceptor", err)
+		}
+	}
+
+	hreq, err := http.NewRequest(http.MethodGet, "http://app.com?_include_deleted=yes", nil)
+	if err != nil {
+		t.Fatalf("failed to build new http testRequest: %s", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), MetadataAnnotator(context.Background(), hreq))
+	err = ClientUnaryInterceptor(ctx, hreq.Method, &testRequest{}, &testResponse{}, nil, nil)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unexpected error %v, expected InvalidArgument", err)
+	}
+}
+
+func TestFilteringLimits(t *testing.T) {
+	limits := query.FilteringLimits{MaxDepth: 2, MaxConditions: 3}
+	for name, filter := range map[string]string{
+		"nested": "a == 1 and (b == 2 or (c == 3 and (d == 4 or e == 5)))",
+		"long":   "a == 1 or b == 2 or c == 3 or d == 4 or e == 5 or f == 6 or g == 7 or h == 8",
+	} {
+		hreq, err := http.NewRequest(http.MethodGet, "http://app.com?_filter="+url.QueryEscape(filter), nil)
+		if err != nil {
+			t.Fatalf("failed to build new http testRequest: %s", err)
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), MetadataAnnotator(context.Background(), hreq))
+
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			t.Errorf("unexpected call of invoker for %s filter", name)
+			return nil
+		}
+		err = ClientUnaryInterceptorWithLimits(limits)(ctx, hreq.Method, &testRequest{}, &testResponse{}, nil, invoker)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("unexpected error %v for %s filter, expected InvalidArgument", err, name)
+		}
+		if err := ClientUnaryInterceptor(ctx, hreq.Method, &testRequest{}, &testResponse{}, nil, nopInvoker); err != nil {
+			t.Errorf("unexpected error %v for %s filter without limits", err, name)
+		}
+	}
+
+	g := gateway{}
+	WithGatewayFilteringLimits(limits)(&g)
+	if g.filteringLimits != limits {
+		t.Errorf("unexpected filtering limits %v - expected: %v", g.filteringLimits, limits)
+	}
+}
+
+func nopInvoker(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	return nil
+}
@@ -11,13 +11,44 @@ import (
 	"google.golang.org/grpc/grpclog"
 )
 
+type serverInterceptorOptions struct {
+	policies map[string]*query.CollectionPolicy
+	limits   query.FilteringLimits
+}
+
+// ServerInterceptorOption configures UnaryServerInterceptor.
+type ServerInterceptorOption func(*serverInterceptorOptions)
+
+// WithCollectionPolicy restricts filtering and sorting collection operators of
+// requests to the gRPC method fullMethod (e.g. "/example.Contacts/List") by policy p.
+// Requests that violate the policy are rejected with codes.InvalidArgument and
+// per-field error details, see query.CollectionPolicy.Validate.
+func WithCollectionPolicy(fullMethod string, p *query.CollectionPolicy) ServerInterceptorOption {
+	return func(o *serverInterceptorOptions) {
+		o.policies[fullMethod] = p
+	}
+}
+
+// WithFilteringLimits rejects requests with filtering collection operators that
+// exceed limits l with codes.InvalidArgument, see query.FilteringLimits.Validate.
+func WithFilteringLimits(l query.FilteringLimits) ServerInterceptorOption {
+	return func(o *serverInterceptorOptions) {
+		o.limits = l
+	}
+}
+
 // UnaryServerInterceptor returns grpc.UnaryServerInterceptor
 // that should be used as a middleware if an user's request message
 // defines any of collection operators.
 //
 // Returned middleware populates collection operators from gRPC metadata if
 // they defined in a request message.
-func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+func UnaryServerInterceptor(options ...ServerInterceptorOption) grpc.UnaryServerInterceptor {
+	opts := &serverInterceptorOptions{policies: make(map[string]*query.CollectionPolicy)}
+	for _, o := range options {
+		o(opts)
+	}
+
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res interface{}, err error) {
 
 		if req == nil {
@@ -25,6 +56,14 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 			return handler(ctx, req)
 		}
 
+		var policy *query.CollectionPolicy
+		if info != nil {
+			policy = opts.policies[info.FullMethod]
+		}
+		if err := validateCollectionOps(req, policy, opts.limits); err != nil {
+			return nil, err
+		}
+
 		res, err = handler(ctx, req)
 		if err != nil {
 			return res, err
@@ -45,6 +84,23 @@ func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+func validateCollectionOps(req interface{}, p *query.CollectionPolicy, l query.FilteringLimits) error {
+	if p == nil && l == (query.FilteringLimits{}) {
+		return nil
+	}
+	f, s := new(query.Filtering), new(query.Sorting)
+	if err := GetCollectionOp(req, f); err != nil {
+		grpclog.Errorf("collection operator interceptor: failed to get filtering - %s", err)
+	}
+	if err := GetCollectionOp(req, s); err != nil {
+		grpclog.Errorf("collection operator interceptor: failed to get sorting - %s", err)
+	}
+	if err := l.Validate(f); err != nil {
+		return err
+	}
+	return p.Validate(f, s)
+}
+
 func SetCollectionOps(req, op interface{}) error {
 	reqval := reflect.ValueOf(req)
 
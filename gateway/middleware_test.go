@@ -1,9 +1,15 @@
 package gateway
 
 import (
+	"context"
 	"testing"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errfields"
 )
 
 func TestUnsetOp(t *testing.T) {
@@ -49,3 +55,81 @@ func TestUnsetOp(t *testing.T) {
 		t.Errorf("invalid error: %s - expected: %s", err, "response value is not a struct - int")
 	}
 }
+
+func TestUnaryServerInterceptorCollectionPolicy(t *testing.T) {
+	policy := query.NewCollectionPolicy().Filterable("name", "==").Sortable("name")
+	interceptor := UnaryServerInterceptor(WithCollectionPolicy("/test.Service/List", policy))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &testResponse{}, nil
+	}
+
+	f, err := query.ParseFiltering("name ~ 'x' and id == 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &testRequest{Filtering: f, Sorting: &query.Sorting{Criterias: []*query.SortCriteria{{Tag: "name"}}}}
+
+	_, err = interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/test.Service/List"}, handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("invalid error code: %s - expected: %s", status.Code(err), codes.InvalidArgument)
+	}
+	fields := status.Convert(err).Details()[0].(*errfields.FieldInfo).GetFields()
+	if len(fields) != 2 || fields["name"] == nil || fields["id"] == nil {
+		t.Errorf("invalid field errors: %v - expected errors for name and id", fields)
+	}
+
+	// methods without policy are not restricted
+	if _, err = interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Read"}, handler); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	req.Filtering, err = query.ParseFiltering("name == 'x'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/test.Service/List"}, handler); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	// soft deleted records are included only if the policy allows it
+	req.Filtering.IncludeDeleted = true
+	_, err = interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/test.Service/List"}, handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("invalid error code: %s - expected: %s", status.Code(err), codes.InvalidArgument)
+	}
+	if fields := status.Convert(err).Details()[0].(*errfields.FieldInfo).GetFields(); fields["include_deleted"] == nil {
+		t.Errorf("invalid field errors: %v - expected error for include_deleted", fields)
+	}
+	policy.AllowIncludeDeleted()
+	if _, err = interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/test.Service/List"}, handler); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestUnaryServerInterceptorFilteringLimits(t *testing.T) {
+	interceptor := UnaryServerInterceptor(WithFilteringLimits(query.FilteringLimits{MaxConditions: 2, MaxInListSize: 2}))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return &testResponse{}, nil
+	}
+
+	f, err := query.ParseFiltering("a == 1 and b in [1, 2, 3] and c == 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = interceptor(context.Background(), &testRequest{Filtering: f}, &grpc.UnaryServerInfo{FullMethod: "/test.Service/List"}, handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("invalid error code: %s - expected: %s", status.Code(err), codes.InvalidArgument)
+	}
+	fields := status.Convert(err).Details()[0].(*errfields.FieldInfo).GetFields()
+	if len(fields) != 2 || fields["_filter"] == nil || fields["b"] == nil {
+		t.Errorf("invalid field errors: %v - expected errors for _filter and b", fields)
+	}
+
+	f, err = query.ParseFiltering("a == 1 and b in [1, 2]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = interceptor(context.Background(), &testRequest{Filtering: f}, &grpc.UnaryServerInfo{FullMethod: "/test.Service/List"}, handler); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
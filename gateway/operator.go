@@ -19,6 +19,7 @@ const (
 	pageTokenQueryKey            = "_page_token"
 	searchQueryKey               = "_fts"
 	isTotalSizeNeededQueryKey    = "_is_total_size_needed"
+	includeDeletedQueryKey       = "_include_deleted"
 	pageInfoSizeMetaKey          = "status-page-info-size"
 	pageInfoOffsetMetaKey        = "status-page-info-offset"
 	pageInfoPageTokenMetaKey     = "status-page-info-page_token"
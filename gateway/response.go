@@ -68,6 +68,7 @@ func (fw *ResponseForwarder) ForwardMessage(ctx context.Context, mux *runtime.Se
 
 	handleForwardResponseServerMetadata(fw.OutgoingHeaderMatcher, rw, md)
 	handleForwardResponseTrailerHeader(rw, md)
+	handleForwardResponseETag(rw, md)
 
 	rw.Header().Set("Content-Type", marshaler.ContentType(nil))
 
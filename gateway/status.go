@@ -79,10 +79,14 @@ func SetRunning(ctx context.Context, message, resource string) error {
 // API Syntax otherwise context will be used to extract
 // `grpcgateway-status-code` from gRPC metadata.
 // If `grpcgateway-status-code` is not set it is assumed that it is OK.
+// Statuses of ETagMismatchError are converted to HTTP 412 Precondition Failed.
 func HTTPStatus(ctx context.Context, st *status.Status) (int, string) {
 
 	if st != nil {
 		httpStatus := HTTPStatusFromCode(st.Code())
+		if IsETagMismatch(st) {
+			httpStatus = http.StatusPreconditionFailed
+		}
 
 		return httpStatus, CodeName(st.Code())
 	}
@@ -105,6 +109,9 @@ func HTTPStatusWithMethod(ctx context.Context, method string, st *status.Status)
 
 	if st != nil {
 		httpStatus := HTTPStatusFromCode(st.Code())
+		if IsETagMismatch(st) {
+			httpStatus = http.StatusPreconditionFailed
+		}
 
 		return httpStatus, CodeName(st.Code())
 	}
@@ -0,0 +1,378 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+
+	atlaserrors "github.com/infobloxopen/atlas-app-toolkit/v2/errors"
+)
+
+// DefaultBatchSize is the number of rows BatchCreate inserts by a single statement by default.
+const DefaultBatchSize = 500
+
+// maxBindParams is the maximum number of arguments of a statement supported by Postgres.
+const maxBindParams = 65535
+
+type conflictAction int
+
+const (
+	conflictError conflictAction = iota
+	conflictDoNothing
+	conflictUpdate
+)
+
+type batchOptions struct {
+	size            int
+	action          conflictAction
+	conflictColumns []string
+	updateColumns   []string
+}
+
+// BatchOption configures BatchCreate.
+type BatchOption func(*batchOptions)
+
+// WithBatchSize sets the number of rows BatchCreate inserts by a single statement, see DefaultBatchSize.
+func WithBatchSize(size int) BatchOption {
+	return func(o *batchOptions) {
+		if size > 0 {
+			o.size = size
+		}
+	}
+}
+
+// OnConflictDoNothing makes BatchCreate skip rows that conflict with existing ones on the unique constraint
+// of columns, or on any unique constraint if columns are not given.
+func OnConflictDoNothing(columns ...string) BatchOption {
+	return func(o *batchOptions) {
+		o.action = conflictDoNothing
+		o.conflictColumns = columns
+	}
+}
+
+// OnConflictUpdate makes BatchCreate upsert rows, i.e. update updateColumns of existing rows that conflict
+// with inserted ones on the unique constraint of columns. All inserted columns except columns, primary keys
+// and the creation timestamp are updated if updateColumns are not given, conflicting rows are skipped
+// if there are no such columns.
+func OnConflictUpdate(columns []string, updateColumns ...string) BatchOption {
+	return func(o *batchOptions) {
+		o.action = conflictUpdate
+		o.conflictColumns = columns
+		o.updateColumns = updateColumns
+	}
+}
+
+// batchRowError is an error of the row of BatchCreate at index.
+type batchRowError struct {
+	index int
+	err   error
+}
+
+// BatchCreate inserts rows, a slice of models or pointers to models, within the transaction from ctx
+// and returns the number of inserted or updated rows. Rows are inserted in batches by a single statement
+// per batch, see WithBatchSize, conflicts with existing rows are handled according to OnConflictDoNothing
+// and OnConflictUpdate. Primary keys of inserted rows are set unless conflicts are handled.
+// Timestamps of creation and update are set and rows are assigned to the tenant of the transaction,
+// see WithTenancy, other GORM callbacks are not run.
+//
+// If a batch fails due to invalid data, a constraint violation or rows upserting the same row,
+// its rows are inserted one by one, rows that fail are skipped and the others are inserted.
+// Failed rows are reported by *errors.Container with a detail per row targeted at the index of the row in rows.
+// Other errors abort BatchCreate.
+func BatchCreate(ctx context.Context, rows interface{}, opts ...BatchOption) (int64, error) {
+	o := &batchOptions{size: DefaultBatchSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	v := reflect.Indirect(reflect.ValueOf(rows))
+	if v.Kind() != reflect.Slice {
+		return 0, fmt.Errorf("BatchCreate expects a slice of models, got %T", rows)
+	}
+	models := make([]interface{}, v.Len())
+	for i := range models {
+		if elem := v.Index(i); elem.Kind() == reflect.Ptr {
+			models[i] = elem.Interface()
+		} else {
+			models[i] = elem.Addr().Interface()
+		}
+	}
+
+	var total int64
+	var failed []batchRowError
+	for start := 0; start < len(models); start += o.size {
+		end := start + o.size
+		if end > len(models) {
+			end = len(models)
+		}
+		n, err := o.insert(ctx, models[start:end])
+		if err == nil {
+			total += n
+			continue
+		}
+		if !isRowError(err) {
+			return total, err
+		}
+		// the batch is rolled back, so its rows are inserted one by one to find the failing ones
+		for i := start; i < end; i++ {
+			n, err := o.insert(ctx, models[i:i+1])
+			if err != nil {
+				if !isRowError(err) {
+					return total, err
+				}
+				failed = append(failed, batchRowError{index: i, err: err})
+				continue
+			}
+			total += n
+		}
+	}
+	if len(failed) > 0 {
+		return total, batchError(failed, len(models))
+	}
+	return total, nil
+}
+
+// isRowError reports whether err is caused by the data of inserted rows, i.e. a data exception (class 22),
+// an integrity constraint violation (class 23) or a cardinality violation (21000) of a batch
+// that upserts the same row more than once.
+func isRowError(err error) bool {
+	state := sqlState(err)
+	return state == "21000" || strings.HasPrefix(state, "22") || strings.HasPrefix(state, "23")
+}
+
+// batchError returns the error container of failed rows out of total rows.
+func batchError(failed []batchRowError, total int) error {
+	code := codes.AlreadyExists
+	for _, f := range failed {
+		if sqlState(f.err) != "23505" {
+			code = codes.InvalidArgument
+		}
+	}
+	c := atlaserrors.NewContainer(code, "%d of %d rows failed", len(failed), total)
+	for _, f := range failed {
+		rowCode := codes.InvalidArgument
+		if sqlState(f.err) == "23505" {
+			rowCode = codes.AlreadyExists
+		}
+		msg := f.err.Error()
+		var pqErr *pq.Error
+		if errors.As(f.err, &pqErr) {
+			msg = pqErr.Message
+		}
+		c.WithDetail(rowCode, strconv.Itoa(f.index), "%s", msg)
+	}
+	return c
+}
+
+// insert inserts models within a savepoint of the transaction from ctx,
+// so the transaction is intact if the statement fails.
+func (o *batchOptions) insert(ctx context.Context, models []interface{}) (n int64, err error) {
+	err = Nested(ctx, func(tx *gorm.DB) error {
+		n, err = o.insertRows(tx, models)
+		return err
+	})
+	return n, err
+}
+
+// insertRows inserts models by a single statement, or by several ones if the number of arguments exceeds
+// maxBindParams, and returns the number of inserted or updated rows.
+func (o *batchOptions) insertRows(db *gorm.DB, models []interface{}) (int64, error) {
+	now := gorm.NowFunc()
+	scopes := make([]*gorm.Scope, len(models))
+	for i, m := range models {
+		scope := db.NewScope(m)
+		for _, name := range []string{"CreatedAt", "UpdatedAt"} {
+			if field, ok := scope.FieldByName(name); ok && field.IsBlank {
+				scope.Err(field.Set(now))
+			}
+		}
+		tenancyAssignCallback(scope)
+		if scope.HasError() {
+			return 0, scope.DB().Error
+		}
+		scopes[i] = scope
+	}
+
+	// columns that are omitted by all rows are left to their defaults
+	var columns []string
+	var indexes []int
+	for j, field := range scopes[0].Fields() {
+		if !field.IsNormal || field.IsIgnored {
+			continue
+		}
+		for _, scope := range scopes {
+			if !defaulted(scope.Fields()[j]) {
+				columns = append(columns, field.DBName)
+				indexes = append(indexes, j)
+				break
+			}
+		}
+	}
+
+	scope := scopes[0]
+	// arguments of the conflict clause precede values of rows, so statements of a split batch share the clause
+	conflict, conflictVars, err := o.conflictClause(db, scope, columns)
+	if err != nil {
+		return 0, err
+	}
+	// Postgres limits the number of arguments of a statement, so batches of wide rows are split
+	size := len(scopes)
+	if len(columns) > 0 && (maxBindParams-len(conflictVars))/len(columns) < size {
+		size = (maxBindParams - len(conflictVars)) / len(columns)
+	}
+	var total int64
+	for start := 0; start < len(scopes); start += size {
+		end := start + size
+		if end > len(scopes) {
+			end = len(scopes)
+		}
+		n, err := o.insertStatement(db, scopes[start:end], columns, indexes, conflict, conflictVars)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// insertStatement inserts indexes fields of scopes to columns by a single statement with the conflict clause
+// and returns the number of inserted or updated rows.
+func (o *batchOptions) insertStatement(db *gorm.DB, scopes []*gorm.Scope, columns []string, indexes []int, conflict string, conflictVars []interface{}) (int64, error) {
+	var values []string
+	vars := append([]interface{}{}, conflictVars...)
+	for _, scope := range scopes {
+		fields := scope.Fields()
+		placeholders := make([]string, len(indexes))
+		for k, j := range indexes {
+			if defaulted(fields[j]) {
+				placeholders[k] = "DEFAULT"
+				continue
+			}
+			vars = append(vars, fields[j].Field.Interface())
+			placeholders[k] = "$" + strconv.Itoa(len(vars))
+		}
+		values = append(values, "("+strings.Join(placeholders, ", ")+")")
+	}
+
+	scope := scopes[0]
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = scope.Quote(col)
+	}
+	stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", scope.QuotedTableName(), strings.Join(quoted, ", "), strings.Join(values, ", "))
+	stmt += conflict
+
+	primaryFields := scope.PrimaryFields()
+	if o.action != conflictError || len(primaryFields) != 1 {
+		res, err := db.CommonDB().Exec(stmt, vars...)
+		if err != nil {
+			return 0, err
+		}
+		return res.RowsAffected()
+	}
+	// Without conflict handling all rows are inserted. Primary keys are assigned in the order rows are returned,
+	// as GORM does for batch inserts, since Postgres returns rows inserted by INSERT ... VALUES in the order of VALUES.
+	result, err := db.CommonDB().Query(stmt+" RETURNING "+scope.Quote(primaryFields[0].DBName), vars...)
+	if err != nil {
+		return 0, err
+	}
+	defer result.Close()
+	n := 0
+	for ; n < len(scopes) && result.Next(); n++ {
+		field, _ := scopes[n].FieldByName(primaryFields[0].Name)
+		if err := result.Scan(field.Field.Addr().Interface()); err != nil {
+			return int64(n), err
+		}
+	}
+	if err := result.Err(); err != nil {
+		return int64(n), err
+	}
+	if n != len(scopes) {
+		return int64(n), fmt.Errorf("Cannot assign primary keys, %d of %d rows are returned", n, len(scopes))
+	}
+	return int64(n), nil
+}
+
+// defaulted reports whether the value of field is omitted and left to the default of its column.
+func defaulted(field *gorm.Field) bool {
+	return field.IsBlank && (field.HasDefaultValue || field.IsPrimaryKey)
+}
+
+// conflictClause returns the ON CONFLICT clause of the statement inserting columns of scope's model,
+// placeholders of its arguments are numbered from $1.
+func (o *batchOptions) conflictClause(db *gorm.DB, scope *gorm.Scope, columns []string) (string, []interface{}, error) {
+	if o.action == conflictError {
+		return "", nil, nil
+	}
+	target, err := dbColumns(scope, o.conflictColumns)
+	if err != nil {
+		return "", nil, err
+	}
+	var clause string
+	if len(target) > 0 {
+		quoted := make([]string, len(target))
+		for i, col := range target {
+			quoted[i] = scope.Quote(col)
+		}
+		clause = " ON CONFLICT (" + strings.Join(quoted, ", ") + ")"
+	} else {
+		clause = " ON CONFLICT"
+	}
+	if o.action == conflictDoNothing {
+		return clause + " DO NOTHING", nil, nil
+	}
+	if len(target) == 0 {
+		return "", nil, errors.New("OnConflictUpdate requires conflict columns")
+	}
+
+	updates, err := dbColumns(scope, o.updateColumns)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(updates) == 0 {
+		for _, col := range columns {
+			field, _ := scope.FieldByName(col)
+			if !field.IsPrimaryKey && field.Name != "CreatedAt" && !containsString(target, col) {
+				updates = append(updates, col)
+			}
+		}
+	}
+	if len(updates) == 0 {
+		// there is nothing to update, as GORM does conflicting rows are skipped
+		return clause + " DO NOTHING", nil, nil
+	}
+	set := make([]string, len(updates))
+	for i, col := range updates {
+		set[i] = scope.Quote(col) + " = EXCLUDED." + scope.Quote(col)
+	}
+	clause += " DO UPDATE SET " + strings.Join(set, ", ")
+
+	// rows of other tenants are not updated
+	cond, args := tenantCondition(db, indirectType(reflect.TypeOf(scope.Value)), scope.QuotedTableName())
+	if cond == "" {
+		return clause, nil, nil
+	}
+	for i := range args {
+		cond = strings.Replace(cond, "?", "$"+strconv.Itoa(i+1), 1)
+	}
+	return clause + " WHERE " + cond, args, nil
+}
+
+// dbColumns returns column names of fields of scope's model with the given names or column names.
+func dbColumns(scope *gorm.Scope, names []string) ([]string, error) {
+	columns := make([]string, len(names))
+	for i, name := range names {
+		field, ok := scope.FieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("Cannot find column %s in %s", name, scope.TableName())
+		}
+		columns[i] = field.DBName
+	}
+	return columns, nil
+}
@@ -0,0 +1,208 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errdetails"
+)
+
+func TestBatchCreate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	txn := &Transaction{parent: gdb}
+	ctx := NewContext(context.Background(), txn)
+
+	contacts := []ContactORM{
+		{Name: "John", Email: "john@example.com"},
+		{Name: "Jane", Email: "jane@example.com"},
+		{Name: "Jim", Email: "jim@example.com"},
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`^INSERT INTO "contacts" \("name", "email"\) VALUES \(\$1, \$2\), \(\$3, \$4\) RETURNING "id"$`).
+		WithArgs("John", "john@example.com", "Jane", "jane@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`^INSERT INTO "contacts" \("name", "email"\) VALUES \(\$1, \$2\) RETURNING "id"$`).
+		WithArgs("Jim", "jim@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	n, err := BatchCreate(ctx, contacts, WithBatchSize(2))
+	if err != nil {
+		t.Fatalf("failed to create contacts - %s", err)
+	}
+	if n != 3 {
+		t.Errorf("unexpected number of created contacts %d - expected: 3", n)
+	}
+	for i, c := range contacts {
+		if c.Id != int64(i+1) {
+			t.Errorf("unexpected id %d of contact %d", c.Id, i)
+		}
+	}
+
+	// the failed batch is inserted row by row
+	upsert := `^INSERT INTO "contacts" \("id", "name", "email"\) VALUES %s ON CONFLICT \("email"\) DO UPDATE SET "name" = EXCLUDED."name"$`
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_3$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(fmt.Sprintf(upsert, `\(\$1, \$2, \$3\), \(\$4, \$5, \$6\)`)).
+		WithArgs(1, "Johnny", "john@example.com", 4, "", "nobody@example.com").
+		WillReturnError(&pq.Error{Code: "23514", Message: `new row for relation "contacts" violates check constraint "name_not_empty"`})
+	mock.ExpectExec(`^ROLLBACK TO SAVEPOINT atlas_sp_3$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_3$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_4$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(fmt.Sprintf(upsert, `\(\$1, \$2, \$3\)`)).
+		WithArgs(1, "Johnny", "john@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_4$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_5$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(fmt.Sprintf(upsert, `\(\$1, \$2, \$3\)`)).
+		WithArgs(4, "", "nobody@example.com").
+		WillReturnError(&pq.Error{Code: "23514", Message: `new row for relation "contacts" violates check constraint "name_not_empty"`})
+	mock.ExpectExec(`^ROLLBACK TO SAVEPOINT atlas_sp_5$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_5$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	n, err = BatchCreate(ctx, []*ContactORM{
+		{Id: 1, Name: "Johnny", Email: "john@example.com"},
+		{Id: 4, Email: "nobody@example.com"},
+	}, OnConflictUpdate([]string{"email"}, "name"))
+	if n != 1 {
+		t.Errorf("unexpected number of upserted contacts %d - expected: 1", n)
+	}
+	st, ok := err.(interface{ GRPCStatus() *status.Status })
+	if !ok {
+		t.Fatalf("unexpected error %v - expected error container", err)
+	}
+	if st.GRPCStatus().Code() != codes.InvalidArgument || st.GRPCStatus().Message() != "1 of 2 rows failed" {
+		t.Errorf("unexpected status %v", st.GRPCStatus())
+	}
+	details := st.GRPCStatus().Details()
+	if len(details) != 1 {
+		t.Fatalf("unexpected details %v", details)
+	}
+	if d := details[0].(*errdetails.TargetInfo); d.GetTarget() != "1" || codes.Code(d.GetCode()) != codes.InvalidArgument ||
+		d.GetMessage() != `new row for relation "contacts" violates check constraint "name_not_empty"` {
+		t.Errorf("unexpected detail %v", d)
+	}
+
+	// other errors abort the batch
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_6$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^INSERT INTO "contacts" \("id", "name", "email"\) VALUES \(\$1, \$2, \$3\) ON CONFLICT DO NOTHING$`).
+		WithArgs(1, "John", "john@example.com").
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectExec(`^ROLLBACK TO SAVEPOINT atlas_sp_6$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_6$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	if _, err := BatchCreate(ctx, contacts[:1:1], OnConflictDoNothing()); err == nil || err.Error() != "connection reset" {
+		t.Errorf("unexpected error %v - expected: connection reset", err)
+	}
+
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_7$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^ROLLBACK TO SAVEPOINT atlas_sp_7$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_7$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	if _, err := BatchCreate(ctx, contacts, OnConflictUpdate(nil)); err == nil {
+		t.Error("expected error of missing conflict columns")
+	}
+
+	// the batch upserting the same row twice is upserted row by row
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_8$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(fmt.Sprintf(upsert, `\(\$1, \$2, \$3\), \(\$4, \$5, \$6\)`)).
+		WithArgs(1, "Johnny", "john@example.com", 1, "John", "john@example.com").
+		WillReturnError(&pq.Error{Code: "21000", Message: "ON CONFLICT DO UPDATE command cannot affect row a second time"})
+	mock.ExpectExec(`^ROLLBACK TO SAVEPOINT atlas_sp_8$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_8$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_9$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(fmt.Sprintf(upsert, `\(\$1, \$2, \$3\)`)).
+		WithArgs(1, "Johnny", "john@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_9$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_10$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(fmt.Sprintf(upsert, `\(\$1, \$2, \$3\)`)).
+		WithArgs(1, "John", "john@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_10$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	n, err = BatchCreate(ctx, []*ContactORM{
+		{Id: 1, Name: "Johnny", Email: "john@example.com"},
+		{Id: 1, Name: "John", Email: "john@example.com"},
+	}, OnConflictUpdate([]string{"email"}, "name"))
+	if err != nil || n != 2 {
+		t.Errorf("unexpected result %d, %v - expected: 2 upserted contacts", n, err)
+	}
+
+	mock.ExpectCommit()
+	if err := txn.Commit(ctx); err != nil {
+		t.Errorf("failed to commit transaction - %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
+
+func TestBatchCreateLimits(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	txn := &Transaction{parent: gdb}
+	ctx := NewContext(context.Background(), txn)
+
+	// the batch exceeding the number of statement arguments is split
+	contacts := make([]ContactORM, maxBindParams/2+1)
+	for i := range contacts {
+		contacts[i] = ContactORM{Name: "John", Email: fmt.Sprintf("john%d@example.com", i)}
+	}
+	rows := sqlmock.NewRows([]string{"id"})
+	for i := 1; i < len(contacts); i++ {
+		rows.AddRow(i)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`^INSERT INTO "contacts" \("name", "email"\) VALUES \(\$1, \$2\), .*, \(\$65533, \$65534\) RETURNING "id"$`).
+		WillReturnRows(rows)
+	mock.ExpectQuery(`^INSERT INTO "contacts" \("name", "email"\) VALUES \(\$1, \$2\) RETURNING "id"$`).
+		WithArgs("John", fmt.Sprintf("john%d@example.com", len(contacts)-1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(len(contacts)))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	n, err := BatchCreate(ctx, contacts, WithBatchSize(len(contacts)))
+	if err != nil {
+		t.Fatalf("failed to create contacts - %s", err)
+	}
+	if n != int64(len(contacts)) || contacts[len(contacts)-1].Id != int64(len(contacts)) {
+		t.Errorf("unexpected number of created contacts %d - expected: %d", n, len(contacts))
+	}
+
+	// conflicting rows are skipped if there is nothing to update
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^INSERT INTO "contacts" \("id", "name", "email"\) VALUES \(\$1, \$2, \$3\) ON CONFLICT \("name", "email"\) DO NOTHING$`).
+		WithArgs(1, "John", "john@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	n, err = BatchCreate(ctx, []*ContactORM{{Id: 1, Name: "John", Email: "john@example.com"}}, OnConflictUpdate([]string{"name", "email"}))
+	if err != nil || n != 0 {
+		t.Errorf("unexpected result %d, %v - expected: no upserted contacts", n, err)
+	}
+
+	mock.ExpectCommit()
+	if err := txn.Commit(ctx); err != nil {
+		t.Errorf("failed to commit transaction - %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
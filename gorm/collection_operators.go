@@ -3,6 +3,7 @@ package gorm
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
@@ -23,10 +24,49 @@ type PaginationConverter interface {
 	PaginationToGorm(ctx context.Context, p *query.Pagination) (offset, limit int32)
 }
 
+// CursorConverter is implemented by pagination converters that support
+// keyset (cursor) pagination.
+type CursorConverter interface {
+	CursorToGorm(ctx context.Context, c *query.PageCursor, s *query.Sorting, obj interface{}) (string, []interface{}, error)
+}
+
+// KeysetConverter is implemented by cursor converters that apply keyset pagination
+// only if it is enabled explicitly, see DefaultPaginationConverter.Keyset.
+type KeysetConverter interface {
+	KeysetPagination() bool
+}
+
+// PageTokenConverter is implemented by pagination converters that encode and
+// decode page tokens. Decoded tokens are expected to be verified against scope,
+// the hash of collection operators of the current request (see query.PageTokenScope).
+type PageTokenConverter interface {
+	PageTokenToGorm(ctx context.Context, p *query.Pagination, scope string) (*query.PageToken, error)
+	PageTokenFromGorm(ctx context.Context, t *query.PageToken, scope string) (string, error)
+}
+
+// CollectionPolicyConverter is implemented by converters that restrict filtering
+// and sorting collection operators, see query.CollectionPolicy.
+type CollectionPolicyConverter interface {
+	ValidateCollectionOperators(ctx context.Context, f *query.Filtering, s *query.Sorting) error
+}
+
 type SearchingConverter interface {
 	SearchingToGorm(ctx context.Context, s *query.Searching, fieldsForFTS []string, obj interface{}) (string, error)
 }
 
+// SearchingQueryConverter is implemented by searching converters that convert the search query
+// to the argument of the condition returned by SearchingToGorm, otherwise SearchQuery is used.
+type SearchingQueryConverter interface {
+	SearchingQueryToGorm(ctx context.Context, s *query.Searching) string
+}
+
+// SearchingRankConverter is implemented by searching converters that rank records matching the search query.
+// The returned expression has the same placeholder for the search query as the condition returned by
+// SearchingToGorm, an empty expression means records are not ranked.
+type SearchingRankConverter interface {
+	SearchingRankToGorm(ctx context.Context, s *query.Searching, fieldsForFTS []string, obj interface{}) (string, error)
+}
+
 type CollectionOperatorsConverter interface {
 	FilteringConditionConverter
 	SortingCriteriaConverter
@@ -35,12 +75,32 @@ type CollectionOperatorsConverter interface {
 	SearchingConverter
 }
 
+// ApplyCollectionOperatorsEx applies collection operators to gorm instance db.
+// If p requests server-driven pagination (page token is set), the page token is
+// decoded and verified if c implements PageTokenConverter. If c implements
+// CursorConverter and keyset pagination is not disabled (see KeysetConverter),
+// keyset pagination is applied: sorting is extended with primary key columns
+// of obj and the cursor is turned into a seek predicate.
+// If c implements CollectionPolicyConverter, f and s are validated by c first.
 func ApplyCollectionOperatorsEx(ctx context.Context, db *gorm.DB, obj interface{}, c CollectionOperatorsConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*gorm.DB, error) {
+	if pc, ok := c.(CollectionPolicyConverter); ok {
+		if err := pc.ValidateCollectionOperators(ctx, f, s); err != nil {
+			return nil, err
+		}
+	}
+
 	db, fAssocToJoin, err := ApplyFilteringEx(ctx, db, f, obj, c)
 	if err != nil {
 		return nil, err
 	}
 
+	if p.GetPageToken() != "" {
+		db, s, p, err = applyPageToken(ctx, db, obj, c, f, s, p, fs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	db, sAssocToJoin, err := ApplySortingEx(ctx, db, s, obj, c)
 	if err != nil {
 		return nil, err
@@ -67,6 +127,8 @@ func ApplyCollectionOperatorsEx(ctx context.Context, db *gorm.DB, obj interface{
 	return db, nil
 }
 
+// ApplyCollectionOperatorsWithSearchingEx applies collection operators and searching operator sc to gorm instance db.
+// If c implements SearchingRankConverter and neither sorting nor page token is requested, records are ordered by rank.
 func ApplyCollectionOperatorsWithSearchingEx(ctx context.Context, db *gorm.DB, obj interface{}, c CollectionOperatorsConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection, sc *query.Searching, fieldsForFTS []string) (*gorm.DB, error) {
 	db, err := ApplyCollectionOperatorsEx(ctx, db, obj, c, f, s, p, fs)
 	if err != nil {
@@ -78,6 +140,12 @@ func ApplyCollectionOperatorsWithSearchingEx(ctx context.Context, db *gorm.DB, o
 		return nil, err
 	}
 
+	if len(s.GetCriterias()) == 0 && p.GetPageToken() == "" {
+		if rc, ok := c.(SearchingRankConverter); ok {
+			return ApplySearchRankingEx(ctx, db, sc, obj, fieldsForFTS, rc)
+		}
+	}
+
 	return db, nil
 }
 
@@ -87,39 +155,62 @@ func ApplySearchingEx(ctx context.Context, db *gorm.DB, s *query.Searching, obj
 	if err != nil {
 		return nil, err
 	}
-	if s != nil && s.Query != "" {
-		s.Query = strings.TrimSpace(s.Query)
-		s.Query = strings.ReplaceAll(s.Query, ":", " ")
-		splChar := []string{"(", ")", "|", "+", "<", "'", "&", "!", "%", ";"}
-		for _, spl := range splChar {
-			if strings.Contains(s.Query, spl) {
-				s.Query = ""
-				return db.Where(str, s.Query), nil
-			}
-		}
-		s.Query = strings.Join(strings.Fields(s.Query), " ")
-		if s.Query != "" {
-			s.Query = strings.ReplaceAll(s.Query, " ", " & ")
-			s.Query = s.Query + ":*"
-		}
-		return db.Where(str, s.Query), nil
+	if s.GetQuery() == "" {
+		return db, nil
 	}
-	return db, nil
+	return db.Where(str, searchingQuery(ctx, s, c)), nil
+}
+
+// ApplySearchRankingEx orders records of gorm instance db by their rank of searching operator s, most relevant first.
+func ApplySearchRankingEx(ctx context.Context, db *gorm.DB, s *query.Searching, obj interface{}, fieldsForFTS []string, c SearchingRankConverter) (*gorm.DB, error) {
+	if s.GetQuery() == "" {
+		return db, nil
+	}
+	rank, err := c.SearchingRankToGorm(ctx, s, fieldsForFTS, obj)
+	if err != nil || rank == "" {
+		return db, err
+	}
+	return db.Order(gorm.Expr(rank+" DESC", searchingQuery(ctx, s, c))), nil
+}
+
+// searchingQuery returns the argument of the searching condition of c for search query s.
+func searchingQuery(ctx context.Context, s *query.Searching, c interface{}) string {
+	if qc, ok := c.(SearchingQueryConverter); ok {
+		return qc.SearchingQueryToGorm(ctx, s)
+	}
+	return SearchQuery(s.GetQuery())
 }
 
 // ApplyFiltering applies filtering operator f to gorm instance db.
+// Soft deleted records are excluded unless f requests to include them.
+// Associations to be joined are returned, unless f refers to has many or many to many associations,
+// then all associations are joined within a subquery that records are matched against by primary key.
 func ApplyFilteringEx(ctx context.Context, db *gorm.DB, f *query.Filtering, obj interface{}, c FilteringConditionConverter) (*gorm.DB, map[string]struct{}, error) {
 	str, args, assocToJoin, err := FilteringToGormEx(ctx, f, obj, c)
 	if err != nil {
 		return nil, nil, err
 	}
-	if str != "" {
-		return db.Where(str, args...), assocToJoin, nil
+	if f.GetIncludeDeleted() {
+		db = db.Unscoped()
 	}
-	return db, nil, nil
+	if str == "" {
+		return db, nil, nil
+	}
+	for assoc := range assocToJoin {
+		if !isToManyAssociation(obj, assoc) {
+			continue
+		}
+		str, args, err = associationSubquery(db, assocToJoin, obj, str, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db.Where(str, args...), nil, nil
+	}
+	return db.Where(str, args...), assocToJoin, nil
 }
 
 // ApplySorting applies sorting operator s to gorm instance db.
+// Sorting by has many and many to many associations is not supported.
 func ApplySortingEx(ctx context.Context, db *gorm.DB, s *query.Sorting, obj interface{}, c SortingCriteriaConverter) (*gorm.DB, map[string]struct{}, error) {
 	var crs []string
 	var assocToJoin map[string]struct{}
@@ -129,6 +220,9 @@ func ApplySortingEx(ctx context.Context, db *gorm.DB, s *query.Sorting, obj inte
 			return nil, nil, err
 		}
 		if assoc != "" {
+			if isToManyAssociation(obj, assoc) {
+				return nil, nil, fmt.Errorf("Cannot sort by to many association %s", assoc)
+			}
 			if assocToJoin == nil {
 				assocToJoin = make(map[string]struct{})
 			}
@@ -162,19 +256,168 @@ func ApplySorting(ctx context.Context, db *gorm.DB, s *query.Sorting, obj interf
 }
 
 // JoinAssociations joins obj's associations from assoc to the current gorm query.
+// Associations may be paths of nested associations, e.g. "Owner.Org", every association
+// on a path is joined once and aliased by its path, e.g. owner and owner__org,
+// so the same table can be joined by several associations.
+// Associations are joined in alphabetical order, so the resulting query is stable.
+// Soft deleted records of associations are not joined unless db is unscoped,
+// records of other tenants are not joined if db is scoped to a tenant, see WithTenancy.
+// Joining has many and many to many associations duplicates records of obj's model,
+// ApplyFilteringEx matches them by a subquery instead.
 func JoinAssociations(ctx context.Context, db *gorm.DB, assoc map[string]struct{}, obj interface{}) (*gorm.DB, error) {
-	for k := range assoc {
-		tableName, sourceKeys, targetKeys, err := JoinInfo(ctx, obj, k)
+	joins, args, err := associationJoinClauses(db, assoc, obj)
+	if err != nil {
+		return nil, err
+	}
+	for i, join := range joins {
+		db = db.Joins(join, args[i]...)
+	}
+	return db, nil
+}
+
+// associationJoinClauses returns JOIN clauses of obj's associations from assoc along with their arguments,
+// see JoinAssociations.
+func associationJoinClauses(db *gorm.DB, assoc map[string]struct{}, obj interface{}) ([]string, [][]interface{}, error) {
+	unscoped := isUnscoped(db, obj)
+	var clauses []string
+	var clauseArgs [][]interface{}
+	for _, k := range associationPaths(assoc) {
+		joins, err := associationJoins(obj, k)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		for _, j := range joins {
+			var keyPairs []string
+			for i, k := range j.sourceKeys {
+				keyPairs = append(keyPairs, k+" = "+j.targetKeys[i])
+			}
+			var args []interface{}
+			if j.model != nil {
+				if cond := softDeleteCondition(j.model, j.alias); cond != "" && !unscoped {
+					keyPairs = append(keyPairs, cond)
+				}
+				var cond string
+				cond, args = tenantCondition(db, j.model, j.alias)
+				if cond != "" {
+					keyPairs = append(keyPairs, cond)
+				}
+			}
+			clauses = append(clauses, fmt.Sprintf("LEFT JOIN %s %s ON %s", j.table, j.alias, strings.Join(keyPairs, " AND ")))
+			clauseArgs = append(clauseArgs, args)
 		}
-		var keyPairs []string
-		for i, k := range sourceKeys {
-			keyPairs = append(keyPairs, k+" = "+targetKeys[i])
+	}
+	return clauses, clauseArgs, nil
+}
+
+// associationSubquery returns the condition that matches records of obj's model by primary key
+// against a subquery, which joins associations from assoc and applies filtering condition str,
+// so records are not duplicated by joined has many and many to many associations.
+func associationSubquery(db *gorm.DB, assoc map[string]struct{}, obj interface{}, str string, args []interface{}) (string, []interface{}, error) {
+	objType := indirectType(reflect.TypeOf(obj))
+	table := tableName(objType)
+	keys := primaryKeyTags(objType)
+	if len(keys) == 0 {
+		return "", nil, fmt.Errorf("%s has no primary key to filter by to many associations", objType)
+	}
+	for i, k := range keys {
+		keys[i] = table + "." + k
+	}
+	joins, joinArgs, err := associationJoinClauses(db, assoc, obj)
+	if err != nil {
+		return "", nil, err
+	}
+	var subArgs []interface{}
+	for _, a := range joinArgs {
+		subArgs = append(subArgs, a...)
+	}
+	key := strings.Join(keys, ", ")
+	if len(keys) > 1 {
+		key = "(" + key + ")"
+	}
+	cond := fmt.Sprintf("%s IN (SELECT %s FROM %s %s WHERE %s)", key, strings.Join(keys, ", "), table, strings.Join(joins, " "), str)
+	return cond, append(subArgs, args...), nil
+}
+
+// applyPageToken applies the page token of p to gorm instance db and returns
+// sorting and pagination that are to be applied afterwards.
+func applyPageToken(ctx context.Context, db *gorm.DB, obj interface{}, c CollectionOperatorsConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*gorm.DB, *query.Sorting, *query.Pagination, error) {
+	cc, isCursor := cursorConverter(c)
+	tc, ok := c.(PageTokenConverter)
+	if !ok {
+		if !isCursor {
+			return db, s, p, nil
 		}
-		alias := gorm.ToDBName(k)
-		join := fmt.Sprintf("LEFT JOIN %s %s ON %s", tableName, alias, strings.Join(keyPairs, " AND "))
-		db = db.Joins(join)
+		s = CursorSorting(s, obj)
+		db, err := ApplyCursorEx(ctx, db, p, s, obj, cc)
+		return db, s, p, err
+	}
+
+	token, err := tc.PageTokenToGorm(ctx, p, query.PageTokenScope(f, s, fs))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if isCursor {
+		s = CursorSorting(s, obj)
+	}
+	if token == nil {
+		return db, s, p, nil
+	}
+	if token.Cursor == nil {
+		return db, s, tokenPagination(token, p), nil
+	}
+	if !isCursor {
+		return nil, nil, nil, fmt.Errorf("%T does not support cursor page tokens", c)
+	}
+	str, args, err := cc.CursorToGorm(ctx, token.Cursor, s, obj)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if str != "" {
+		db = db.Where(str, args...)
+	}
+	return db, s, tokenPagination(token, p), nil
+}
+
+// tokenPagination returns the pagination the page of page token t decoded from p is fetched with.
+// The page size is bound to the token, so it cannot be changed while paging.
+func tokenPagination(t *query.PageToken, p *query.Pagination) *query.Pagination {
+	if t == nil || (t.Cursor != nil && t.Limit <= 0) {
+		return p
+	}
+	return &query.Pagination{
+		PageToken:         p.GetPageToken(),
+		Offset:            t.Offset,
+		Limit:             t.Limit,
+		IsTotalSizeNeeded: p.GetIsTotalSizeNeeded(),
+	}
+}
+
+// cursorConverter returns c as CursorConverter if c supports keyset pagination and it is enabled.
+func cursorConverter(c interface{}) (CursorConverter, bool) {
+	cc, ok := c.(CursorConverter)
+	if kc, isKeyset := c.(KeysetConverter); ok && isKeyset {
+		return cc, kc.KeysetPagination()
+	}
+	return cc, ok
+}
+
+// ApplyCursorEx applies the seek predicate of the cursor page token of p to gorm
+// instance db. s is expected to be the sorting extended by CursorSorting.
+// Page tokens that are not cursor tokens, e.g. "null" for the first page, are ignored.
+func ApplyCursorEx(ctx context.Context, db *gorm.DB, p *query.Pagination, s *query.Sorting, obj interface{}, c CursorConverter) (*gorm.DB, error) {
+	if !query.IsCursorToken(p.GetPageToken()) {
+		return db, nil
+	}
+	cursor, err := query.DecodeCursorToken(p.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	str, args, err := c.CursorToGorm(ctx, cursor, s, obj)
+	if err != nil {
+		return nil, err
+	}
+	if str != "" {
+		return db.Where(str, args...), nil
 	}
 	return db, nil
 }
@@ -9,8 +9,11 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/infobloxopen/atlas-app-toolkit/v2/gateway"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
@@ -125,3 +128,118 @@ func TestApplyCollectionOperators(t *testing.T) {
 		t.Fatal("no error returned")
 	}
 }
+
+func TestApplyCollectionOperatorsPolicy(t *testing.T) {
+	ctx := context.Background()
+	c := NewDefaultPbToOrmConverter(&PersonProto{}).(*DefaultPbToOrmConverter)
+	c.Policy = query.NewCollectionPolicy().Filterable("age", "<=", ">").Filterable("sub_person.name", "==").Sortable("age")
+
+	f, err := query.ParseFiltering("age <= 25 and sub_person.name == 'Mike'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := query.ParseSorting("age desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, mock := setUp(t)
+	gormDB, err = ApplyCollectionOperatorsEx(ctx, gormDB, &Person{}, c, f, s, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT "people".* FROM "people" LEFT JOIN sub_people sub_person ON people.id = sub_person.person_id WHERE (((people.age <= $1) AND (sub_person.name = $2))) ORDER BY people.age desc`)).
+		WithArgs(25.0, "Mike").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	var actual []Person
+	gormDB.Find(&actual)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	f, err = query.ParseFiltering("age >= 25 or name ~ 'M.*'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err = query.ParseSorting("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, _ = setUp(t)
+	_, err = ApplyCollectionOperatorsEx(ctx, gormDB, &Person{}, c, f, s, nil, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestApplyFilteringCollectionOperators(t *testing.T) {
+	f, err := query.ParseFiltering("tags has 'a' and labels contains ['b', 'c'] and tags in ['d']")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, mock := setUp(t)
+	gormDB, _, err = ApplyFiltering(context.Background(), gormDB, f, &Entity{}, &EntityProto{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "entities" WHERE ((((entities.tags ? $1) AND (entities.labels @> $2)) AND (entities.tags ?| $3)))`)).
+		WithArgs("a", `{"b","c"}`, `{"d"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	var actual []Entity
+	gormDB.Find(&actual)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSortingCriteriaToGorm(t *testing.T) {
+	tests := []struct {
+		sort  string
+		obj   interface{}
+		order []string
+		assoc []string
+	}{
+		{
+			sort:  "name desc, parent.name nulls first, age asc nulls last",
+			obj:   &Person{},
+			order: []string{"people.name desc", "parent.name nulls first", "people.age nulls last"},
+			assoc: []string{"", "Parent", ""},
+		},
+		{
+			sort:  "tags.Location.City desc nulls last, nested_entity.nested_field1",
+			obj:   &Entity{},
+			order: []string{"entities.tags #>> '{Location,City}' desc nulls last", "nested_entity.nested_field1"},
+			assoc: []string{"", "NestedEntity"},
+		},
+	}
+
+	c := &DefaultSortingCriteriaConverter{}
+	for _, test := range tests {
+		s, err := query.ParseSorting(test.sort)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, cr := range s.GetCriterias() {
+			order, assoc, err := c.SortingCriteriaToGorm(context.Background(), cr, test.obj)
+			assert.NoError(t, err, cr.GetTag())
+			assert.Equal(t, test.order[i], order)
+			assert.Equal(t, test.assoc[i], assoc)
+		}
+	}
+}
+
+func TestApplySortingNulls(t *testing.T) {
+	s, err := query.ParseSorting("parent.name desc nulls last")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, mock := setUp(t)
+	gormDB, err = ApplyCollectionOperatorsEx(context.Background(), gormDB, &Person{}, NewDefaultPbToOrmConverter(&PersonProto{}), nil, s, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT "people".* FROM "people" LEFT JOIN parents parent ON people.parent_id = parent.id ORDER BY parent.name desc nulls last`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	var actual []Person
+	gormDB.Find(&actual)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -2,13 +2,19 @@ package gorm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
+	jgorm "github.com/jinzhu/gorm"
+	"github.com/jinzhu/gorm/dialects/postgres"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
 
+	"github.com/infobloxopen/atlas-app-toolkit/v2/errors"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/resource"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/util"
@@ -27,19 +33,43 @@ type DefaultFilteringConditionConverter struct {
 // DefaultSortingCriteriaConverter performs default convertion for Sorting collection operator
 type DefaultSortingCriteriaConverter struct{}
 
-// DefaultPaginationConverter performs default convertion for Paging collection operator
-type DefaultPaginationConverter struct{}
+// DefaultPaginationConverter performs default convertion for Paging collection operator.
+// If Codec is set, page tokens are encoded and decoded with it, otherwise only
+// unsigned cursor page tokens are supported.
+// Keyset enables keyset (cursor) pagination, otherwise page tokens are offset ones
+// and sorting is not extended with primary key columns.
+type DefaultPaginationConverter struct {
+	Codec  query.PageTokenCodec
+	Keyset bool
+}
+
+// KeysetPagination reports whether keyset pagination is enabled.
+func (converter *DefaultPaginationConverter) KeysetPagination() bool {
+	return converter.Keyset
+}
 
-// DefaultSearchingConverter performs default convertion for Searching operator
-type DefaultSearchingConverter struct{}
+// DefaultSearchingConverter performs default convertion for Searching operator.
+// Records are matched against the document built from fieldsForFTS by GetFullTextSearchDBMask or, if Column is set,
+// against the tsvector column, e.g. a generated column covered by a GIN index. Dictionary sets the text search
+// configuration, "simple" by default. The search query matches all its words, the last one as a prefix, see SearchQuery,
+// or, if WebSearch is set, parsed by websearch_to_tsquery, e.g. `"exact phrase" -excluded or alternative`.
+// If Rank is set, records are ordered by ts_rank unless sorting is requested.
+type DefaultSearchingConverter struct {
+	Dictionary string
+	Column     string
+	WebSearch  bool
+	Rank       bool
+}
 
-// DefaultPbToOrmConverter performs default convertion for all collection operators
+// DefaultPbToOrmConverter performs default convertion for all collection operators.
+// If Policy is set, filtering and sorting collection operators are validated against it.
 type DefaultPbToOrmConverter struct {
 	DefaultFilteringConditionConverter
 	DefaultSortingCriteriaConverter
 	DefaultFieldSelectionConverter
 	DefaultPaginationConverter
 	DefaultSearchingConverter
+	Policy *query.CollectionPolicy
 }
 
 // NewDefaultPbToOrmConverter creates default converter for all collection operators
@@ -50,9 +80,15 @@ func NewDefaultPbToOrmConverter(pb proto.Message) CollectionOperatorsConverter {
 		DefaultFieldSelectionConverter{},
 		DefaultPaginationConverter{},
 		DefaultSearchingConverter{},
+		nil,
 	}
 }
 
+// ValidateCollectionOperators validates filtering f and sorting s against the policy of the converter.
+func (converter *DefaultPbToOrmConverter) ValidateCollectionOperators(ctx context.Context, f *query.Filtering, s *query.Sorting) error {
+	return converter.Policy.Validate(f, s)
+}
+
 // LogicalOperatorToGorm returns GORM Plain SQL representation of the logical operator.
 func (converter *DefaultFilteringConditionConverter) LogicalOperatorToGorm(ctx context.Context, lop *query.LogicalOperator, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
 	var lres string
@@ -72,6 +108,12 @@ func (converter *DefaultFilteringConditionConverter) LogicalOperatorToGorm(ctx c
 		lres, largs, lAssocToJoin, err = converter.NumberArrayConditionToGorm(ctx, l.LeftNumberArrayCondition, obj)
 	case *query.LogicalOperator_LeftStringArrayCondition:
 		lres, largs, lAssocToJoin, err = converter.StringArrayConditionToGorm(ctx, l.LeftStringArrayCondition, obj)
+	case *query.LogicalOperator_LeftTimeCondition:
+		lres, largs, lAssocToJoin, err = converter.TimeConditionToGorm(ctx, l.LeftTimeCondition, obj)
+	case *query.LogicalOperator_LeftBoolCondition:
+		lres, largs, lAssocToJoin, err = converter.BoolConditionToGorm(ctx, l.LeftBoolCondition, obj)
+	case *query.LogicalOperator_LeftContainsCondition:
+		lres, largs, lAssocToJoin, err = converter.ContainsConditionToGorm(ctx, l.LeftContainsCondition, obj)
 	default:
 		return "", nil, nil, fmt.Errorf("%T type is not supported in Filtering", l)
 	}
@@ -95,6 +137,12 @@ func (converter *DefaultFilteringConditionConverter) LogicalOperatorToGorm(ctx c
 		rres, rargs, rAssocToJoin, err = converter.NumberArrayConditionToGorm(ctx, r.RightNumberArrayCondition, obj)
 	case *query.LogicalOperator_RightStringArrayCondition:
 		rres, rargs, rAssocToJoin, err = converter.StringArrayConditionToGorm(ctx, r.RightStringArrayCondition, obj)
+	case *query.LogicalOperator_RightTimeCondition:
+		rres, rargs, rAssocToJoin, err = converter.TimeConditionToGorm(ctx, r.RightTimeCondition, obj)
+	case *query.LogicalOperator_RightBoolCondition:
+		rres, rargs, rAssocToJoin, err = converter.BoolConditionToGorm(ctx, r.RightBoolCondition, obj)
+	case *query.LogicalOperator_RightContainsCondition:
+		rres, rargs, rAssocToJoin, err = converter.ContainsConditionToGorm(ctx, r.RightContainsCondition, obj)
 	default:
 		return "", nil, nil, fmt.Errorf("%T type is not supported in Filtering", r)
 	}
@@ -279,6 +327,60 @@ func (converter *DefaultFilteringConditionConverter) NumberConditionToGorm(ctx c
 	return fmt.Sprintf("%s(%s %s ?)", neg, dbName, o), []interface{}{c.Value}, assocToJoin, nil
 }
 
+// TimeConditionToGorm returns GORM Plain SQL representation of the time condition.
+func (converter *DefaultFilteringConditionConverter) TimeConditionToGorm(ctx context.Context, c *query.TimeCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var assocToJoin map[string]struct{}
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	var o string
+	switch c.Type {
+	case query.TimeCondition_EQ:
+		o = "="
+	case query.TimeCondition_GT:
+		o = ">"
+	case query.TimeCondition_GE:
+		o = ">="
+	case query.TimeCondition_LT:
+		o = "<"
+	case query.TimeCondition_LE:
+		o = "<="
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s %s ?)", neg, dbName, o), []interface{}{c.Value.AsTime()}, assocToJoin, nil
+}
+
+// BoolConditionToGorm returns GORM Plain SQL representation of the bool condition.
+func (converter *DefaultFilteringConditionConverter) BoolConditionToGorm(ctx context.Context, c *query.BoolCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var assocToJoin map[string]struct{}
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	var o string
+	switch c.Type {
+	case query.BoolCondition_EQ:
+		o = "="
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s %s ?)", neg, dbName, o), []interface{}{c.Value}, assocToJoin, nil
+}
+
 // NullConditionToGorm returns GORM Plain SQL representation of the null condition.
 func (converter *DefaultFilteringConditionConverter) NullConditionToGorm(ctx context.Context, c *query.NullCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
 	var assocToJoin map[string]struct{}
@@ -350,6 +452,15 @@ func (converter *DefaultFilteringConditionConverter) StringArrayConditionToGorm(
 		neg = "NOT"
 	}
 
+	// in conditions on collection columns test whether the collection has any of the values,
+	// unless values of a jsonb column are JSON documents to compare the column with
+	switch {
+	case IsArrayCondition(ctx, c.FieldPath, obj):
+		return fmt.Sprintf("%s(%s && ?)", neg, dbName), []interface{}{pq.StringArray(c.Values)}, assocToJoin, nil
+	case len(c.FieldPath) == 1 && IsJSONCondition(ctx, c.FieldPath, obj) && !isRawJSON(c.Values...):
+		return fmt.Sprintf("%s(%s ? ?)", neg, dbName), []interface{}{jsonbOperator("?|"), pq.StringArray(c.Values)}, assocToJoin, nil
+	}
+
 	values := make([]interface{}, 0, len(c.Values))
 	placeholder := ""
 	for _, str := range c.Values {
@@ -365,11 +476,29 @@ func (converter *DefaultFilteringConditionConverter) StringArrayConditionToGorm(
 	return fmt.Sprintf("(%s %s %s (%s))", dbName, neg, o, strings.TrimSuffix(placeholder, ", ")), values, assocToJoin, nil
 }
 
+// SortingCriteriaToGorm returns GORM representation of the sort criteria.
+// Tag of the criteria can refer to a field of an association, e.g. owner.name, in which case
+// the association is returned to be joined, or to a nested key of a postgres.Jsonb field, e.g. info.address.city.
 func (converter *DefaultSortingCriteriaConverter) SortingCriteriaToGorm(ctx context.Context, cr *query.SortCriteria, obj interface{}) (string, string, error) {
-	dbCr, assoc, err := HandleFieldPath(ctx, strings.Split(cr.GetTag(), "."), obj)
+	var (
+		dbCr, assoc string
+		err         error
+	)
+	fieldPath := strings.Split(cr.GetTag(), ".")
+	if len(fieldPath) > 1 && IsJSONCondition(ctx, fieldPath, obj) {
+		dbCr, assoc, err = HandleJSONFieldPath(ctx, fieldPath, obj)
+	} else {
+		dbCr, assoc, err = HandleFieldPath(ctx, fieldPath, obj)
+	}
 	if cr.IsDesc() {
 		dbCr += " desc"
 	}
+	switch cr.GetNulls() {
+	case query.SortCriteria_NULLS_FIRST:
+		dbCr += " nulls first"
+	case query.SortCriteria_NULLS_LAST:
+		dbCr += " nulls last"
+	}
 	return dbCr, assoc, err
 }
 
@@ -380,8 +509,149 @@ func (converter *DefaultPaginationConverter) PaginationToGorm(ctx context.Contex
 	return 0, 0
 }
 
+// PageTokenToGorm decodes the page token of p. Tokens decoded by Codec are
+// verified to be issued for collection operators with the given scope.
+// Returns nil if p requests the first page.
+func (converter *DefaultPaginationConverter) PageTokenToGorm(ctx context.Context, p *query.Pagination, scope string) (*query.PageToken, error) {
+	ptoken := p.GetPageToken()
+	if ptoken == "" || ptoken == "null" {
+		return nil, nil
+	}
+	if converter.Codec == nil {
+		if !query.IsCursorToken(ptoken) {
+			return nil, nil
+		}
+		c, err := query.DecodeCursorToken(ptoken)
+		if err != nil {
+			return nil, err
+		}
+		return &query.PageToken{Cursor: c}, nil
+	}
+	t, err := converter.Codec.Decode(ptoken)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Validate(scope); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// PageTokenFromGorm encodes page token t issued for collection operators with the given scope.
+func (converter *DefaultPaginationConverter) PageTokenFromGorm(ctx context.Context, t *query.PageToken, scope string) (string, error) {
+	if converter.Codec == nil {
+		if t.Cursor != nil {
+			return query.EncodeCursorToken(t.Cursor)
+		}
+		return query.EncodePageToken(t.Offset, t.Limit), nil
+	}
+	tc := *t
+	tc.Scope = scope
+	return converter.Codec.Encode(&tc)
+}
+
+// SearchingToGorm returns the full-text search condition of records matching s with a placeholder for the search query.
 func (converter *DefaultSearchingConverter) SearchingToGorm(ctx context.Context, s *query.Searching, fieldsForFTS []string, obj interface{}) (string, error) {
-	mask := GetFullTextSearchDBMask(obj, fieldsForFTS, " ")
-	fullTextSearchQuery := FormFullTextSearchQuery(mask)
-	return fullTextSearchQuery, nil
+	return converter.document(fieldsForFTS, obj) + " @@ " + converter.tsquery(), nil
+}
+
+// SearchingQueryToGorm returns the search query of s in the syntax of the searching condition.
+func (converter *DefaultSearchingConverter) SearchingQueryToGorm(ctx context.Context, s *query.Searching) string {
+	if converter.WebSearch {
+		return strings.TrimSpace(s.GetQuery())
+	}
+	return SearchQuery(s.GetQuery())
+}
+
+// SearchingRankToGorm returns the ts_rank of records matching s if Rank is set.
+func (converter *DefaultSearchingConverter) SearchingRankToGorm(ctx context.Context, s *query.Searching, fieldsForFTS []string, obj interface{}) (string, error) {
+	if !converter.Rank {
+		return "", nil
+	}
+	return "ts_rank(" + converter.document(fieldsForFTS, obj) + ", " + converter.tsquery() + ")", nil
+}
+
+func (converter *DefaultSearchingConverter) dictionary() string {
+	if converter.Dictionary == "" {
+		return "'simple'"
+	}
+	return quoteLiteral(converter.Dictionary)
+}
+
+// document returns the tsvector records of obj are matched against.
+func (converter *DefaultSearchingConverter) document(fieldsForFTS []string, obj interface{}) string {
+	if converter.Column == "" {
+		return "to_tsvector(" + converter.dictionary() + ", " + GetFullTextSearchDBMask(obj, fieldsForFTS, " ") + ")"
+	}
+	if strings.Contains(converter.Column, ".") {
+		return converter.Column
+	}
+	return tableName(indirectType(reflect.TypeOf(obj))) + "." + converter.Column
+}
+
+func (converter *DefaultSearchingConverter) tsquery() string {
+	if converter.WebSearch {
+		return "websearch_to_tsquery(" + converter.dictionary() + ", ?)"
+	}
+	return "to_tsquery(" + converter.dictionary() + ", ?)"
+}
+
+// ContainsConditionToGorm returns GORM Plain SQL representation of the contains condition.
+// Conditions on postgres.Jsonb fields are converted to jsonb @>, ? and ?& operators, conditions
+// on other fields to array @> operator, so that GIN indexes on the columns can be used.
+func (converter *DefaultFilteringConditionConverter) ContainsConditionToGorm(ctx context.Context, c *query.ContainsCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var (
+		assocToJoin   map[string]struct{}
+		dbName, assoc string
+		err           error
+	)
+	if len(c.Values) == 0 {
+		return "", nil, nil, fmt.Errorf("%s condition on %s requires at least one value", strings.ToLower(c.Type.String()), strings.Join(c.FieldPath, "."))
+	}
+	isJSON := IsJSONCondition(ctx, c.FieldPath, obj)
+	if isJSON {
+		dbName, assoc, err = handleJSONFieldPath(c.FieldPath, obj, "#>")
+	} else {
+		dbName, assoc, err = HandleFieldPath(ctx, c.FieldPath, obj)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+	// containment is defined only for arrays and JSON documents
+	if !isJSON && !isArrayField(ctx, c.FieldPath, obj) {
+		fp := strings.Join(c.FieldPath, ".")
+		return "", nil, nil, errors.NewContainer(codes.InvalidArgument, "Collection operators validation failed.").
+			WithField(fp, "Operator %s is not supported by %s, it is neither an array nor a JSON field.", strings.ToLower(c.Type.String()), fp)
+	}
+
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+
+	switch {
+	case isJSON && c.Type == query.ContainsCondition_HAS && len(c.Values) == 1:
+		return fmt.Sprintf("%s(%s ? ?)", neg, dbName), []interface{}{jsonbOperator("?"), c.Values[0]}, assocToJoin, nil
+	case isJSON && c.Type == query.ContainsCondition_HAS:
+		return fmt.Sprintf("%s(%s ? ?)", neg, dbName), []interface{}{jsonbOperator("?&"), pq.StringArray(c.Values)}, assocToJoin, nil
+	case isJSON:
+		value, err := json.Marshal(c.Values)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return fmt.Sprintf("%s(%s @> ?)", neg, dbName), []interface{}{postgres.Jsonb{RawMessage: value}}, assocToJoin, nil
+	default:
+		return fmt.Sprintf("%s(%s @> ?)", neg, dbName), []interface{}{pq.StringArray(c.Values)}, assocToJoin, nil
+	}
+}
+
+// jsonbOperator returns an argument of a GORM Plain SQL condition that renders jsonb operator o
+// in place of its placeholder, since GORM treats every question mark of a condition as a placeholder,
+// e.g. ("(tags ? ?)", jsonbOperator("?"), "a") is rendered as tags ? $1.
+func jsonbOperator(o string) interface{} {
+	return jgorm.Expr(o)
 }
@@ -0,0 +1,157 @@
+package gorm
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+// CountMode is the way the number of records is computed by Count.
+type CountMode int
+
+const (
+	// ExactCount is the number of records counted by COUNT(*).
+	ExactCount CountMode = iota
+	// PlannerEstimate is the number of rows the Postgres planner estimates the query to return, see EXPLAIN.
+	PlannerEstimate
+	// TableEstimate is the number of rows of the table estimated from Postgres statistics, see pg_class.reltuples.
+	TableEstimate
+)
+
+// String returns the name of mode m.
+func (m CountMode) String() string {
+	switch m {
+	case ExactCount:
+		return "exact"
+	case PlannerEstimate:
+		return "planner estimate"
+	case TableEstimate:
+		return "table estimate"
+	}
+	return fmt.Sprintf("CountMode(%d)", int(m))
+}
+
+// Approximate reports whether the number of records is an estimate.
+func (m CountMode) Approximate() bool {
+	return m != ExactCount
+}
+
+type countOptions struct {
+	approximate bool
+	threshold   int64
+}
+
+// CountOption configures Count.
+type CountOption func(*countOptions)
+
+// WithApproximateCount makes Count return an estimate of the number of records instead of counting them
+// if the estimate is not less than threshold. Counting records of huge tables is slow in Postgres,
+// while the estimate is cheap and accurate enough to display the total size of a collection.
+func WithApproximateCount(threshold int64) CountOption {
+	return func(o *countOptions) {
+		o.approximate = true
+		o.threshold = threshold
+	}
+}
+
+// Count returns the number of records of obj's model matching filtering f and the mode the number was computed in.
+// The same filtering and joins are applied as by ApplyCollectionOperatorsEx, while sorting and pagination are not,
+// so the number can be used as total_size of query.PageInfo.
+// If the number is estimated, see WithApproximateCount, the estimate of a query that has no conditions is taken from
+// the table statistics, otherwise from the query plan.
+func Count(ctx context.Context, db *gorm.DB, obj interface{}, c FilteringConditionConverter, f *query.Filtering, opts ...CountOption) (int64, CountMode, error) {
+	o := &countOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.approximate {
+		estimate, mode, err := estimateCount(ctx, db, obj, c, f)
+		if err != nil {
+			return 0, ExactCount, err
+		}
+		if estimate >= 0 && estimate >= o.threshold {
+			return estimate, mode, nil
+		}
+	}
+	db, assoc, err := ApplyFilteringEx(ctx, db.Model(obj), f, obj, c)
+	if err != nil {
+		return 0, ExactCount, err
+	}
+	db, err = JoinAssociations(ctx, db, assoc, obj)
+	if err != nil {
+		return 0, ExactCount, err
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return 0, ExactCount, err
+	}
+	return total, ExactCount, nil
+}
+
+// estimateCount returns the estimated number of records of obj's model matching filtering f,
+// or a negative number if the table has never been analyzed.
+// The estimate is built from a clean scope of the model, so it is affected neither by joins, sorting and pagination
+// of db nor by rows of joined associations, associations of f are matched by a subquery instead.
+func estimateCount(ctx context.Context, db *gorm.DB, obj interface{}, c FilteringConditionConverter, f *query.Filtering) (int64, CountMode, error) {
+	str, args, assoc, err := FilteringToGormEx(ctx, f, obj, c)
+	if err != nil {
+		return 0, ExactCount, err
+	}
+	db = db.New().Model(obj)
+	if f.GetIncludeDeleted() {
+		db = db.Unscoped()
+	}
+	if str != "" && len(assoc) > 0 {
+		if str, args, err = associationSubquery(db, assoc, obj, str, args); err != nil {
+			return 0, ExactCount, err
+		}
+	}
+	if str != "" {
+		db = db.Where(str, args...)
+	}
+	scope := db.NewScope(obj)
+	// callbacks are not run for the estimate, so the query is scoped to the tenant explicitly
+	tenancyScopeCallback(scope)
+	cond := strings.TrimSpace(scope.CombinedConditionSql())
+	if cond == "" {
+		var estimate sql.NullInt64
+		row := db.CommonDB().QueryRow(`SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass($1)`, scope.TableName())
+		if err := row.Scan(&estimate); err != nil && err != sql.ErrNoRows {
+			return 0, ExactCount, err
+		}
+		// reltuples is -1 if the table has never been analyzed
+		if !estimate.Valid || estimate.Int64 < 0 {
+			return -1, TableEstimate, nil
+		}
+		return estimate.Int64, TableEstimate, nil
+	}
+	var plan []byte
+	row := db.CommonDB().QueryRow(fmt.Sprintf("EXPLAIN (FORMAT JSON) SELECT * FROM %s %s", scope.QuotedTableName(), cond), scope.SQLVars...)
+	if err := row.Scan(&plan); err != nil {
+		return 0, ExactCount, err
+	}
+	estimate, err := planRows(plan)
+	return estimate, PlannerEstimate, err
+}
+
+// planRows returns the number of rows of a query plan in JSON format.
+func planRows(plan []byte) (int64, error) {
+	var explain []struct {
+		Plan struct {
+			Rows float64 `json:"Plan Rows"`
+		}
+	}
+	if err := json.Unmarshal(plan, &explain); err != nil {
+		return 0, err
+	}
+	if len(explain) == 0 {
+		return 0, fmt.Errorf("empty query plan")
+	}
+	return int64(explain[0].Plan.Rows), nil
+}
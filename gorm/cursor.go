@@ -0,0 +1,294 @@
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	jgorm "github.com/jinzhu/gorm"
+	"google.golang.org/grpc/codes"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/errors"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/util"
+)
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// CursorSorting returns a copy of s extended with primary key criterias of obj
+// that are not already present in s, so the resulting sort order is total and
+// can be used for keyset (cursor) pagination.
+// Primary key columns are taken from `gorm:"primary_key"` tags, the Id field is
+// used if there are none. Appended criterias inherit the order of the last
+// criteria of s.
+func CursorSorting(s *query.Sorting, obj interface{}) *query.Sorting {
+	res := &query.Sorting{}
+	order := query.SortCriteria_ASC
+	present := make(map[string]struct{})
+	for _, cr := range s.GetCriterias() {
+		res.Criterias = append(res.Criterias, &query.SortCriteria{Tag: cr.GetTag(), Order: cr.GetOrder(), Nulls: cr.GetNulls()})
+		present[cr.GetTag()] = struct{}{}
+		order = cr.GetOrder()
+	}
+	for _, pk := range primaryKeyTags(indirectType(reflect.TypeOf(obj))) {
+		if _, ok := present[pk]; ok {
+			continue
+		}
+		res.Criterias = append(res.Criterias, &query.SortCriteria{Tag: pk, Order: order})
+	}
+	return res
+}
+
+// CursorPageInfo returns page info for the page of items that was fetched with
+// collection operators s and p in server-driven pagination mode.
+// items is expected to be a slice of GORM models. The page token of the returned
+// page info points right after the last item, or indicates that there are no
+// more pages if the page is not full.
+func CursorPageInfo(ctx context.Context, items interface{}, s *query.Sorting, p *query.Pagination) (*query.PageInfo, error) {
+	pi, cursor, err := cursorPageInfo(items, s, p)
+	if err != nil || cursor == nil {
+		return pi, err
+	}
+	token, err := query.EncodeCursorToken(cursor)
+	if err != nil {
+		return nil, err
+	}
+	pi.PageToken = token
+	return pi, nil
+}
+
+// CursorPageInfoEx works like CursorPageInfo, but the page token is encoded by
+// c and bound to collection operators f, s and fs. The page size is the one bound
+// to the page token of p decoded by c, if any, otherwise the limit of p.
+func CursorPageInfoEx(ctx context.Context, items interface{}, c PageTokenConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*query.PageInfo, error) {
+	scope := query.PageTokenScope(f, s, fs)
+	token, err := c.PageTokenToGorm(ctx, p, scope)
+	if err != nil {
+		return nil, err
+	}
+	p = tokenPagination(token, p)
+	pi, cursor, err := cursorPageInfo(items, s, p)
+	if err != nil || cursor == nil {
+		return pi, err
+	}
+	if pi.PageToken, err = c.PageTokenFromGorm(ctx, &query.PageToken{Cursor: cursor, Limit: p.GetLimit()}, scope); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+// OffsetPageInfoEx returns page info for the page of items that was fetched with collection operators
+// f, s, p and fs in server-driven pagination mode with offset page tokens, i.e. keyset pagination is disabled.
+// The offset and limit of the page are those of the page token of p decoded by c, if any, otherwise those of p.
+// The page token of the returned page info is encoded by c and bound to f, s and fs, it points right after
+// the last item, or indicates that there are no more pages if the page is not full.
+func OffsetPageInfoEx(ctx context.Context, items interface{}, c PageTokenConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*query.PageInfo, error) {
+	itemsVal := reflect.Indirect(reflect.ValueOf(items))
+	if itemsVal.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("%T is not a slice", items)
+	}
+	scope := query.PageTokenScope(f, s, fs)
+	token, err := c.PageTokenToGorm(ctx, p, scope)
+	if err != nil {
+		return nil, err
+	}
+	if token != nil && token.Cursor != nil {
+		return nil, fmt.Errorf("Cannot issue an offset page token for a cursor page token")
+	}
+	p = tokenPagination(token, p)
+	pi := &query.PageInfo{Size: int32(itemsVal.Len())}
+	if itemsVal.Len() == 0 || p.GetLimit() <= 0 || int32(itemsVal.Len()) < p.GetLimit() {
+		pi.SetLastToken()
+		return pi, nil
+	}
+	next := &query.PageToken{Offset: p.GetOffset() + p.GetLimit(), Limit: p.GetLimit()}
+	if pi.PageToken, err = c.PageTokenFromGorm(ctx, next, scope); err != nil {
+		return nil, err
+	}
+	return pi, nil
+}
+
+func cursorPageInfo(items interface{}, s *query.Sorting, p *query.Pagination) (*query.PageInfo, *query.PageCursor, error) {
+	itemsVal := reflect.ValueOf(items)
+	for itemsVal.Kind() == reflect.Ptr {
+		itemsVal = itemsVal.Elem()
+	}
+	if itemsVal.Kind() != reflect.Slice {
+		return nil, nil, fmt.Errorf("%T is not a slice", items)
+	}
+	pi := &query.PageInfo{Size: int32(itemsVal.Len())}
+	if itemsVal.Len() == 0 || (p.GetLimit() > 0 && int32(itemsVal.Len()) < p.GetLimit()) {
+		pi.SetLastToken()
+		return pi, nil, nil
+	}
+
+	last := reflect.Indirect(itemsVal.Index(itemsVal.Len() - 1))
+	sorting := CursorSorting(s, last.Interface())
+	values := make([]interface{}, 0, len(sorting.GetCriterias()))
+	for _, cr := range sorting.GetCriterias() {
+		fieldPath := strings.Split(cr.GetTag(), ".")
+		if len(fieldPath) > 1 {
+			return nil, nil, fmt.Errorf("Cursor pagination by association field %s is not supported", cr.GetTag())
+		}
+		fv := last.FieldByName(util.Camel(fieldPath[0]))
+		if !fv.IsValid() {
+			return nil, nil, fmt.Errorf("Cannot find field %s in %s", cr.GetTag(), last.Type())
+		}
+		values = append(values, fv.Interface())
+	}
+	return pi, query.NewPageCursor(sorting, values...), nil
+}
+
+// CursorToGorm returns GORM Plain SQL representation of the seek predicate that
+// selects rows following the cursor c in a collection ordered by s.
+// s is expected to be extended with primary key criterias by CursorSorting.
+func (converter *DefaultPaginationConverter) CursorToGorm(ctx context.Context, c *query.PageCursor, s *query.Sorting, obj interface{}) (string, []interface{}, error) {
+	if c == nil {
+		return "", nil, nil
+	}
+	if err := c.Validate(s); err != nil {
+		return "", nil, err
+	}
+	objType := indirectType(reflect.TypeOf(obj))
+	crs := s.GetCriterias()
+	dbNames := make([]string, 0, len(crs))
+	values := make([]interface{}, 0, len(crs))
+	nullable := make([]bool, 0, len(crs))
+	uniform := true
+	for i, cr := range crs {
+		fieldPath := strings.Split(cr.GetTag(), ".")
+		if len(fieldPath) > 1 {
+			return "", nil, fmt.Errorf("Cursor pagination by association field %s is not supported", cr.GetTag())
+		}
+		dbName, _, err := HandleFieldPath(ctx, fieldPath, obj)
+		if err != nil {
+			return "", nil, err
+		}
+		sf, ok := objType.FieldByName(util.Camel(fieldPath[0]))
+		if !ok {
+			return "", nil, fmt.Errorf("Cannot find field %s in %s", cr.GetTag(), objType)
+		}
+		v, err := cursorValue(c.Values[i], sf.Type)
+		if err != nil {
+			return "", nil, errors.NewContainer(codes.InvalidArgument, "Page token validation failed.").
+				WithField("page_token", "Invalid value of %s.", cr.GetTag())
+		}
+		if c.Values[i] == nil {
+			v = nil
+		}
+		dbNames = append(dbNames, dbName)
+		values = append(values, v)
+		nullable = append(nullable, v == nil || nullableType(sf.Type))
+		if cr.GetOrder() != crs[0].GetOrder() || nullable[i] {
+			uniform = false
+		}
+	}
+	if len(dbNames) == 0 {
+		return "", nil, nil
+	}
+
+	if uniform {
+		o := ">"
+		if crs[0].IsDesc() {
+			o = "<"
+		}
+		placeholder := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+		return fmt.Sprintf("((%s) %s (%s))", strings.Join(dbNames, ", "), o, placeholder), values, nil
+	}
+
+	// mixed sort orders and nullable columns cannot be expressed with a row value comparison,
+	// so (a > ?) OR (a = ? AND b < ?) OR ... is built instead
+	var (
+		disjuncts []string
+		args      []interface{}
+	)
+	for i, cr := range crs {
+		after, afterArgs := seekCondition(dbNames[i], values[i], nullable[i], cr)
+		if after == "" {
+			continue
+		}
+		var conjuncts []string
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				conjuncts = append(conjuncts, dbNames[j]+" IS NULL")
+				continue
+			}
+			conjuncts = append(conjuncts, fmt.Sprintf("%s = ?", dbNames[j]))
+			args = append(args, values[j])
+		}
+		conjuncts = append(conjuncts, after)
+		args = append(args, afterArgs...)
+		disjuncts = append(disjuncts, "("+strings.Join(conjuncts, " AND ")+")")
+	}
+	if len(disjuncts) == 0 {
+		return "(FALSE)", nil, nil
+	}
+	return "(" + strings.Join(disjuncts, " OR ") + ")", args, nil
+}
+
+// seekCondition returns the condition that selects values of column dbName following value v
+// in the order of cr, or an empty string if no value follows v. NULL values of nullable columns
+// are ordered according to cr.Nulls, by default they follow other values in ascending order.
+func seekCondition(dbName string, v interface{}, nullable bool, cr *query.SortCriteria) (string, []interface{}) {
+	nullsFirst := cr.GetNulls() == query.SortCriteria_NULLS_FIRST ||
+		cr.GetNulls() == query.SortCriteria_NULLS_DEFAULT && cr.IsDesc()
+	if v == nil {
+		if nullsFirst {
+			return dbName + " IS NOT NULL", nil
+		}
+		return "", nil
+	}
+	o := ">"
+	if cr.IsDesc() {
+		o = "<"
+	}
+	if nullsFirst || !nullable {
+		return fmt.Sprintf("%s %s ?", dbName, o), []interface{}{v}
+	}
+	return fmt.Sprintf("(%s %s ? OR %s IS NULL)", dbName, o, dbName), []interface{}{v}
+}
+
+// nullableType reports whether a field of type t may hold NULL, i.e. t is a pointer, a slice, a map
+// or a type implementing driver.Valuer like sql.NullString.
+func nullableType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return t.Implements(valuerType) || reflect.PtrTo(t).Implements(valuerType)
+}
+
+// cursorValue converts a value decoded from a cursor page token to type t.
+func cursorValue(v interface{}, t reflect.Type) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	res := reflect.New(t)
+	if err := json.Unmarshal(data, res.Interface()); err != nil {
+		return nil, err
+	}
+	return res.Elem().Interface(), nil
+}
+
+func primaryKeyTags(objType reflect.Type) []string {
+	if objType.Kind() != reflect.Struct {
+		return nil
+	}
+	var tags []string
+	for i := 0; i < objType.NumField(); i++ {
+		sf := objType.Field(i)
+		if ok, _ := gormTag(&sf, "primary_key"); ok {
+			tags = append(tags, jgorm.ToDBName(sf.Name))
+		}
+	}
+	if len(tags) == 0 {
+		if _, ok := objType.FieldByName("Id"); ok {
+			tags = append(tags, "id")
+		}
+	}
+	return tags
+}
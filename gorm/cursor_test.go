@@ -0,0 +1,286 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+func TestCursorSorting(t *testing.T) {
+	s, err := query.ParseSorting("name desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "name DESC, id DESC", CursorSorting(s, &Person{}).GoString())
+
+	s, err = query.ParseSorting("id,name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "id ASC, name ASC", CursorSorting(s, &Person{}).GoString())
+
+	assert.Equal(t, "id ASC", CursorSorting(nil, &Person{}).GoString())
+}
+
+func TestCursorToGorm(t *testing.T) {
+	c := &DefaultPaginationConverter{}
+	ctx := context.Background()
+
+	s := CursorSorting(&query.Sorting{Criterias: []*query.SortCriteria{{Tag: "age", Order: query.SortCriteria_DESC}}}, &Person{})
+	where, args, err := c.CursorToGorm(ctx, query.NewPageCursor(s, 25, 111), s, &Person{})
+	assert.NoError(t, err)
+	assert.Equal(t, "((people.age, people.id) < (?, ?))", where)
+	assert.Equal(t, []interface{}{25, int64(111)}, args)
+
+	s = CursorSorting(&query.Sorting{Criterias: []*query.SortCriteria{
+		{Tag: "name", Order: query.SortCriteria_ASC},
+		{Tag: "age", Order: query.SortCriteria_DESC},
+	}}, &Person{})
+	where, args, err = c.CursorToGorm(ctx, query.NewPageCursor(s, "Mike", 25, 111), s, &Person{})
+	assert.NoError(t, err)
+	assert.Equal(t, "((people.name > ?) OR (people.name = ? AND people.age < ?) OR (people.name = ? AND people.age = ? AND people.id < ?))", where)
+	assert.Equal(t, []interface{}{"Mike", "Mike", 25, "Mike", 25, int64(111)}, args)
+
+	_, _, err = c.CursorToGorm(ctx, query.NewPageCursor(s, "Mike"), s, &Person{})
+	assert.Error(t, err)
+}
+
+type CursorModel struct {
+	Id   int64
+	Name *string
+}
+
+func TestCursorToGormNulls(t *testing.T) {
+	c := &DefaultPaginationConverter{}
+	ctx := context.Background()
+	sorting := func(order query.SortCriteria_Order, nulls query.SortCriteria_Nulls) *query.Sorting {
+		return CursorSorting(&query.Sorting{Criterias: []*query.SortCriteria{{Tag: "name", Order: order, Nulls: nulls}}}, &CursorModel{})
+	}
+
+	// nulls follow other values in ascending order by default, so only nulls with greater ids follow a null
+	s := sorting(query.SortCriteria_ASC, query.SortCriteria_NULLS_DEFAULT)
+	where, args, err := c.CursorToGorm(ctx, query.NewPageCursor(s, nil, 5), s, &CursorModel{})
+	assert.NoError(t, err)
+	assert.Equal(t, "((cursor_models.name IS NULL AND cursor_models.id > ?))", where)
+	assert.Equal(t, []interface{}{int64(5)}, args)
+
+	s = sorting(query.SortCriteria_ASC, query.SortCriteria_NULLS_FIRST)
+	where, args, err = c.CursorToGorm(ctx, query.NewPageCursor(s, nil, 5), s, &CursorModel{})
+	assert.NoError(t, err)
+	assert.Equal(t, "((cursor_models.name IS NOT NULL) OR (cursor_models.name IS NULL AND cursor_models.id > ?))", where)
+	assert.Equal(t, []interface{}{int64(5)}, args)
+
+	s = sorting(query.SortCriteria_ASC, query.SortCriteria_NULLS_LAST)
+	where, args, err = c.CursorToGorm(ctx, query.NewPageCursor(s, "Mike", 5), s, &CursorModel{})
+	assert.NoError(t, err)
+	assert.Equal(t, "(((cursor_models.name > ? OR cursor_models.name IS NULL)) OR (cursor_models.name = ? AND cursor_models.id > ?))", where)
+	assert.Len(t, args, 3)
+
+	// nulls precede other values in descending order by default
+	s = sorting(query.SortCriteria_DESC, query.SortCriteria_NULLS_DEFAULT)
+	where, _, err = c.CursorToGorm(ctx, query.NewPageCursor(s, "Mike", 5), s, &CursorModel{})
+	assert.NoError(t, err)
+	assert.Equal(t, "((cursor_models.name < ?) OR (cursor_models.name = ? AND cursor_models.id < ?))", where)
+
+	s = sorting(query.SortCriteria_DESC, query.SortCriteria_NULLS_LAST)
+	where, args, err = c.CursorToGorm(ctx, query.NewPageCursor(s, nil, 5), s, &CursorModel{})
+	assert.NoError(t, err)
+	assert.Equal(t, "((cursor_models.name IS NULL AND cursor_models.id < ?))", where)
+	assert.Equal(t, []interface{}{int64(5)}, args)
+}
+
+func TestCursorPagination(t *testing.T) {
+	ctx := context.Background()
+	s, err := query.ParseSorting("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &query.Pagination{PageToken: "null", Limit: 2}
+	pi, err := CursorPageInfo(ctx, []Person{{Id: 1, Name: "Alice"}, {Id: 7, Name: "Mike"}}, s, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(2), pi.GetSize())
+	assert.False(t, pi.NoMore())
+
+	c := NewDefaultPbToOrmConverter(&PersonProto{}).(*DefaultPbToOrmConverter)
+	c.Keyset = true
+	gormDB, mock := setUp(t)
+	p = &query.Pagination{PageToken: pi.GetPageToken(), Limit: 2}
+	gormDB, err = ApplyCollectionOperatorsEx(ctx, gormDB, &Person{}, c, nil, s, p, query.ParseFieldSelection("id,name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "people" WHERE (((people.name, people.id) > ($1, $2))) ORDER BY people.name,people.id LIMIT 2`)).
+		WithArgs("Mike", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(9, "Zed"))
+
+	var actual []Person
+	gormDB.Find(&actual)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	pi, err = CursorPageInfo(ctx, &actual, s, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(1), pi.GetSize())
+	assert.True(t, pi.NoMore())
+}
+
+func TestOffsetPageToken(t *testing.T) {
+	ctx := context.Background()
+	s, err := query.ParseSorting("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// keyset pagination is disabled by default, so offset page tokens keep working
+	gormDB, mock := setUp(t)
+	p := &query.Pagination{PageToken: query.EncodePageToken(4, 2), Offset: 4, Limit: 2}
+	gormDB, err = ApplyCollectionOperatorsEx(ctx, gormDB, &Person{}, NewDefaultPbToOrmConverter(&PersonProto{}), nil, s, p, query.ParseFieldSelection("id,name"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "people" ORDER BY "people"."name" LIMIT 2 OFFSET 4`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	var actual []Person
+	assert.NoError(t, gormDB.Find(&actual).Error)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestSignedPageToken(t *testing.T) {
+	ctx := context.Background()
+	c := &DefaultPbToOrmConverter{}
+	c.Codec = query.NewHMACPageTokenCodec([]byte("secret"), time.Hour)
+	c.Keyset = true
+
+	f, err := query.ParseFiltering("age > 20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := query.ParseSorting("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := query.ParseFieldSelection("id,name")
+	p := &query.Pagination{PageToken: "null", Limit: 1}
+	pi, err := CursorPageInfoEx(ctx, []Person{{Id: 7, Name: "Mike"}}, c, f, s, p, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gormDB, mock := setUp(t)
+	// the page size bound to the token cannot be changed by the client
+	p = &query.Pagination{PageToken: pi.GetPageToken(), Limit: 1000}
+	gormDB, err = ApplyCollectionOperatorsEx(ctx, gormDB, &Person{}, c, f, s, p, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "people" WHERE ((people.age > $1)) AND (((people.name, people.id) > ($2, $3))) ORDER BY people.name,people.id LIMIT 1`)).
+		WithArgs(20.0, "Mike", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	var actual []Person
+	gormDB.Find(&actual)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	// page token issued for a different filter
+	other, err := query.ParseFiltering("age > 30")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, _ = setUp(t)
+	_, err = ApplyCollectionOperatorsEx(ctx, gormDB, &Person{}, c, other, s, p, fs)
+	assert.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// unsigned page token
+	ptoken, err := query.EncodeCursorToken(query.NewPageCursor(CursorSorting(s, &Person{}), "Mike", 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ApplyCollectionOperatorsEx(ctx, gormDB, &Person{}, c, f, s, &query.Pagination{PageToken: ptoken}, fs)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// offset page token
+	ptoken, err = c.PageTokenFromGorm(ctx, &query.PageToken{Offset: 4, Limit: 2}, query.PageTokenScope(f, s, fs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, mock = setUp(t)
+	gormDB, err = ApplyCollectionOperatorsEx(ctx, gormDB, &Person{}, c, f, s, &query.Pagination{PageToken: ptoken}, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "people" WHERE ((people.age > $1)) ORDER BY people.name,people.id LIMIT 2 OFFSET 4`)).
+		WithArgs(20.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	gormDB.Find(&actual)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestOffsetPageInfoEx(t *testing.T) {
+	ctx := context.Background()
+	c := &DefaultPbToOrmConverter{}
+	c.Codec = query.NewHMACPageTokenCodec([]byte("secret"), time.Hour)
+
+	f, err := query.ParseFiltering("age > 20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := query.ParseSorting("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := query.ParseFieldSelection("id,name")
+	scope := query.PageTokenScope(f, s, fs)
+	people := []Person{{Id: 1, Name: "Ann"}, {Id: 2, Name: "Bob"}}
+
+	pi, err := OffsetPageInfoEx(ctx, people, c, f, s, &query.Pagination{PageToken: "null", Limit: 2}, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := c.PageTokenToGorm(ctx, &query.Pagination{PageToken: pi.GetPageToken()}, scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(2), token.Offset)
+	assert.Equal(t, int32(2), token.Limit)
+
+	// the next page follows the token, not the limit of the request
+	pi, err = OffsetPageInfoEx(ctx, people, c, f, s, &query.Pagination{PageToken: pi.GetPageToken(), Limit: 1000}, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err = c.PageTokenToGorm(ctx, &query.Pagination{PageToken: pi.GetPageToken()}, scope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(4), token.Offset)
+	assert.Equal(t, int32(2), token.Limit)
+
+	// the token is bound to the collection operators
+	_, err = c.PageTokenToGorm(ctx, &query.Pagination{PageToken: pi.GetPageToken()}, query.PageTokenScope(nil, s, fs))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	pi, err = OffsetPageInfoEx(ctx, people[:1], c, f, s, &query.Pagination{PageToken: pi.GetPageToken()}, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, pi.NoMore())
+	assert.Equal(t, int32(1), pi.GetSize())
+}
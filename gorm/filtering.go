@@ -33,6 +33,18 @@ type NumberArrayConditionConverter interface {
 	NumberArrayConditionToGorm(ctx context.Context, c *query.NumberArrayCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error)
 }
 
+type TimeConditionConverter interface {
+	TimeConditionToGorm(ctx context.Context, c *query.TimeCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error)
+}
+
+type BoolConditionConverter interface {
+	BoolConditionToGorm(ctx context.Context, c *query.BoolCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error)
+}
+
+type ContainsConditionConverter interface {
+	ContainsConditionToGorm(ctx context.Context, c *query.ContainsCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error)
+}
+
 type FilteringConditionConverter interface {
 	LogicalOperatorConverter
 	NullConditionConverter
@@ -40,6 +52,9 @@ type FilteringConditionConverter interface {
 	StringArrayConditionConverter
 	NumberConditionConverter
 	NumberArrayConditionConverter
+	TimeConditionConverter
+	BoolConditionConverter
+	ContainsConditionConverter
 }
 
 type FilteringConditionProcessor interface {
@@ -82,6 +97,12 @@ func FilteringToGormEx(ctx context.Context, m *query.Filtering, obj interface{},
 		return c.NumberArrayConditionToGorm(ctx, r.NumberArrayCondition, obj)
 	case *query.Filtering_StringArrayCondition:
 		return c.StringArrayConditionToGorm(ctx, r.StringArrayCondition, obj)
+	case *query.Filtering_TimeCondition:
+		return c.TimeConditionToGorm(ctx, r.TimeCondition, obj)
+	case *query.Filtering_BoolCondition:
+		return c.BoolConditionToGorm(ctx, r.BoolCondition, obj)
+	case *query.Filtering_ContainsCondition:
+		return c.ContainsConditionToGorm(ctx, r.ContainsCondition, obj)
 	default:
 		return "", nil, nil, fmt.Errorf("%T type is not supported in Filtering", r)
 	}
@@ -3,11 +3,16 @@ package gorm
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/jinzhu/gorm/dialects/postgres"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errfields"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/resource"
 )
 
@@ -20,6 +25,7 @@ type Entity struct {
 	Id           string
 	Ref          *string
 	Tags         *postgres.Jsonb
+	Labels       pq.StringArray
 }
 
 type EntityProto struct {
@@ -323,6 +329,76 @@ func TestGormFiltering(t *testing.T) {
 			nil,
 			nil,
 		},
+		{
+			"field1 >= 2024-01-01T10:00:00Z",
+			"(entities.field1 >= ?)",
+			[]interface{}{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			nil,
+			nil,
+		},
+		{
+			"field1 < 2024-01-01 and field2 != 2024-01-01T12:00:00+02:00",
+			"((entities.field1 < ?) AND NOT(entities.field2 = ?))",
+			[]interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			nil,
+			nil,
+		},
+		{
+			"field1 == true and not field2 == false",
+			"((entities.field1 = ?) AND NOT(entities.field2 = ?))",
+			[]interface{}{true, false},
+			nil,
+			nil,
+		},
+		{
+			"field1 != true",
+			"NOT(entities.field1 = ?)",
+			[]interface{}{true},
+			nil,
+			nil,
+		},
+		{
+			"nested_entity.nested_field1 > 2024-01-01",
+			"(nested_entity.nested_field1 > ?)",
+			[]interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			map[string]struct{}{"NestedEntity": {}},
+			nil,
+		},
+		{
+			"labels contains ['a', 'b'] and not labels has 'c'",
+			"((entities.labels @> ?) AND NOT(entities.labels @> ?))",
+			[]interface{}{pq.StringArray{"a", "b"}, pq.StringArray{"c"}},
+			nil,
+			nil,
+		},
+		{
+			"labels in ['a', 'b'] or not labels in ['c']",
+			"((entities.labels && ?) OR NOT(entities.labels && ?))",
+			[]interface{}{pq.StringArray{"a", "b"}, pq.StringArray{"c"}},
+			nil,
+			nil,
+		},
+		{
+			"tags contains ['a', 'b'] and tags.Location contains 'Tacoma'",
+			"((entities.tags @> ?) AND (entities.tags #> '{Location}' @> ?))",
+			[]interface{}{postgres.Jsonb{RawMessage: []byte(`["a","b"]`)}, postgres.Jsonb{RawMessage: []byte(`["Tacoma"]`)}},
+			nil,
+			nil,
+		},
+		{
+			"tags has 'a' or not tags has 'b'",
+			"((entities.tags ? ?) OR NOT(entities.tags ? ?))",
+			[]interface{}{jsonbOperator("?"), "a", jsonbOperator("?"), "b"},
+			nil,
+			nil,
+		},
+		{
+			"tags in ['a', 'b']",
+			"(entities.tags ? ?)",
+			[]interface{}{jsonbOperator("?|"), pq.StringArray{"a", "b"}},
+			nil,
+			nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -333,3 +409,14 @@ func TestGormFiltering(t *testing.T) {
 		assert.IsType(t, test.err, err)
 	}
 }
+
+func TestGormFilteringContainsType(t *testing.T) {
+	for _, filter := range []string{"field_string contains 'a'", "not field1 has '1'"} {
+		_, _, _, err := FilterStringToGorm(context.Background(), filter, &Entity{}, &EntityProto{})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code(), filter)
+		if assert.Len(t, st.Details(), 1, filter) {
+			assert.Len(t, st.Details()[0].(*errfields.FieldInfo).GetFields(), 1, filter)
+		}
+	}
+}
@@ -4,75 +4,228 @@ import (
 	"context"
 	"fmt"
 	"github.com/jinzhu/gorm"
+	"github.com/jinzhu/inflection"
 	"reflect"
+	"sort"
 	"strings"
 )
 
+// associationJoin is a join of a table required to resolve an association.
+type associationJoin struct {
+	table      string
+	alias      string
+	sourceKeys []string
+	targetKeys []string
+	// model type of the joined table, nil for join tables of many to many associations
+	model reflect.Type
+}
+
 // JoinInfo extracts the following information for assoc association of obj:
 // - association table name
 // - source join keys
 // - target join keys
+// assoc may be a path of nested associations, e.g. "Owner.Org", then source keys
+// refer to the alias of the preceding association.
+// Many to many associations require two joins and are supported only by JoinAssociations.
 func JoinInfo(ctx context.Context, obj interface{}, assoc string) (string, []string, []string, error) {
+	joins, err := associationJoins(obj, assoc)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(joins) != 1 {
+		return "", nil, nil, fmt.Errorf("many to many association %s cannot be joined with a single join", assoc)
+	}
+	return joins[0].table, joins[0].sourceKeys, joins[0].targetKeys, nil
+}
+
+// associationJoins returns joins of the last association of assoc association path of obj,
+// the preceding associations of the path are expected to be joined separately.
+func associationJoins(obj interface{}, assoc string) ([]associationJoin, error) {
 	objType := indirectType(reflect.TypeOf(obj))
-	sf, ok := objType.FieldByName(assoc)
-	if !ok {
-		return "", nil, nil, fmt.Errorf("Cannot find field %s in %s", assoc, objType)
+	source := tableName(objType)
+	path := strings.Split(assoc, ".")
+	for i, name := range path {
+		sf, ok := objType.FieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("Cannot find field %s in %s", name, objType)
+		}
+		assocType := indirectType(sf.Type)
+		alias := associationAlias(strings.Join(path[:i+1], "."))
+		if i < len(path)-1 {
+			objType, source = assocType, alias
+			continue
+		}
+		if ok, joinTable := gormTag(&sf, "many2many"); ok {
+			return many2manyJoins(objType, assocType, &sf, joinTable, source, alias)
+		}
+		sourceKeys, targetKeys, err := joinKeys(objType, assocType, &sf, source, alias)
+		if err != nil {
+			return nil, err
+		}
+		return []associationJoin{{
+			table:      tableName(assocType),
+			alias:      alias,
+			sourceKeys: sourceKeys,
+			targetKeys: targetKeys,
+			model:      assocType,
+		}}, nil
 	}
-	ok, assocKey := gormTag(&sf, "association_foreignkey")
+	return nil, &EmptyFieldPathError{}
+}
+
+// isToManyAssociation reports whether assoc association path of obj contains a has many or
+// many to many association, joining which duplicates records of obj's model.
+func isToManyAssociation(obj interface{}, assoc string) bool {
+	objType := indirectType(reflect.TypeOf(obj))
+	for _, name := range strings.Split(assoc, ".") {
+		sf, ok := objType.FieldByName(name)
+		if !ok {
+			return false
+		}
+		t := sf.Type
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Slice {
+			return true
+		}
+		objType = indirectType(t)
+	}
+	return false
+}
+
+// joinKeys returns source and target join keys of sf association of objType joined as alias.
+func joinKeys(objType, assocType reflect.Type, sf *reflect.StructField, source, alias string) ([]string, []string, error) {
+	ok, assocKey := gormTag(sf, "association_foreignkey")
 	if !ok {
-		return "", nil, nil, fmt.Errorf("association_foreignkey tag is absent in %s", objType)
+		return nil, nil, fmt.Errorf("association_foreignkey tag is absent in %s", objType)
 	}
 	assocKeys := strings.Split(assocKey, ",")
-	ok, fKey := gormTag(&sf, "foreignkey")
+	ok, fKey := gormTag(sf, "foreignkey")
 	if !ok {
-		return "", nil, nil, fmt.Errorf("foreignkey tag is absent in %s", objType)
+		return nil, nil, fmt.Errorf("foreignkey tag is absent in %s", objType)
 	}
 	fKeys := strings.Split(fKey, ",")
 
 	if len(assocKeys) != len(fKeys) {
-		return "", nil, nil, fmt.Errorf(`%s: the number of association keys is not equal to the number
-of foreign keys in %s association`, objType, assoc)
+		return nil, nil, fmt.Errorf(`%s: the number of association keys is not equal to the number
+of foreign keys in %s association`, objType, sf.Name)
 	}
 
-	assocType := indirectType(sf.Type)
-	_, childTableName, dbAssocKeys, dbFKeys, err := parseParentChildAssoc(assoc, true, objType, assocType, assocKeys, fKeys)
+	dbAssocKeys, dbFKeys, err := parseParentChildAssoc(objType, assocType, source, alias, assocKeys, fKeys)
 	if err != nil {
-		parentTableName, _, dbAssocKeys, dbFKeys, err := parseParentChildAssoc(assoc, false, assocType, objType, assocKeys, fKeys)
+		dbAssocKeys, dbFKeys, err := parseParentChildAssoc(assocType, objType, alias, source, assocKeys, fKeys)
 		if err != nil {
-			return "", nil, nil, err
+			return nil, nil, err
 		}
-		return parentTableName, dbFKeys, dbAssocKeys, nil
+		return dbFKeys, dbAssocKeys, nil
 	}
-	return childTableName, dbAssocKeys, dbFKeys, nil
+	return dbAssocKeys, dbFKeys, nil
 }
 
-func parseParentChildAssoc(assoc string, assocChild bool, parent reflect.Type, child reflect.Type, assocKeys []string, fKeys []string) (string, string, []string, []string, error) {
-	parentTableName := tableName(parent)
-	childTableName := tableName(child)
-	alias := gorm.ToDBName(assoc)
+func parseParentChildAssoc(parent reflect.Type, child reflect.Type, parentAlias, childAlias string, assocKeys []string, fKeys []string) ([]string, []string, error) {
 	var dbAssocKeys, dbFKeys []string
 	for _, k := range assocKeys {
 		sf, ok := parent.FieldByName(k)
 		if !ok {
-			return "", "", nil, nil, fmt.Errorf("Association key %s is not found in %s", k, parent)
-		}
-		if assocChild {
-			dbAssocKeys = append(dbAssocKeys, parentTableName+"."+columnName(&sf))
-		} else {
-			dbAssocKeys = append(dbAssocKeys, alias+"."+columnName(&sf))
+			return nil, nil, fmt.Errorf("Association key %s is not found in %s", k, parent)
 		}
-
+		dbAssocKeys = append(dbAssocKeys, parentAlias+"."+columnName(&sf))
 	}
 	for _, k := range fKeys {
 		sf, ok := child.FieldByName(k)
 		if !ok {
-			return "", "", nil, nil, fmt.Errorf("Foreign key %s is not found in %s", k, child)
+			return nil, nil, fmt.Errorf("Foreign key %s is not found in %s", k, child)
 		}
-		if assocChild {
-			dbFKeys = append(dbFKeys, alias+"."+columnName(&sf))
+		dbFKeys = append(dbFKeys, childAlias+"."+columnName(&sf))
+	}
+	return dbAssocKeys, dbFKeys, nil
+}
+
+// many2manyJoins returns joins of joinTable and the table of sf many to many association of objType,
+// the join table is aliased by alias of the association followed by the name of the join table.
+// Keys are resolved from foreignkey, association_foreignkey, jointable_foreignkey and
+// association_jointable_foreignkey tags or the same naming conventions GORM uses.
+func many2manyJoins(objType, assocType reflect.Type, sf *reflect.StructField, joinTable, source, alias string) ([]associationJoin, error) {
+	jt := associationJoin{table: joinTable, alias: alias + "__" + joinTable}
+	join := associationJoin{table: tableName(assocType), alias: alias, model: assocType}
+
+	keys := func(t reflect.Type, tag, jtTag, prefix string) ([]string, []string, error) {
+		var fields []string
+		if ok, v := gormTag(sf, tag); ok {
+			fields = strings.Split(v, ",")
 		} else {
-			dbFKeys = append(dbFKeys, childTableName+"."+columnName(&sf))
+			fields = primaryKeyTags(t)
+		}
+		var jtColumns []string
+		if ok, v := gormTag(sf, jtTag); ok {
+			jtColumns = strings.Split(v, ",")
+		}
+		if len(jtColumns) != 0 && len(jtColumns) != len(fields) {
+			return nil, nil, fmt.Errorf("%s: the number of %s is not equal to the number of keys in %s association", objType, jtTag, sf.Name)
 		}
+		var columns []string
+		for _, k := range fields {
+			field, ok := modelField(t, k)
+			if !ok {
+				return nil, nil, fmt.Errorf("Association key %s is not found in %s", k, t)
+			}
+			columns = append(columns, columnName(&field))
+			if len(jtColumns) < len(fields) {
+				jtColumns = append(jtColumns, prefix+"_"+columnName(&field))
+			}
+		}
+		return columns, jtColumns, nil
+	}
+
+	ownKeys, ownJTKeys, err := keys(objType, "foreignkey", "jointable_foreignkey", gorm.ToDBName(objType.Name()))
+	if err != nil {
+		return nil, err
+	}
+	assocPrefix := gorm.ToDBName(assocType.Name())
+	if objType == assocType {
+		assocPrefix = inflection.Singular(gorm.ToDBName(sf.Name))
+	}
+	assocKeys, assocJTKeys, err := keys(assocType, "association_foreignkey", "association_jointable_foreignkey", assocPrefix)
+	if err != nil {
+		return nil, err
+	}
+	for i, k := range ownKeys {
+		jt.sourceKeys = append(jt.sourceKeys, source+"."+k)
+		jt.targetKeys = append(jt.targetKeys, jt.alias+"."+ownJTKeys[i])
+	}
+	for i, k := range assocKeys {
+		join.sourceKeys = append(join.sourceKeys, jt.alias+"."+assocJTKeys[i])
+		join.targetKeys = append(join.targetKeys, alias+"."+k)
+	}
+	return []associationJoin{jt, join}, nil
+}
+
+// modelField returns the field of t with the name or column name key.
+func modelField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Name == key || columnName(&sf) == key || gorm.ToDBName(sf.Name) == key {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// associationPaths returns sorted association paths of assoc along with all their prefixes,
+// so every association is joined once and after the association it is nested in.
+func associationPaths(assoc map[string]struct{}) []string {
+	paths := make(map[string]struct{})
+	for k := range assoc {
+		path := strings.Split(k, ".")
+		for i := range path {
+			paths[strings.Join(path[:i+1], ".")] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(paths))
+	for k := range paths {
+		names = append(names, k)
 	}
-	return parentTableName, childTableName, dbAssocKeys, dbFKeys, nil
+	sort.Strings(names)
+	return names
 }
@@ -0,0 +1,277 @@
+// Package migrate runs SQL migrations against a Postgres database.
+//
+// Migrations are read from files named {version}_{name}.up.sql and {version}_{name}.down.sql
+// and the version of the database is recorded in the schema_migrations table in the same
+// format as golang-migrate, so gorm.VerifyMigrationVersion can verify it.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultTable is the name of the table the version of the database is recorded in.
+const DefaultTable = "schema_migrations"
+
+// Migration is a versioned change of the database schema.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Load reads migrations from directory dir of fsys, e.g. embed.FS.
+// Files that do not have .sql extension are ignored.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		version, name, direction, err := parseFileName(e.Name())
+		if err != nil {
+			return nil, err
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		} else if m.Name != name {
+			return nil, fmt.Errorf("Migrations %q and %q have the same version %d", m.Name, name, version)
+		}
+		if direction == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	return migrations, nil
+}
+
+// LoadDir reads migrations from directory path, see Load.
+func LoadDir(path string) ([]Migration, error) {
+	return Load(os.DirFS(path), ".")
+}
+
+func parseFileName(name string) (int64, string, string, error) {
+	parts := strings.SplitN(strings.TrimSuffix(name, ".sql"), "_", 2)
+	if len(parts) < 2 {
+		return 0, "", "", fmt.Errorf("Filename %q does not match migration file naming requirements ##_name.[up/down].sql", name)
+	}
+	version, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("Filename %q does not start with a positive migration version", name)
+	}
+	ext := path.Ext(parts[1])
+	if ext != ".up" && ext != ".down" {
+		return 0, "", "", fmt.Errorf("Filename %q does not match migration file naming requirements ##_name.[up/down].sql", name)
+	}
+	return version, strings.TrimSuffix(parts[1], ext), ext[1:], nil
+}
+
+// Migrator applies migrations to a database.
+// Migrations are applied under a session-level advisory lock, so concurrent
+// migrators of the same table wait for each other instead of racing.
+type Migrator struct {
+	migrations []Migration
+	table      string
+	lockID     int64
+}
+
+// Option configures a Migrator.
+type Option func(*Migrator)
+
+// WithTable sets the name of the table the version of the database is recorded in, DefaultTable by default.
+func WithTable(table string) Option {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// WithLockID sets the key of the advisory lock, by default it is derived from the name of the table.
+func WithLockID(id int64) Option {
+	return func(m *Migrator) {
+		m.lockID = id
+	}
+}
+
+// New returns a Migrator of migrations.
+func New(migrations []Migration, opts ...Option) *Migrator {
+	m := &Migrator{
+		migrations: append([]Migration(nil), migrations...),
+		table:      DefaultTable,
+	}
+	sort.Slice(m.migrations, func(i, j int) bool {
+		return m.migrations[i].Version < m.migrations[j].Version
+	})
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.lockID == 0 {
+		m.lockID = int64(crc32.ChecksumIEEE([]byte(m.table)))
+	}
+	return m
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context, db *sql.DB) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+	return m.To(ctx, db, m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down reverts all applied migrations.
+func (m *Migrator) Down(ctx context.Context, db *sql.DB) error {
+	return m.To(ctx, db, 0)
+}
+
+// To applies or reverts migrations until the database is at version, 0 reverts all migrations.
+// The version is recorded after every migration, a failed migration leaves the database dirty
+// and has to be fixed manually.
+func (m *Migrator) To(ctx context.Context, db *sql.DB, version int64) error {
+	target := m.index(version)
+	if version != 0 && target < 0 {
+		return fmt.Errorf("Migration %d not found", version)
+	}
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, m.lockID); err != nil {
+		return err
+	}
+	// the lock is held by the connection, so it has to be released even if ctx is done
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, m.lockID)
+
+	current, dirty, err := m.version(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("Database at version %d, but is dirty", current)
+	}
+	i := m.index(current)
+	if current != 0 && i < 0 {
+		return fmt.Errorf("Database at version %d, but migration %d not found", current, current)
+	}
+	for ; i < target; i++ {
+		next := m.migrations[i+1]
+		if err := m.apply(ctx, conn, next.Version, next.Up); err != nil {
+			return fmt.Errorf("Migration %d_%s failed - %s", next.Version, next.Name, err)
+		}
+	}
+	for ; i > target; i-- {
+		var prev int64
+		if i > 0 {
+			prev = m.migrations[i-1].Version
+		}
+		if err := m.apply(ctx, conn, prev, m.migrations[i].Down); err != nil {
+			return fmt.Errorf("Migration %d_%s failed to revert - %s", m.migrations[i].Version, m.migrations[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// Version returns the version of the database and reports whether a migration failed.
+func (m *Migrator) Version(ctx context.Context, db *sql.DB) (int64, bool, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer conn.Close()
+	return m.version(ctx, conn)
+}
+
+// UpFunc returns a function that applies all pending migrations, e.g. for integration.WithMigrateUpFunction.
+func (m *Migrator) UpFunc() func(*sql.DB) error {
+	return func(db *sql.DB) error {
+		return m.Up(context.Background(), db)
+	}
+}
+
+// DownFunc returns a function that reverts all applied migrations, e.g. for integration.WithMigrateDownFunction.
+func (m *Migrator) DownFunc() func(*sql.DB) error {
+	return func(db *sql.DB) error {
+		return m.Down(context.Background(), db)
+	}
+}
+
+// index returns the index of migration version, or -1 if there is no such migration.
+func (m *Migrator) index(version int64) int {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *Migrator) version(ctx context.Context, conn *sql.Conn) (int64, bool, error) {
+	stmt := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`, m.table)
+	if _, err := conn.ExecContext(ctx, stmt); err != nil {
+		return 0, false, err
+	}
+	var version int64
+	var dirty bool
+	err := conn.QueryRowContext(ctx, fmt.Sprintf(`SELECT version, dirty FROM %s LIMIT 1`, m.table)).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// apply runs query of a migration to version, the database is marked dirty until query succeeds.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, version int64, query string) error {
+	if err := m.setVersion(ctx, conn, version, true); err != nil {
+		return err
+	}
+	if strings.TrimSpace(query) != "" {
+		if _, err := conn.ExecContext(ctx, query); err != nil {
+			return err
+		}
+	}
+	return m.setVersion(ctx, conn, version, false)
+}
+
+func (m *Migrator) setVersion(ctx context.Context, conn *sql.Conn, version int64, dirty bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s`, m.table)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if version != 0 || dirty {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (version, dirty) VALUES ($1, $2)`, m.table), version, dirty); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,137 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+var testFS = fstest.MapFS{
+	"migrations/1_users.up.sql":     {Data: []byte("CREATE TABLE users (id int)")},
+	"migrations/1_users.down.sql":   {Data: []byte("DROP TABLE users")},
+	"migrations/2_groups.up.sql":    {Data: []byte("CREATE TABLE groups (id int)")},
+	"migrations/2_groups.down.sql":  {Data: []byte("DROP TABLE groups")},
+	"migrations/10_emails.up.sql":   {Data: []byte("ALTER TABLE users ADD email text")},
+	"migrations/10_emails.down.sql": {Data: []byte("ALTER TABLE users DROP email")},
+	"migrations/README.md":          {Data: []byte("# migrations")},
+}
+
+func TestLoad(t *testing.T) {
+	migrations, err := Load(testFS, "migrations")
+	if err != nil {
+		t.Fatalf("failed to load migrations - %s", err)
+	}
+	m := New(migrations)
+	if len(m.migrations) != 3 {
+		t.Fatalf("unexpected migrations %v", m.migrations)
+	}
+	for i, expected := range []Migration{
+		{Version: 1, Name: "users", Up: "CREATE TABLE users (id int)", Down: "DROP TABLE users"},
+		{Version: 2, Name: "groups", Up: "CREATE TABLE groups (id int)", Down: "DROP TABLE groups"},
+		{Version: 10, Name: "emails", Up: "ALTER TABLE users ADD email text", Down: "ALTER TABLE users DROP email"},
+	} {
+		if m.migrations[i] != expected {
+			t.Errorf("unexpected migration %v - expected: %v", m.migrations[i], expected)
+		}
+	}
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"malformed": {"1_users.sql": {}},
+		"version":   {"users.up.sql": {}},
+		"duplicate": {"1_users.up.sql": {}, "1_groups.up.sql": {}},
+	} {
+		if _, err := Load(fsys, "."); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func expectVersion(mock sqlmock.Sqlmock, version int64, dirty bool) {
+	mock.ExpectExec(`^SELECT pg_advisory_lock\(\$1\)`).
+		WithArgs(int64(4156727022)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^CREATE TABLE IF NOT EXISTS schema_migrations`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	rows := sqlmock.NewRows([]string{"version", "dirty"})
+	if version != 0 || dirty {
+		rows.AddRow(version, dirty)
+	}
+	mock.ExpectQuery(`^SELECT version, dirty FROM schema_migrations LIMIT 1`).WillReturnRows(rows)
+}
+
+func expectSetVersion(mock sqlmock.Sqlmock, version int64, dirty bool) {
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 1))
+	if version != 0 || dirty {
+		mock.ExpectExec(`^INSERT INTO schema_migrations \(version, dirty\) VALUES \(\$1, \$2\)`).
+			WithArgs(version, dirty).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+}
+
+func expectUnlock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`^SELECT pg_advisory_unlock\(\$1\)`).
+		WithArgs(int64(4156727022)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestMigrator(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	migrations, err := Load(testFS, "migrations")
+	if err != nil {
+		t.Fatalf("failed to load migrations - %s", err)
+	}
+	m := New(migrations)
+	ctx := context.Background()
+
+	// the database is at version 1
+	expectVersion(mock, 1, false)
+	for _, v := range []int64{2, 10} {
+		expectSetVersion(mock, v, true)
+		mock.ExpectExec(`^(CREATE|ALTER) TABLE`).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectSetVersion(mock, v, false)
+	}
+	expectUnlock(mock)
+	if err := m.Up(ctx, db); err != nil {
+		t.Fatalf("failed to migrate up - %s", err)
+	}
+
+	expectVersion(mock, 10, false)
+	expectSetVersion(mock, 2, true)
+	mock.ExpectExec(`^ALTER TABLE users DROP email`).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectSetVersion(mock, 2, false)
+	expectUnlock(mock)
+	if err := m.To(ctx, db, 2); err != nil {
+		t.Fatalf("failed to migrate to 2 - %s", err)
+	}
+
+	// the failed migration leaves the database dirty
+	expectVersion(mock, 2, false)
+	expectSetVersion(mock, 1, true)
+	mock.ExpectExec(`^DROP TABLE groups`).WillReturnError(errors.New("table in use"))
+	expectUnlock(mock)
+	if err := m.Down(ctx, db); err == nil {
+		t.Error("expected error of failed migration")
+	}
+
+	expectVersion(mock, 1, true)
+	expectUnlock(mock)
+	if err := m.Down(ctx, db); err == nil || err.Error() != "Database at version 1, but is dirty" {
+		t.Errorf("unexpected error %v, expected dirty database", err)
+	}
+
+	if err := m.To(ctx, db, 3); err == nil {
+		t.Error("expected error of unknown version")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
@@ -0,0 +1,184 @@
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	defaultRelayBatchSize = 100
+	defaultRelayInterval  = time.Second
+	// relayLockKey is the key of the advisory lock relays take turns by, "outbox" in ASCII.
+	relayLockKey = 0x6f7574626f78
+)
+
+// OutboxEvent is an event stored in the outbox table until it is published by Relay.
+// Events are published in the order of their IDs on a best-effort basis, see Relay.
+type OutboxEvent struct {
+	ID        int64 `gorm:"primary_key"`
+	Topic     string
+	Key       string
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// TableName returns the name of the outbox table.
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+// Enqueue stores events in the outbox table within the transaction from ctx, starting the transaction
+// if necessary, so events are published by Relay if and only if the request transaction is committed.
+// ErrTxnReadOnly is returned if the transaction from ctx is READ ONLY, see WithReadReplica.
+func Enqueue(ctx context.Context, events ...*OutboxEvent) error {
+	if txn, ok := FromContext(ctx); ok && txn.readOnly {
+		return ErrTxnReadOnly
+	}
+	db, err := BeginFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range events {
+		if err := db.Create(e).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Publisher delivers outbox events to a message broker.
+type Publisher interface {
+	// Publish delivers event, an error stops the relay from publishing the following events
+	// until the next attempt.
+	Publish(ctx context.Context, event *OutboxEvent) error
+}
+
+// Relay drains the outbox table by publishing events with a Publisher.
+// Events are published with at-least-once delivery, i.e. an event is deleted from the outbox
+// only after it is published, so it can be published again if the relay fails to delete it.
+// Relays running concurrently against the same database take turns by means of a transaction-level
+// advisory lock, so a single relay publishes at a time. Events are published in the order of their IDs
+// on a best-effort basis: IDs are assigned before request transactions commit, so an event committed
+// after events with greater IDs are published is published after them.
+type Relay struct {
+	db           *gorm.DB
+	publisher    Publisher
+	batchSize    int
+	interval     time.Duration
+	errorHandler func(error)
+}
+
+// RelayOption configures Relay.
+type RelayOption func(*Relay)
+
+// WithRelayBatchSize sets the maximum number of events published within a single outbox transaction.
+func WithRelayBatchSize(n int) RelayOption {
+	return func(r *Relay) {
+		r.batchSize = n
+	}
+}
+
+// WithRelayInterval sets the interval Run polls the outbox table with once it is drained.
+func WithRelayInterval(d time.Duration) RelayOption {
+	return func(r *Relay) {
+		r.interval = d
+	}
+}
+
+// WithRelayErrorHandler sets the function Run reports errors of draining the outbox table to.
+func WithRelayErrorHandler(fn func(error)) RelayOption {
+	return func(r *Relay) {
+		r.errorHandler = fn
+	}
+}
+
+// NewRelay returns a new Relay that publishes events from the outbox table of db with publisher.
+func NewRelay(db *gorm.DB, publisher Publisher, options ...RelayOption) *Relay {
+	r := &Relay{
+		db:        db,
+		publisher: publisher,
+		batchSize: defaultRelayBatchSize,
+		interval:  defaultRelayInterval,
+	}
+	for _, o := range options {
+		o(r)
+	}
+	return r
+}
+
+// Drain publishes a batch of events from the outbox table and deletes the published ones.
+// Returns the number of published events, if publishing an event fails the events published
+// before it are deleted and the error is returned. Nothing is published if another relay is draining
+// the outbox table.
+func (r *Relay) Drain(ctx context.Context) (n int, err error) {
+	tx := r.db.BeginTx(ctx, nil)
+	if tx.Error != nil {
+		return 0, tx.Error
+	}
+	defer func() {
+		if perr := recover(); perr != nil {
+			tx.Rollback()
+			panic(perr)
+		}
+	}()
+
+	// the lock is released with the transaction, events are not locked, so writers are not blocked while publishing
+	var locked bool
+	if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", relayLockKey).Row().Scan(&locked); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if !locked {
+		return 0, tx.Rollback().Error
+	}
+
+	var events []*OutboxEvent
+	if err := tx.Order("id").Limit(r.batchSize).Find(&events).Error; err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	var published []int64
+	var perr error
+	for _, e := range events {
+		if perr = r.publisher.Publish(ctx, e); perr != nil {
+			perr = fmt.Errorf("failed to publish outbox event %d: %w", e.ID, perr)
+			break
+		}
+		published = append(published, e.ID)
+	}
+	if len(published) > 0 {
+		if err := tx.Where("id IN (?)", published).Delete(&OutboxEvent{}).Error; err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		return 0, err
+	}
+	return len(published), perr
+}
+
+// Run drains the outbox table until ctx is done. Once the table is drained, it is polled with
+// the relay interval. Errors are reported to the relay error handler and retried after the interval.
+func (r *Relay) Run(ctx context.Context) error {
+	for {
+		n, err := r.Drain(ctx)
+		if err != nil && r.errorHandler != nil {
+			r.errorHandler(err)
+		}
+		if err == nil && n == r.batchSize {
+			continue
+		}
+		t := time.NewTimer(r.interval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package gorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jinzhu/gorm"
+	fieldmask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/gateway"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/gorm/resource"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+	resourcepb "github.com/infobloxopen/atlas-app-toolkit/v2/rpc/resource"
+)
+
+// Repository implements CRUD and List operations of a resource that is represented by GORM model ORM
+// and protobuf message PB, e.g. by types generated by protoc-gen-gorm.
+// All operations run within the request transaction from context, see UnaryServerInterceptor.
+type Repository[ORM, PB any] struct {
+	toORM        func(*PB, context.Context) (ORM, error)
+	toPB         func(*ORM, context.Context) (PB, error)
+	converter    CollectionOperatorsConverter
+	versionField string
+	countOptions []CountOption
+}
+
+type repositoryOptions struct {
+	converter    CollectionOperatorsConverter
+	versionField string
+	countOptions []CountOption
+}
+
+// RepositoryOption configures Repository.
+type RepositoryOption func(*repositoryOptions)
+
+// WithConverter sets the converter of collection operators of List, NewDefaultPbToOrmConverter is used by default.
+func WithConverter(c CollectionOperatorsConverter) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.converter = c
+	}
+}
+
+// WithVersionField enables optimistic locking by field name of ORM, that is either an integer version
+// incremented by every update or a time.Time field updated by GORM, e.g. UpdatedAt.
+// Update fails with gateway.ETagMismatchError if the stored resource has been modified since the version
+// the client is aware of, i.e. the version of the If-Match header (see gateway.IfMatch) or, if there is
+// no such header, the version of the updated resource. Versions are emitted as ETag, see gateway.SetETag.
+func WithVersionField(name string) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.versionField = name
+	}
+}
+
+// WithCountOptions sets options of counting the total number of resources by List, see Count.
+// The total_size_approximate of the page info reports whether the total number is an estimate.
+func WithCountOptions(opts ...CountOption) RepositoryOption {
+	return func(o *repositoryOptions) {
+		o.countOptions = append(o.countOptions, opts...)
+	}
+}
+
+// NewRepository returns a new Repository that converts resources by means of toORM and toPB,
+// e.g. NewRepository((*pb.Contact).ToORM, (*ContactORM).ToPB).
+// Panics if *PB is not a protobuf message.
+func NewRepository[ORM, PB any](toORM func(*PB, context.Context) (ORM, error), toPB func(*ORM, context.Context) (PB, error), options ...RepositoryOption) *Repository[ORM, PB] {
+	pb, ok := interface{}(new(PB)).(proto.Message)
+	if !ok {
+		panic(fmt.Sprintf("gorm: %T is not a protobuf message", new(PB)))
+	}
+	opts := &repositoryOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	if opts.converter == nil {
+		opts.converter = NewDefaultPbToOrmConverter(pb)
+	}
+	return &Repository[ORM, PB]{toORM: toORM, toPB: toPB, converter: opts.converter, versionField: opts.versionField, countOptions: opts.countOptions}
+}
+
+// Create stores in and returns the stored resource.
+func (r *Repository[ORM, PB]) Create(ctx context.Context, in *PB) (*PB, error) {
+	db, err := BeginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orm, err := r.toORM(in, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Create(&orm).Error; err != nil {
+		return nil, err
+	}
+	r.setETag(ctx, db, &orm)
+	return r.pb(ctx, &orm)
+}
+
+// Read returns the resource with identifier id, associations are preloaded according to fs.
+// Returns codes.NotFound error if there is no such resource.
+func (r *Repository[ORM, PB]) Read(ctx context.Context, id *resourcepb.Identifier, fs *query.FieldSelection) (*PB, error) {
+	db, err := BeginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v, err := r.decode(id)
+	if err != nil {
+		return nil, err
+	}
+	orm := new(ORM)
+	db, err = ApplyFieldSelectionEx(ctx, r.wherePrimaryKey(db, v), fs, orm, r.converter)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.First(orm).Error; err != nil {
+		return nil, r.error(err)
+	}
+	r.setETag(ctx, db, orm)
+	return r.pb(ctx, orm)
+}
+
+// Update updates the stored resource with in and returns the updated resource.
+// If mask is not empty, only the fields listed in mask are updated, see MergeWithMask.
+// Returns codes.NotFound error if there is no such resource, see WithVersionField for optimistic locking.
+func (r *Repository[ORM, PB]) Update(ctx context.Context, in *PB, mask *fieldmask.FieldMask) (*PB, error) {
+	db, err := BeginFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orm, err := r.toORM(in, ctx)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := r.expectedETags(ctx, db, &orm)
+	if err != nil {
+		return nil, err
+	}
+	existing := new(ORM)
+	if err := r.wherePrimaryKey(db, db.NewScope(&orm).PrimaryKeyValue()).First(existing).Error; err != nil {
+		return nil, r.error(err)
+	}
+	if len(mask.GetPaths()) > 0 {
+		pb, err := r.toPB(existing, ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := MergeWithMask(in, &pb, mask); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if orm, err = r.toORM(&pb, ctx); err != nil {
+			return nil, err
+		}
+	}
+	if r.versionField == "" {
+		if err := db.Save(&orm).Error; err != nil {
+			return nil, err
+		}
+	} else if err := r.updateVersion(db, &orm, existing, expected); err != nil {
+		return nil, err
+	}
+	r.setETag(ctx, db, &orm)
+	return r.pb(ctx, &orm)
+}
+
+// expectedETags returns the entity tags of versions of the resource the client is aware of, nil if any version matches.
+func (r *Repository[ORM, PB]) expectedETags(ctx context.Context, db *gorm.DB, orm *ORM) ([]string, error) {
+	if r.versionField == "" {
+		return nil, nil
+	}
+	if etags, ok := gateway.IfMatch(ctx); ok {
+		return etags, nil
+	}
+	f, err := r.version(db, orm)
+	if err != nil || f.IsBlank {
+		return nil, err
+	}
+	return []string{versionETag(f.Field.Interface())}, nil
+}
+
+// updateVersion updates existing resource with orm by a conditional UPDATE, so it fails if the stored
+// version has changed since existing was read or does not match expected.
+func (r *Repository[ORM, PB]) updateVersion(db *gorm.DB, orm, existing *ORM, expected []string) error {
+	current, err := r.version(db, existing)
+	if err != nil {
+		return err
+	}
+	if expected != nil && !containsString(expected, versionETag(current.Field.Interface())) {
+		return r.versionMismatch()
+	}
+
+	scope := db.NewScope(orm)
+	attrs := make(map[string]interface{})
+	for _, f := range scope.Fields() {
+		if f.IsNormal && !f.IsPrimaryKey && !f.IsIgnored {
+			attrs[f.DBName] = f.Field.Interface()
+		}
+	}
+	next, err := r.version(db, orm)
+	if err != nil {
+		return err
+	}
+	switch next.Field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		next.Field.SetInt(current.Field.Int() + 1)
+		attrs[next.DBName] = next.Field.Interface()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		next.Field.SetUint(current.Field.Uint() + 1)
+		attrs[next.DBName] = next.Field.Interface()
+	}
+
+	db = db.Model(orm).Where(fmt.Sprintf("%s.%s = ?", scope.QuotedTableName(), scope.Quote(current.DBName)), current.Field.Interface()).Updates(attrs)
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return r.versionMismatch()
+	}
+	return nil
+}
+
+func (r *Repository[ORM, PB]) version(db *gorm.DB, orm *ORM) (*gorm.Field, error) {
+	f, ok := db.NewScope(orm).FieldByName(r.versionField)
+	if !ok {
+		return nil, fmt.Errorf("gorm: cannot find version field %s in %T", r.versionField, orm)
+	}
+	return f, nil
+}
+
+func (r *Repository[ORM, PB]) versionMismatch() error {
+	return gateway.ETagMismatchError(fmt.Sprintf("%s has been modified", resource.Name(r.message())))
+}
+
+// setETag emits the version of orm as ETag if optimistic locking is enabled.
+func (r *Repository[ORM, PB]) setETag(ctx context.Context, db *gorm.DB, orm *ORM) {
+	if r.versionField == "" {
+		return
+	}
+	if f, err := r.version(db, orm); err == nil {
+		// ETag is emitted only within a gRPC call, there is nothing to do about it otherwise
+		gateway.SetETag(ctx, versionETag(f.Field.Interface()))
+	}
+}
+
+// versionETag returns the entity tag of version v. Timestamps are rounded to microseconds as they are stored by postgres.
+func versionETag(v interface{}) string {
+	switch v := v.(type) {
+	case time.Time:
+		return strconv.FormatInt(v.Round(time.Microsecond).UnixMicro(), 10)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return versionETag(*v)
+	}
+	return fmt.Sprint(v)
+}
+
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Delete deletes the resource with identifier id.
+// Returns codes.NotFound error if there is no such resource.
+func (r *Repository[ORM, PB]) Delete(ctx context.Context, id *resourcepb.Identifier) error {
+	db, err := BeginFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	v, err := r.decode(id)
+	if err != nil {
+		return err
+	}
+	db = r.wherePrimaryKey(db, v).Delete(new(ORM))
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return r.error(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// List returns the page of resources selected by collection operators f, s, p and fs along with its page info.
+// In server-driven pagination mode, i.e. if the page token is set, the page token of the next page is returned,
+// otherwise its offset. The page token is issued by the converter for the page the token of p refers to,
+// see CursorPageInfoEx and OffsetPageInfoEx. The total number of resources matching f is returned if p requests it.
+// The page info is expected to be returned in the response, where gateway.UnaryServerInterceptor finds it
+// and passes it to REST clients, see gateway.SetPageInfo.
+func (r *Repository[ORM, PB]) List(ctx context.Context, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) ([]*PB, *query.PageInfo, error) {
+	tx, err := BeginFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	obj := new(ORM)
+	db, err := ApplyCollectionOperatorsEx(ctx, tx, obj, r.converter, f, s, p, fs)
+	if err != nil {
+		return nil, nil, err
+	}
+	var items []*ORM
+	if err := db.Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+
+	pi, err := r.pageInfo(ctx, items, f, s, p, fs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p.GetIsTotalSizeNeeded() {
+		if pi.TotalSize, pi.TotalSizeApproximate, err = r.count(ctx, tx, f); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	res := make([]*PB, 0, len(items))
+	for _, item := range items {
+		pb, err := r.pb(ctx, item)
+		if err != nil {
+			return nil, nil, err
+		}
+		res = append(res, pb)
+	}
+	return res, pi, nil
+}
+
+func (r *Repository[ORM, PB]) pageInfo(ctx context.Context, items []*ORM, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*query.PageInfo, error) {
+	if p.GetPageToken() != "" {
+		_, isCursor := cursorConverter(r.converter)
+		tc, isToken := r.converter.(PageTokenConverter)
+		switch {
+		case isCursor && isToken:
+			return CursorPageInfoEx(ctx, items, tc, f, s, p, fs)
+		case isCursor:
+			return CursorPageInfo(ctx, items, s, p)
+		case isToken:
+			return OffsetPageInfoEx(ctx, items, tc, f, s, p, fs)
+		}
+	}
+	pi := &query.PageInfo{Size: int32(len(items))}
+	if l := p.GetLimit(); l > 0 {
+		if int32(len(items)) < l {
+			pi.SetLastOffset()
+		} else {
+			pi.Offset = p.GetOffset() + l
+		}
+	}
+	return pi, nil
+}
+
+// count returns the number of resources matching f and reports whether the number is an estimate.
+func (r *Repository[ORM, PB]) count(ctx context.Context, db *gorm.DB, f *query.Filtering) (int64, bool, error) {
+	total, mode, err := Count(ctx, db, new(ORM), r.converter, f, r.countOptions...)
+	return total, mode.Approximate(), err
+}
+
+func (r *Repository[ORM, PB]) pb(ctx context.Context, orm *ORM) (*PB, error) {
+	pb, err := r.toPB(orm, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb, nil
+}
+
+func (r *Repository[ORM, PB]) message() proto.Message {
+	return interface{}(new(PB)).(proto.Message)
+}
+
+func (r *Repository[ORM, PB]) decode(id *resourcepb.Identifier) (driver.Value, error) {
+	v, err := resource.Decode(r.message(), id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if v == nil {
+		return nil, status.Error(codes.InvalidArgument, "resource identifier is missing")
+	}
+	return v, nil
+}
+
+func (r *Repository[ORM, PB]) wherePrimaryKey(db *gorm.DB, v interface{}) *gorm.DB {
+	scope := db.NewScope(new(ORM))
+	return db.Where(fmt.Sprintf("%s.%s = ?", scope.QuotedTableName(), scope.Quote(scope.PrimaryKey())), v)
+}
+
+func (r *Repository[ORM, PB]) error(err error) error {
+	if gorm.IsRecordNotFoundError(err) {
+		return status.Errorf(codes.NotFound, "%s not found", resource.Name(r.message()))
+	}
+	return err
+}
@@ -0,0 +1,322 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jinzhu/gorm"
+	fieldmask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/gateway"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/gorm/resource"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+	resourcepb "github.com/infobloxopen/atlas-app-toolkit/v2/rpc/resource"
+)
+
+type Contact struct {
+	Id    *resourcepb.Identifier
+	Name  string
+	Email string
+}
+
+func (*Contact) Reset()               {}
+func (*Contact) ProtoMessage()        {}
+func (*Contact) String() string       { return "Contact" }
+func (*Contact) ResourceName() string { return "contact" }
+
+func (m *Contact) ToORM(ctx context.Context) (ContactORM, error) {
+	id, err := resource.DecodeInt64(m, m.Id)
+	if err != nil {
+		return ContactORM{}, err
+	}
+	return ContactORM{Id: id, Name: m.Name, Email: m.Email}, nil
+}
+
+type ContactORM struct {
+	Id    int64 `gorm:"primary_key"`
+	Name  string
+	Email string
+}
+
+func (ContactORM) TableName() string { return "contacts" }
+
+func (m *ContactORM) ToPB(ctx context.Context) (Contact, error) {
+	id, err := resource.Encode(&Contact{}, m.Id)
+	if err != nil {
+		return Contact{}, err
+	}
+	return Contact{Id: id, Name: m.Name, Email: m.Email}, nil
+}
+
+func setUpRepository(t *testing.T) (context.Context, *Repository[ContactORM, Contact], sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	ctx := NewContext(context.Background(), &Transaction{parent: gdb})
+	return ctx, NewRepository((*Contact).ToORM, (*ContactORM).ToPB), mock
+}
+
+func TestRepository_CRUD(t *testing.T) {
+	ctx, repo, mock := setUpRepository(t)
+	mock.ExpectBegin()
+
+	mock.ExpectQuery(`^INSERT INTO "contacts" \("name","email"\) VALUES \(\$1,\$2\) RETURNING "contacts"."id"`).
+		WithArgs("John", "john@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	created, err := repo.Create(ctx, &Contact{Name: "John", Email: "john@example.com"})
+	if err != nil {
+		t.Fatalf("failed to create contact - %s", err)
+	}
+	if created.Id.GetResourceId() != "1" || created.Name != "John" {
+		t.Errorf("unexpected created contact %v", created)
+	}
+
+	mock.ExpectQuery(`^SELECT \* FROM "contacts" WHERE \("contacts"."id" = \$1\) ORDER BY "contacts"."id" ASC LIMIT 1`).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "John", "john@example.com"))
+	read, err := repo.Read(ctx, created.Id, nil)
+	if err != nil {
+		t.Fatalf("failed to read contact - %s", err)
+	}
+	if read.Email != "john@example.com" {
+		t.Errorf("unexpected read contact %v", read)
+	}
+
+	// only the masked field is updated, the rest is kept
+	mock.ExpectQuery(`^SELECT \* FROM "contacts" WHERE \("contacts"."id" = \$1\) ORDER BY "contacts"."id" ASC LIMIT 1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "John", "john@example.com"))
+	mock.ExpectExec(`^UPDATE "contacts" SET "name" = \$1, "email" = \$2 WHERE "contacts"."id" = \$3`).
+		WithArgs("Johnny", "john@example.com", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	updated, err := repo.Update(ctx, &Contact{Id: created.Id, Name: "Johnny"}, &fieldmask.FieldMask{Paths: []string{"name"}})
+	if err != nil {
+		t.Fatalf("failed to update contact - %s", err)
+	}
+	if updated.Name != "Johnny" || updated.Email != "john@example.com" {
+		t.Errorf("unexpected updated contact %v", updated)
+	}
+
+	mock.ExpectExec(`^DELETE FROM "contacts" WHERE \("contacts"."id" = \$1\)`).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.Delete(ctx, created.Id); err != nil {
+		t.Errorf("failed to delete contact - %s", err)
+	}
+
+	mock.ExpectExec(`^DELETE FROM "contacts" WHERE \("contacts"."id" = \$1\)`).
+		WithArgs("1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := repo.Delete(ctx, created.Id); status.Code(err) != codes.NotFound {
+		t.Errorf("unexpected error %v, expected NotFound", err)
+	}
+
+	if _, err := repo.Read(ctx, nil, nil); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("unexpected error %v, expected InvalidArgument", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
+
+func TestRepository_List(t *testing.T) {
+	ctx, repo, mock := setUpRepository(t)
+	f, err := query.ParseFiltering("name == 'John'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := query.ParsePagination("2", "4", "", "true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT \* FROM "contacts" WHERE \(\(contacts.name = \$1\)\) LIMIT 2 OFFSET 4`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "John").AddRow(6, "John"))
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "contacts" WHERE \(\(contacts.name = \$1\)\)`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	items, pi, err := repo.List(ctx, f, nil, p, nil)
+	if err != nil {
+		t.Fatalf("failed to list contacts - %s", err)
+	}
+	if len(items) != 2 || items[1].Id.GetResourceId() != "6" {
+		t.Errorf("unexpected contacts %v", items)
+	}
+	if pi.GetSize() != 2 || pi.GetOffset() != 6 || pi.GetTotalSize() != 7 {
+		t.Errorf("unexpected page info %v", pi)
+	}
+
+	// the last page
+	p.IsTotalSizeNeeded = false
+	mock.ExpectQuery(`^SELECT \* FROM "contacts" WHERE \(\(contacts.name = \$1\)\) LIMIT 2 OFFSET 4`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "John"))
+	_, pi, err = repo.List(ctx, f, nil, p, nil)
+	if err != nil {
+		t.Fatalf("failed to list contacts - %s", err)
+	}
+	if !pi.NoMore() || pi.GetTotalSize() != 0 {
+		t.Errorf("unexpected page info %v", pi)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
+
+func TestRepository_ListPageToken(t *testing.T) {
+	ctx, _, mock := setUpRepository(t)
+	c := NewDefaultPbToOrmConverter(&Contact{}).(*DefaultPbToOrmConverter)
+	c.Codec = query.NewHMACPageTokenCodec([]byte("secret"), time.Hour)
+	repo := NewRepository((*Contact).ToORM, (*ContactORM).ToPB, WithConverter(c))
+	f, err := query.ParseFiltering("name == 'John'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT \* FROM "contacts" WHERE \(\(contacts.name = \$1\)\) LIMIT 2$`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "John").AddRow(2, "John"))
+	_, pi, err := repo.List(ctx, f, nil, &query.Pagination{PageToken: "null", Limit: 2}, nil)
+	if err != nil {
+		t.Fatalf("failed to list contacts - %s", err)
+	}
+	if pi.GetSize() != 2 || pi.GetOffset() != 0 || pi.NoMore() {
+		t.Fatalf("unexpected page info %v", pi)
+	}
+
+	// the next page is selected by the signed offset page token, not by the limit of the request
+	mock.ExpectQuery(`^SELECT \* FROM "contacts" WHERE \(\(contacts.name = \$1\)\) LIMIT 2 OFFSET 2$`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "John").AddRow(4, "John"))
+	_, pi, err = repo.List(ctx, f, nil, &query.Pagination{PageToken: pi.GetPageToken(), Limit: 1000}, nil)
+	if err != nil {
+		t.Fatalf("failed to list contacts - %s", err)
+	}
+	token, err := c.PageTokenToGorm(ctx, &query.Pagination{PageToken: pi.GetPageToken()}, query.PageTokenScope(f, nil, nil))
+	if err != nil {
+		t.Fatalf("failed to decode page token - %s", err)
+	}
+	if pi.GetSize() != 2 || token.Offset != 4 || token.Limit != 2 {
+		t.Errorf("unexpected page info %v and page token %+v", pi, token)
+	}
+
+	// the last page
+	mock.ExpectQuery(`^SELECT \* FROM "contacts" WHERE \(\(contacts.name = \$1\)\) LIMIT 2 OFFSET 4$`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(5, "John"))
+	_, pi, err = repo.List(ctx, f, nil, &query.Pagination{PageToken: pi.GetPageToken()}, nil)
+	if err != nil {
+		t.Fatalf("failed to list contacts - %s", err)
+	}
+	if pi.GetSize() != 1 || !pi.NoMore() {
+		t.Errorf("unexpected page info %v", pi)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
+
+type Note struct {
+	Id      *resourcepb.Identifier
+	Text    string
+	Version int64
+}
+
+func (*Note) Reset()               {}
+func (*Note) ProtoMessage()        {}
+func (*Note) String() string       { return "Note" }
+func (*Note) ResourceName() string { return "note" }
+
+func (m *Note) ToORM(ctx context.Context) (NoteORM, error) {
+	id, err := resource.DecodeInt64(m, m.Id)
+	if err != nil {
+		return NoteORM{}, err
+	}
+	return NoteORM{Id: id, Text: m.Text, Version: m.Version}, nil
+}
+
+type NoteORM struct {
+	Id      int64 `gorm:"primary_key"`
+	Text    string
+	Version int64
+}
+
+func (NoteORM) TableName() string { return "notes" }
+
+func (m *NoteORM) ToPB(ctx context.Context) (Note, error) {
+	id, err := resource.Encode(&Note{}, m.Id)
+	if err != nil {
+		return Note{}, err
+	}
+	return Note{Id: id, Text: m.Text, Version: m.Version}, nil
+}
+
+func TestRepository_UpdateVersion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	ctx := NewContext(context.Background(), &Transaction{parent: gdb})
+	repo := NewRepository((*Note).ToORM, (*NoteORM).ToPB, WithVersionField("Version"))
+	id := &resourcepb.Identifier{ResourceId: "1"}
+	selectNote := func(version int64) {
+		mock.ExpectQuery(`^SELECT \* FROM "notes" WHERE \("notes"."id" = \$1\)`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "text", "version"}).AddRow(1, "draft", version))
+	}
+
+	mock.ExpectBegin()
+	selectNote(2)
+	mock.ExpectExec(`^UPDATE "notes" SET "text" = \$1, "version" = \$2 WHERE "notes"."id" = \$3 AND \(\("notes"."version" = \$4\)\)`).
+		WithArgs("final", 3, 1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	updated, err := repo.Update(ctx, &Note{Id: id, Text: "final", Version: 2}, nil)
+	if err != nil {
+		t.Fatalf("failed to update note - %s", err)
+	}
+	if updated.Version != 3 {
+		t.Errorf("unexpected version %d - expected: 3", updated.Version)
+	}
+
+	// the note has been modified since the version of If-Match
+	selectNote(3)
+	ictx := metadata.NewIncomingContext(ctx, metadata.Pairs("grpcgateway-if-match", `"2"`))
+	_, err = repo.Update(ictx, &Note{Id: id, Text: "final"}, &fieldmask.FieldMask{Paths: []string{"text"}})
+	if st := status.Convert(err); !gateway.IsETagMismatch(st) {
+		t.Errorf("unexpected error %v, expected etag mismatch", err)
+	}
+
+	// the note is modified concurrently
+	selectNote(3)
+	mock.ExpectExec(`^UPDATE "notes"`).
+		WithArgs("final", 4, 1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = repo.Update(ctx, &Note{Id: id, Text: "final", Version: 3}, nil)
+	if st := status.Convert(err); st.Code() != codes.FailedPrecondition {
+		t.Errorf("unexpected error %v, expected FailedPrecondition", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
@@ -0,0 +1,88 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// serializationFailureCodes are SQLSTATE codes of transactions that failed due to
+// concurrent transactions and may succeed if retried.
+var serializationFailureCodes = map[string]struct{}{
+	"40001": {}, // serialization_failure
+	"40P01": {}, // deadlock_detected
+}
+
+// maxRetryBackoff is the maximum backoff between attempts of WithRetry.
+const maxRetryBackoff = 10 * time.Second
+
+// WithRetry makes UnaryServerInterceptorTxn re-run the handler with a fresh Transaction up to
+// maxRetries times if the handler or commit fails with a serialization failure or a deadlock,
+// see IsSerializationFailure. Attempts are separated by an exponential backoff starting at
+// backoff with random jitter, the backoff grows up to 10 seconds. Handlers are expected to return database errors as is or wrapped
+// with fmt.Errorf("%w"), otherwise only commit errors can be recognized.
+// After-commit hooks are run once, after the attempt that was committed.
+func WithRetry(maxRetries int, backoff time.Duration) InterceptorOption {
+	return func(o *interceptorOptions) {
+		o.maxRetries = maxRetries
+		o.backoff = backoff
+		o.retryable = IsSerializationFailure
+	}
+}
+
+// IsSerializationFailure reports whether err is a postgres serialization failure (40001)
+// or a deadlock (40P01), i.e. the transaction failed due to concurrent transactions and
+// may succeed if retried.
+func IsSerializationFailure(err error) bool {
+	_, ok := serializationFailureCodes[sqlState(err)]
+	return ok
+}
+
+// sqlState returns the SQLSTATE code of postgres error err, an empty string if err is not a postgres error.
+func sqlState(err error) string {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return ""
+	}
+	return string(pqErr.Code)
+}
+
+func (o *interceptorOptions) shouldRetry(err error) bool {
+	return o.retryable != nil && err != nil && o.retryable(err)
+}
+
+// delay returns the backoff before the retry that follows the given attempt. The backoff doubles
+// with every attempt up to maxRetryBackoff, or up to the initial backoff if it is greater.
+func (o *interceptorOptions) delay(attempt int) time.Duration {
+	limit := maxRetryBackoff
+	if o.backoff > limit {
+		limit = o.backoff
+	}
+	d := o.backoff
+	for i := 0; i < attempt && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		d = limit
+	}
+	if d > 0 {
+		// half of the delay is fixed, the other half is random jitter
+		d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	}
+	return d
+}
+
+// wait sleeps before the retry that follows the given attempt, returns an error if ctx is done first.
+func (o *interceptorOptions) wait(ctx context.Context, attempt int) error {
+	t := time.NewTimer(o.delay(attempt))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -2,57 +2,73 @@ package gorm
 
 import (
 	"reflect"
+	"strings"
 	"time"
 )
 
-// GetFullTextSearchDBMask ...
+// GetFullTextSearchDBMask returns the document of full-text search built from columns of fields of object
+// joined by separator. Strings, numbers, booleans, timestamps and arrays of strings are supported,
+// fields of other types are skipped. Nullable columns are replaced by empty strings if they are null.
 func GetFullTextSearchDBMask(object interface{}, fields []string, separator string) string {
-	mask := ""
-	objectVal := indirectValue(reflect.ValueOf(object))
-	if objectVal.Kind() != reflect.Struct {
-		return mask
+	objectType := indirectType(reflect.TypeOf(object))
+	if objectType.Kind() != reflect.Struct {
+		return ""
 	}
-	fieldsSize := len(fields)
-	for i, fieldName := range fields {
-		fieldVal := objectVal.FieldByName(camelCase(fieldName))
-		if !fieldVal.IsValid() {
+	var parts []string
+	for _, fieldName := range fields {
+		sf, ok := objectType.FieldByName(camelCase(fieldName))
+		if !ok {
 			continue
 		}
-		underlyingVal := indirectValue(fieldVal)
-		if !underlyingVal.IsValid() {
-			switch fieldVal.Interface().(type) {
-			case *time.Time:
-				underlyingVal = fieldVal
-			default:
-				continue
-			}
+		fieldType, nullable := sf.Type, false
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType, nullable = fieldType.Elem(), true
 		}
-		switch underlyingVal.Interface().(type) {
-		case int32:
-			mask += fieldName
-		case string:
-			mask += fieldName
-			mask += " || '" + separator + "' || "
-			mask += "replace(" + fieldName + ", '@', ' ')"
-			mask += " || '" + separator + "' || "
-			mask += "replace(" + fieldName + ", '.', ' ')"
-		case *time.Time:
-			mask += "coalesce(to_char(" + fieldName + ", 'MM/DD/YY HH:MI pm'), '')"
-		case bool:
-			mask += fieldName
-		default:
-			continue
+		column := fieldName
+		if nullable {
+			column = "coalesce(" + fieldName + "::text, '')"
 		}
-		if i != fieldsSize-1 {
-			mask += " || '" + separator + "' || "
+		switch {
+		case fieldType == reflect.TypeOf(time.Time{}):
+			parts = append(parts, "coalesce(to_char("+fieldName+", 'MM/DD/YY HH:MI pm'), '')")
+		case fieldType.Kind() == reflect.String:
+			parts = append(parts, column+" || '"+separator+"' || "+
+				"replace("+column+", '@', ' ')"+" || '"+separator+"' || "+
+				"replace("+column+", '.', ' ')")
+		case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.String:
+			parts = append(parts, "coalesce(array_to_string("+fieldName+", '"+separator+"'), '')")
+		case fieldType.Kind() == reflect.Bool,
+			fieldType.Kind() >= reflect.Int && fieldType.Kind() <= reflect.Float64:
+			parts = append(parts, column)
 		}
 	}
-
-	return mask
+	return strings.Join(parts, " || '"+separator+"' || ")
 }
 
-// FormFullTextSearchQuery ...
+// FormFullTextSearchQuery returns the condition that matches mask against the search query converted by SearchQuery.
 func FormFullTextSearchQuery(mask string) string {
 	fullTextSearchQuery := "to_tsvector('simple', " + mask + ") @@ to_tsquery('simple', ?)"
 	return fullTextSearchQuery
 }
+
+// SearchQuery converts search query q to the argument of to_tsquery that matches records
+// containing all words of q, the last one as a prefix, e.g. "john smi" is converted to "john & smi:*",
+// so results are narrowed as the query is typed. Queries containing tsquery operators match nothing.
+func SearchQuery(q string) string {
+	q = strings.ReplaceAll(strings.TrimSpace(q), ":", " ")
+	for _, spl := range []string{"(", ")", "|", "+", "<", "'", "&", "!", "%", ";"} {
+		if strings.Contains(q, spl) {
+			return ""
+		}
+	}
+	words := strings.Fields(q)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.Join(words, " & ") + ":*"
+}
+
+// quoteLiteral returns s quoted as a SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,121 @@
+package gorm
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+type SearchableORM struct {
+	Name      string
+	Age       int64
+	Score     float32
+	Active    bool
+	Nickname  *string
+	Level     *uint32
+	Tags      pq.StringArray
+	CreatedAt time.Time
+	DeletedAt *time.Time
+	Data      []byte
+}
+
+func TestGetFullTextSearchDBMask(t *testing.T) {
+	for _, tc := range []struct {
+		fields   []string
+		expected string
+	}{
+		{
+			fields:   []string{"name"},
+			expected: "name || ' ' || replace(name, '@', ' ') || ' ' || replace(name, '.', ' ')",
+		},
+		{
+			fields:   []string{"age", "data", "score", "active", "unknown"},
+			expected: "age || ' ' || score || ' ' || active",
+		},
+		{
+			fields: []string{"nickname", "level"},
+			expected: "coalesce(nickname::text, '') || ' ' || replace(coalesce(nickname::text, ''), '@', ' ') || ' ' || " +
+				"replace(coalesce(nickname::text, ''), '.', ' ') || ' ' || coalesce(level::text, '')",
+		},
+		{
+			fields: []string{"tags", "created_at", "deleted_at"},
+			expected: "coalesce(array_to_string(tags, ' '), '') || ' ' || " +
+				"coalesce(to_char(created_at, 'MM/DD/YY HH:MI pm'), '') || ' ' || coalesce(to_char(deleted_at, 'MM/DD/YY HH:MI pm'), '')",
+		},
+	} {
+		if mask := GetFullTextSearchDBMask(&SearchableORM{}, tc.fields, " "); mask != tc.expected {
+			t.Errorf("unexpected mask of %v:\n%s\nexpected:\n%s", tc.fields, mask, tc.expected)
+		}
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	for q, expected := range map[string]string{
+		"  john  smith ": "john & smith:*",
+		"john:smith":     "john & smith:*",
+		"john | smith":   "",
+		"   ":            "",
+	} {
+		if actual := SearchQuery(q); actual != expected {
+			t.Errorf("unexpected search query of %q: %q - expected: %q", q, actual, expected)
+		}
+	}
+}
+
+func TestApplyCollectionOperatorsWithSearching(t *testing.T) {
+	ctx := context.Background()
+	db, mock := setUp(t)
+	s := &query.Searching{Query: "john smith"}
+
+	c := NewDefaultPbToOrmConverter(&Contact{})
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE (to_tsvector('simple', name || ' ' || replace(name, '@', ' ') || ' ' || replace(name, '.', ' ')) @@ to_tsquery('simple', $1))`)).
+		WithArgs("john & smith:*").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	gormDB, err := ApplyCollectionOperatorsWithSearchingEx(ctx, db, &ContactORM{}, c, nil, nil, nil, nil, s, []string{"name"})
+	if err != nil {
+		t.Fatalf("failed to apply searching - %s", err)
+	}
+	if err := gormDB.Find(&[]ContactORM{}).Error; err != nil {
+		t.Fatalf("failed to search contacts - %s", err)
+	}
+
+	// records are ordered by rank unless sorting is requested
+	rc := NewDefaultPbToOrmConverter(&Contact{}).(*DefaultPbToOrmConverter)
+	rc.DefaultSearchingConverter = DefaultSearchingConverter{Dictionary: "english", Column: "tsv", WebSearch: true, Rank: true}
+	c = rc
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE (contacts.tsv @@ websearch_to_tsquery('english', $1)) ORDER BY ts_rank(contacts.tsv, websearch_to_tsquery('english', $2)) DESC`)).
+		WithArgs("john smith", "john smith").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	gormDB, err = ApplyCollectionOperatorsWithSearchingEx(ctx, db, &ContactORM{}, c, nil, nil, nil, nil, s, []string{"name"})
+	if err != nil {
+		t.Fatalf("failed to apply searching - %s", err)
+	}
+	if err := gormDB.Find(&[]ContactORM{}).Error; err != nil {
+		t.Fatalf("failed to search contacts - %s", err)
+	}
+
+	sort, err := query.ParseSorting("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "contacts" WHERE (contacts.tsv @@ websearch_to_tsquery('english', $1)) ORDER BY "contacts"."name"`)).
+		WithArgs("john smith").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	gormDB, err = ApplyCollectionOperatorsWithSearchingEx(ctx, db, &ContactORM{}, c, nil, sort, nil, nil, s, []string{"name"})
+	if err != nil {
+		t.Fatalf("failed to apply searching - %s", err)
+	}
+	if err := gormDB.Find(&[]ContactORM{}).Error; err != nil {
+		t.Fatalf("failed to search contacts - %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
@@ -0,0 +1,53 @@
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// deletedAtField is the name of the field GORM uses to mark records as soft deleted.
+const deletedAtField = "DeletedAt"
+
+// PurgeDeleted permanently deletes records of obj's model that were soft deleted
+// more than retention ago and returns the number of purged records.
+// An error is returned if obj's model does not support soft delete, i.e. has no DeletedAt field.
+func PurgeDeleted(ctx context.Context, db *gorm.DB, obj interface{}, retention time.Duration) (int64, error) {
+	column, ok := softDeleteColumn(indirectType(reflect.TypeOf(obj)))
+	if !ok {
+		return 0, fmt.Errorf("%T does not support soft delete", obj)
+	}
+	scope := db.NewScope(obj)
+	cond := fmt.Sprintf("%s.%s < ?", scope.QuotedTableName(), scope.Quote(column))
+	res := db.Unscoped().Where(cond, time.Now().Add(-retention)).Delete(obj)
+	return res.RowsAffected, res.Error
+}
+
+// isUnscoped reports whether soft deleted records are included into results of db.
+func isUnscoped(db *gorm.DB, obj interface{}) bool {
+	return db.NewScope(obj).Search.Unscoped
+}
+
+// softDeleteCondition returns the condition that excludes soft deleted records of
+// t model joined as alias, or an empty string if the model does not support soft delete.
+func softDeleteCondition(t reflect.Type, alias string) string {
+	column, ok := softDeleteColumn(t)
+	if !ok {
+		return ""
+	}
+	return alias + "." + column + " IS NULL"
+}
+
+func softDeleteColumn(t reflect.Type) (string, bool) {
+	if t.Kind() != reflect.Struct {
+		return "", false
+	}
+	sf, ok := t.FieldByName(deletedAtField)
+	if !ok {
+		return "", false
+	}
+	return columnName(&sf), true
+}
@@ -0,0 +1,82 @@
+package gorm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+type Post struct {
+	Id        int64
+	Title     string
+	AuthorId  int64
+	Author    Author `gorm:"foreignkey:AuthorId;association_foreignkey:Id"`
+	DeletedAt *time.Time
+}
+
+type Author struct {
+	Id        int64
+	Name      string
+	DeletedAt *time.Time
+}
+
+func TestApplyCollectionOperators_softDelete(t *testing.T) {
+	for _, test := range []struct {
+		includeDeleted bool
+		expected       string
+	}{
+		{
+			false,
+			`SELECT "posts".* FROM "posts" LEFT JOIN authors author ON posts.author_id = author.id AND author.deleted_at IS NULL WHERE "posts"."deleted_at" IS NULL AND (((author.name = $1)))`,
+		},
+		{
+			true,
+			`SELECT "posts".* FROM "posts" LEFT JOIN authors author ON posts.author_id = author.id WHERE ((author.name = $1))`,
+		},
+	} {
+		f, err := query.ParseFiltering("author.name == 'John'")
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.IncludeDeleted = test.includeDeleted
+
+		gormDB, mock := setUp(t)
+		gormDB, err = ApplyCollectionOperatorsEx(context.Background(), gormDB, &Post{}, NewDefaultPbToOrmConverter(&PersonProto{}), f, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mock.ExpectQuery(fixedFullRe(test.expected)).WithArgs("John").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "title"}))
+
+		var actual []Post
+		gormDB.Find(&actual)
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("include deleted %t: %s", test.includeDeleted, err)
+		}
+	}
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	gormDB, mock := setUp(t)
+	mock.ExpectBegin()
+	mock.ExpectExec(fixedFullRe(`DELETE FROM "posts" WHERE ("posts"."deleted_at" < $1)`)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	n, err := PurgeDeleted(context.Background(), gormDB, &Post{}, 30*24*time.Hour)
+	if err != nil || n != 3 {
+		t.Errorf("unexpected error %v and number of purged records %d", err, n)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to purge deleted records - %s", err)
+	}
+
+	if _, err := PurgeDeleted(context.Background(), gormDB, &Parent{}, time.Hour); err == nil {
+		t.Error("expected error for a model without soft delete")
+	}
+}
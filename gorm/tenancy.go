@@ -0,0 +1,148 @@
+package gorm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/auth"
+)
+
+// tenantSetting is the name of the gorm setting that carries the *Tenant of a transaction.
+const tenantSetting = "atlas:tenant"
+
+// tenancyCallback is the name of gorm callbacks that scope queries to the tenant.
+const tenancyCallback = "atlas:tenancy"
+
+// ErrTenantMissing is returned by transactions of requests that have no tenant, see WithTenancy.
+var ErrTenantMissing = status.Error(codes.Unauthenticated, "Tenant for request missing in context")
+
+// Tenant is the account and, optionally, the compartment the data of a request belongs to.
+// Models are scoped to the tenant by auth.MultiTenancyField and auth.MultiCompartmentField columns.
+type Tenant struct {
+	AccountID     string
+	CompartmentID string
+}
+
+// TenantFromContext returns the tenant of the authorization token of ctx, see auth.GetAccountID
+// and auth.GetCompartmentID. The token is expected to be verified earlier in the interceptor chain.
+// ErrTenantMissing is returned if the token has no account ID.
+func TenantFromContext(ctx context.Context) (*Tenant, error) {
+	accountID, err := auth.GetAccountID(ctx, nil)
+	if err != nil || accountID == "" {
+		return nil, ErrTenantMissing
+	}
+	compartmentID, err := auth.GetCompartmentID(ctx, nil)
+	if err != nil {
+		return nil, ErrTenantMissing
+	}
+	return &Tenant{AccountID: accountID, CompartmentID: compartmentID}, nil
+}
+
+// WithTenancy makes transaction interceptors scope the data of requests to the tenant returned by tenant,
+// TenantFromContext is used if tenant is nil.
+// Queries, updates and deletes done through the transaction of a request are restricted to the rows of the tenant,
+// as well as associations joined by JoinAssociations, and created rows are assigned to the tenant.
+// Only models that have the tenant columns are scoped, raw SQL is never scoped.
+// If a request has no tenant, its transaction fails to begin with the error returned by tenant.
+func WithTenancy(tenant func(ctx context.Context) (*Tenant, error)) InterceptorOption {
+	return func(o *interceptorOptions) {
+		if tenant == nil {
+			tenant = TenantFromContext
+		}
+		o.tenant = tenant
+	}
+}
+
+// tenantFromDB returns the tenant gorm instance db is scoped to, if any.
+func tenantFromDB(db *gorm.DB) (*Tenant, bool) {
+	v, ok := db.Get(tenantSetting)
+	if !ok {
+		return nil, false
+	}
+	tenant, ok := v.(*Tenant)
+	return tenant, ok && tenant != nil
+}
+
+// columns returns the tenant columns and their values.
+func (t *Tenant) columns() ([]string, []interface{}) {
+	if t.CompartmentID == "" {
+		return []string{auth.MultiTenancyField}, []interface{}{t.AccountID}
+	}
+	return []string{auth.MultiTenancyField, auth.MultiCompartmentField}, []interface{}{t.AccountID, t.CompartmentID}
+}
+
+// tenantCondition returns the condition that restricts t model joined as alias to the tenant of db,
+// or an empty string if db is not scoped or the model has no tenant columns.
+func tenantCondition(db *gorm.DB, t reflect.Type, alias string) (string, []interface{}) {
+	tenant, ok := tenantFromDB(db)
+	if !ok {
+		return "", nil
+	}
+	scope := db.NewScope(reflect.New(t).Interface())
+	var conds []string
+	var args []interface{}
+	cols, vals := tenant.columns()
+	for i, col := range cols {
+		if field, ok := scope.FieldByName(col); ok {
+			conds = append(conds, alias+"."+field.DBName+" = ?")
+			args = append(args, vals[i])
+		}
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// registerTenancyCallbacks registers callbacks that scope queries of db to the tenant of the transaction.
+func registerTenancyCallbacks(db *gorm.DB) {
+	if db == nil || db.Callback().Query().Get(tenancyCallback) != nil {
+		return
+	}
+	db.Callback().Create().Before("gorm:create").Register(tenancyCallback, tenancyAssignCallback)
+	db.Callback().Query().Before("gorm:query").Register(tenancyCallback, tenancyScopeCallback)
+	db.Callback().RowQuery().Before("gorm:row_query").Register(tenancyCallback, tenancyScopeCallback)
+	db.Callback().Update().Before("gorm:update").Register(tenancyCallback, func(scope *gorm.Scope) {
+		tenancyAssignCallback(scope)
+		tenancyScopeCallback(scope)
+	})
+	db.Callback().Delete().Before("gorm:delete").Register(tenancyCallback, tenancyScopeCallback)
+}
+
+// tenancyScopeCallback restricts the query of scope to the rows of the tenant.
+func tenancyScopeCallback(scope *gorm.Scope) {
+	tenant, ok := tenantFromScope(scope)
+	if !ok {
+		return
+	}
+	cols, vals := tenant.columns()
+	for i, col := range cols {
+		if field, ok := scope.FieldByName(col); ok {
+			scope.Search.Where(fmt.Sprintf("%s.%s = ?", scope.QuotedTableName(), scope.Quote(field.DBName)), vals[i])
+		}
+	}
+}
+
+// tenancyAssignCallback assigns the created or updated row of scope to the tenant.
+func tenancyAssignCallback(scope *gorm.Scope) {
+	tenant, ok := tenantFromScope(scope)
+	if !ok {
+		return
+	}
+	cols, vals := tenant.columns()
+	for i, col := range cols {
+		if field, ok := scope.FieldByName(col); ok {
+			scope.Err(scope.SetColumn(field, vals[i]))
+		}
+	}
+}
+
+func tenantFromScope(scope *gorm.Scope) (*Tenant, bool) {
+	if scope.HasError() {
+		return nil, false
+	}
+	return tenantFromDB(scope.DB())
+}
@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errdetails"
@@ -27,8 +31,13 @@ var txnKey ctxKey
 var (
 	ErrCtxTxnMissing = errors.New("Database transaction for request missing in context")
 	ErrCtxTxnNoDB    = errors.New("Transaction in context, but DB is nil")
+	ErrTxnNotStarted = errors.New("Transaction is not started")
+	ErrTxnReadOnly   = errors.New("Transaction is READ ONLY")
 )
 
+// savepointName restricts savepoint names to SQL identifiers, as they cannot be passed as query arguments.
+var savepointName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // NewContext returns a new Context that carries value txn.
 func NewContext(parent context.Context, txn *Transaction) context.Context {
 	return context.WithValue(parent, txnKey, txn)
@@ -43,11 +52,20 @@ func FromContext(ctx context.Context) (txn *Transaction, ok bool) {
 // Transaction serves as a wrapper around `*gorm.DB` instance.
 // It works as a singleton to prevent an application of creating more than one
 // transaction instance per incoming request.
+// Besides the transaction on the primary DB, it manages a READ ONLY transaction
+// on the read replica, see BeginReadOnly.
+// Transactions created by interceptors with WithTenancy are scoped to the tenant of the request.
 type Transaction struct {
 	mu              sync.Mutex
 	parent          *gorm.DB
+	replica         *gorm.DB
+	readOnly        bool
 	current         *gorm.DB
+	currentReadOnly *gorm.DB
 	afterCommitHook []func(context.Context)
+	savepoints      int
+	tenant          *Tenant
+	tenantErr       error
 }
 
 func NewTransaction(db *gorm.DB) Transaction {
@@ -97,6 +115,24 @@ func BeginWithOptionsFromContext(ctx context.Context, opts *sql.TxOptions) (*gor
 	return db, nil
 }
 
+// BeginReadOnlyFromContext will extract transaction wrapper from context and start new READ ONLY
+// transaction on the read replica, see Transaction.BeginReadOnly.
+// Error will be returned in case either transaction or db connection info is missing in context.
+func BeginReadOnlyFromContext(ctx context.Context) (*gorm.DB, error) {
+	txn, ok := FromContext(ctx)
+	if !ok {
+		return nil, ErrCtxTxnMissing
+	}
+	if txn.parent == nil {
+		return nil, ErrCtxTxnNoDB
+	}
+	db := txn.beginReadOnly(ctx, nil)
+	if db.Error != nil {
+		return nil, db.Error
+	}
+	return db, nil
+}
+
 // Begin starts new transaction by calling `*gorm.DB.Begin()`
 // Returns new instance of `*gorm.DB` (error can be checked by `*gorm.DB.Error`)
 func (t *Transaction) Begin() *gorm.DB {
@@ -104,11 +140,18 @@ func (t *Transaction) Begin() *gorm.DB {
 }
 
 func (t *Transaction) beginWithContext(ctx context.Context) *gorm.DB {
+	if t.readOnly {
+		return t.beginReadOnly(ctx, nil)
+	}
+
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if t.tenantErr != nil {
+		return t.failed(t.tenantErr)
+	}
 	if t.current == nil {
-		t.current = t.parent.BeginTx(ctx, nil)
+		t.current = t.scoped(t.parent.BeginTx(ctx, nil))
 	}
 
 	return t.current
@@ -121,24 +164,94 @@ func (t *Transaction) BeginWithOptions(opts *sql.TxOptions) *gorm.DB {
 }
 
 func (t *Transaction) beginWithContextAndOptions(ctx context.Context, opts *sql.TxOptions) *gorm.DB {
+	if t.readOnly {
+		return t.beginReadOnly(ctx, opts)
+	}
+
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if t.tenantErr != nil {
+		return t.failed(t.tenantErr)
+	}
 	if t.current == nil {
-		t.current = t.parent.BeginTx(ctx, opts)
+		t.current = t.scoped(t.parent.BeginTx(ctx, opts))
 	}
 
 	return t.current
 }
 
+// BeginReadOnly starts new READ ONLY transaction on the read replica, or on the primary DB
+// if there is no replica, by calling `*gorm.DB.BeginTx()`.
+// The read-only transaction is independent of the one started by Begin, so writes stay on the primary DB.
+// Returns new instance of `*gorm.DB` (error can be checked by `*gorm.DB.Error`)
+func (t *Transaction) BeginReadOnly() *gorm.DB {
+	return t.beginReadOnly(context.Background(), nil)
+}
+
+func (t *Transaction) beginReadOnly(ctx context.Context, opts *sql.TxOptions) *gorm.DB {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.tenantErr != nil {
+		return t.failed(t.tenantErr)
+	}
+	if t.currentReadOnly == nil {
+		roOpts := &sql.TxOptions{ReadOnly: true}
+		if opts != nil {
+			roOpts.Isolation = opts.Isolation
+		}
+		db := t.replica
+		if db == nil {
+			db = t.parent
+		}
+		t.currentReadOnly = t.scoped(db.BeginTx(ctx, roOpts))
+	}
+
+	return t.currentReadOnly
+}
+
+// scoped scopes db to the tenant of the transaction, if any.
+func (t *Transaction) scoped(db *gorm.DB) *gorm.DB {
+	if t.tenant == nil {
+		return db
+	}
+	return db.Set(tenantSetting, t.tenant)
+}
+
+// failed returns a new instance of `*gorm.DB` with error err.
+func (t *Transaction) failed(err error) *gorm.DB {
+	db := t.parent.New()
+	db.AddError(err)
+	return db
+}
+
+// endReadOnly commits or rolls back the READ ONLY transaction, if any, and reports whether there was one.
+func (t *Transaction) endReadOnly(commit bool) (bool, error) {
+	db := t.currentReadOnly
+	if db == nil {
+		return false, nil
+	}
+	t.currentReadOnly = nil
+	if reflect.ValueOf(db.CommonDB()).IsNil() {
+		return false, nil
+	}
+	if commit {
+		return true, db.Commit().Error
+	}
+	return true, db.Rollback().Error
+}
+
 // Rollback terminates transaction by calling `*gorm.DB.Rollback()`
 // Reset current transaction and returns an error if any.
+// The READ ONLY transaction is rolled back as well.
 func (t *Transaction) Rollback() error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	_, roErr := t.endReadOnly(false)
 	if t.current == nil {
-		return nil
+		return roErr
 	}
 	if reflect.ValueOf(t.current.CommonDB()).IsNil() {
 		return status.Error(codes.Unavailable, "Database connection not available")
@@ -146,86 +259,268 @@ func (t *Transaction) Rollback() error {
 	t.current.Rollback()
 	err := t.current.Error
 	t.current = nil
+	if err == nil {
+		err = roErr
+	}
 	return err
 }
 
 // Commit finishes transaction by calling `*gorm.DB.Commit()`
 // Reset current transaction and returns an error if any.
+// The READ ONLY transaction is committed as well. After-commit hooks are run once the transaction
+// on the primary DB is committed or, if only the READ ONLY transaction is started, once it is committed.
 func (t *Transaction) Commit(ctx context.Context) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
-	if t.current == nil || reflect.ValueOf(t.current.CommonDB()).IsNil() {
-		return nil
+	readOnly, roErr := t.endReadOnly(true)
+	if t.current == nil {
+		if readOnly && roErr == nil {
+			t.runAfterCommitHooks(ctx)
+		}
+		return roErr
+	}
+	if reflect.ValueOf(t.current.CommonDB()).IsNil() {
+		return roErr
 	}
 	t.current.Commit()
 	err := t.current.Error
 	if err == nil {
-		for i := range t.afterCommitHook {
-			t.afterCommitHook[i](ctx)
-		}
+		t.runAfterCommitHooks(ctx)
+		err = roErr
 	}
 	t.current = nil
 	return err
 }
 
+func (t *Transaction) runAfterCommitHooks(ctx context.Context) {
+	for i := range t.afterCommitHook {
+		t.afterCommitHook[i](ctx)
+	}
+}
+
+// Savepoint establishes a savepoint with the given name within the current transaction,
+// so the work done afterwards can be undone by RollbackTo without aborting the transaction.
+// Returns ErrTxnNotStarted if the transaction is not started.
+func (t *Transaction) Savepoint(name string) error {
+	return t.execSavepoint("SAVEPOINT %s", name)
+}
+
+// RollbackTo rolls back the work done in the current transaction since the savepoint with the given name
+// was established. The savepoint remains valid and can be rolled back to again.
+func (t *Transaction) RollbackTo(name string) error {
+	return t.execSavepoint("ROLLBACK TO SAVEPOINT %s", name)
+}
+
+// Release destroys the savepoint with the given name, the work done since it was established
+// remains part of the current transaction.
+func (t *Transaction) Release(name string) error {
+	return t.execSavepoint("RELEASE SAVEPOINT %s", name)
+}
+
+func (t *Transaction) execSavepoint(stmt, name string) error {
+	if !savepointName.MatchString(name) {
+		return fmt.Errorf("Invalid savepoint name %q", name)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	db := t.current
+	if t.readOnly {
+		db = t.currentReadOnly
+	}
+	if db == nil {
+		return ErrTxnNotStarted
+	}
+	return db.Exec(fmt.Sprintf(stmt, name)).Error
+}
+
+func (t *Transaction) nextSavepoint() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.savepoints++
+	return fmt.Sprintf("atlas_sp_%d", t.savepoints)
+}
+
+// Nested runs fn within a savepoint of the transaction from ctx, starting the transaction if necessary.
+// If fn returns an error or panics, the work done by fn is rolled back to the savepoint and the rest of
+// the transaction is kept. The savepoint is released in either case.
+// The error returned by fn is returned as is, so it can be handled by the caller without aborting the request.
+// If rolling back to the savepoint fails, the transaction remains aborted and fails to commit.
+func Nested(ctx context.Context, fn func(tx *gorm.DB) error) (err error) {
+	db, err := BeginFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	txn, _ := FromContext(ctx)
+	name := txn.nextSavepoint()
+	if err := txn.Savepoint(name); err != nil {
+		return err
+	}
+	// the savepoint is released after rolling back to it, so failed calls leave no savepoints open
+	rollback := func() {
+		if txn.RollbackTo(name) == nil {
+			txn.Release(name)
+		}
+	}
+	defer func() {
+		if perr := recover(); perr != nil {
+			rollback()
+			panic(perr)
+		}
+		if err != nil {
+			rollback()
+			return
+		}
+		err = txn.Release(name)
+	}()
+	return fn(db)
+}
+
+type interceptorOptions struct {
+	maxRetries int
+	backoff    time.Duration
+	retryable  func(error) bool
+	replica    *gorm.DB
+	isReadOnly func(fullMethod string) bool
+	tenant     func(ctx context.Context) (*Tenant, error)
+}
+
+// InterceptorOption configures transaction interceptors.
+type InterceptorOption func(*interceptorOptions)
+
+// WithReadReplica makes transaction interceptors start transactions of gRPC methods matching
+// isReadOnly as READ ONLY transactions on replica, i.e. Begin of such requests behaves like BeginReadOnly.
+// IsReadOnlyMethod is used if isReadOnly is nil.
+// Transactions of other methods can use replica by means of BeginReadOnlyFromContext.
+func WithReadReplica(replica *gorm.DB, isReadOnly func(fullMethod string) bool) InterceptorOption {
+	return func(o *interceptorOptions) {
+		if isReadOnly == nil {
+			isReadOnly = IsReadOnlyMethod
+		}
+		o.replica = replica
+		o.isReadOnly = isReadOnly
+	}
+}
+
+// IsReadOnlyMethod reports whether gRPC method fullMethod (e.g. "/example.Contacts/List")
+// is a List or Read method.
+func IsReadOnlyMethod(fullMethod string) bool {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	return strings.HasPrefix(method, "List") || strings.HasPrefix(method, "Read")
+}
+
+// newTransaction returns a new transaction created after txn for a request to gRPC method fullMethod.
+func (o *interceptorOptions) newTransaction(ctx context.Context, txn *Transaction, fullMethod string) *Transaction {
+	// Deep copy is necessary as a tansaction should be created per request.
+	t := &Transaction{
+		parent:          txn.parent,
+		replica:         o.replica,
+		readOnly:        o.isReadOnly != nil && o.isReadOnly(fullMethod),
+		afterCommitHook: txn.afterCommitHook,
+	}
+	if o.tenant != nil {
+		t.tenant, t.tenantErr = o.tenant(ctx)
+		if t.tenantErr == nil && (t.tenant == nil || t.tenant.AccountID == "") {
+			t.tenantErr = ErrTenantMissing
+		}
+	}
+	return t
+}
+
+// register registers gorm callbacks the options depend on.
+func (o *interceptorOptions) register(txn *Transaction) {
+	if o.tenant != nil {
+		registerTenancyCallbacks(txn.parent)
+		registerTenancyCallbacks(o.replica)
+	}
+}
+
 // UnaryServerInterceptor returns grpc.UnaryServerInterceptor that manages
 // a `*Transaction` instance.
 // New *Transaction instance is created before grpc.UnaryHandler call.
 // Client is responsible to call `txn.Begin()` to open transaction.
 // If call of grpc.UnaryHandler returns with an error the transaction
 // is aborted, otherwise committed.
-func UnaryServerInterceptor(db *gorm.DB) grpc.UnaryServerInterceptor {
+func UnaryServerInterceptor(db *gorm.DB, options ...InterceptorOption) grpc.UnaryServerInterceptor {
 	txn := &Transaction{parent: db}
-	return UnaryServerInterceptorTxn(txn)
+	return UnaryServerInterceptorTxn(txn, options...)
 }
 
-func UnaryServerInterceptorTxn(txn *Transaction) grpc.UnaryServerInterceptor {
+// UnaryServerInterceptorTxn works like UnaryServerInterceptor, but new *Transaction instances
+// are created after txn, i.e. inherit its DB and after-commit hooks.
+// Options can enable retries of handlers that failed due to concurrent transactions, see WithRetry.
+func UnaryServerInterceptorTxn(txn *Transaction, options ...InterceptorOption) grpc.UnaryServerInterceptor {
+	opts := &interceptorOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	opts.register(txn)
+
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// Deep copy is necessary as a tansaction should be created per request.
-		txn := &Transaction{parent: txn.parent, afterCommitHook: txn.afterCommitHook}
-		defer func() {
-			// simple panic handler
-			if perr := recover(); perr != nil {
-				// we do not try to safe the world -
-				// just attempt to close our transaction
-				// re-raise panic and let someone to handle it
-				txn.Rollback()
-				panic(perr)
+		var fullMethod string
+		if info != nil {
+			fullMethod = info.FullMethod
+		}
+		for attempt := 0; ; attempt++ {
+			var retry bool
+			resp, retry, err = unaryServerTxn(ctx, opts.newTransaction(ctx, txn, fullMethod), req, handler, opts)
+			if !retry || attempt >= opts.maxRetries {
+				return resp, err
 			}
-
-			var terr error
-			if err != nil {
-				terr = txn.Rollback()
-			} else {
-				if terr = txn.Commit(ctx); terr != nil {
-					err = status.Error(codes.Internal, "failed to commit transaction")
-				}
+			if werr := opts.wait(ctx, attempt); werr != nil {
+				return resp, err
 			}
+		}
+	}
+}
 
-			if terr == nil {
-				return
-			}
-			// Catch the status: UNAVAILABLE error that Rollback might return
-			if _, ok := status.FromError(terr); ok {
-				err = terr
-				return
-			}
+// unaryServerTxn calls handler within transaction txn and reports whether the call may be retried.
+func unaryServerTxn(ctx context.Context, txn *Transaction, req interface{}, handler grpc.UnaryHandler, opts *interceptorOptions) (resp interface{}, retry bool, err error) {
+	defer func() {
+		// simple panic handler
+		if perr := recover(); perr != nil {
+			// we do not try to safe the world -
+			// just attempt to close our transaction
+			// re-raise panic and let someone to handle it
+			txn.Rollback()
+			panic(perr)
+		}
 
-			st := status.Convert(err)
-			st, serr := st.WithDetails(errdetails.New(codes.Internal, "gorm", terr.Error()))
-			// do not override error if failed to attach details
-			if serr == nil {
-				err = st.Err()
+		var terr error
+		if err != nil {
+			retry = opts.shouldRetry(err)
+			terr = txn.Rollback()
+		} else {
+			if terr = txn.Commit(ctx); terr != nil {
+				retry = opts.shouldRetry(terr)
+				err = status.Error(codes.Internal, "failed to commit transaction")
 			}
+		}
+
+		if terr == nil {
 			return
-		}()
+		}
+		// Catch the status: UNAVAILABLE error that Rollback might return
+		if _, ok := status.FromError(terr); ok {
+			err = terr
+			return
+		}
 
-		ctx = NewContext(ctx, txn)
-		resp, err = handler(ctx, req)
+		st := status.Convert(err)
+		st, serr := st.WithDetails(errdetails.New(codes.Internal, "gorm", terr.Error()))
+		// do not override error if failed to attach details
+		if serr == nil {
+			err = st.Err()
+		}
+		return
+	}()
 
-		return resp, err
-	}
+	ctx = NewContext(ctx, txn)
+	resp, err = handler(ctx, req)
+
+	return resp, false, err
 }
 
 // StreamServerInterceptor returns grpc.StreamServerInterceptor that manages
@@ -234,15 +529,27 @@ func UnaryServerInterceptorTxn(txn *Transaction) grpc.UnaryServerInterceptor {
 // Client is responsible to call `txn.Begin()` to open transaction.
 // If call of grpc.StreamHandler returns with an error the transaction
 // is aborted, otherwise committed.
-func StreamServerInterceptor(db *gorm.DB) grpc.StreamServerInterceptor {
+func StreamServerInterceptor(db *gorm.DB, options ...InterceptorOption) grpc.StreamServerInterceptor {
 	txn := &Transaction{parent: db}
-	return StreamServerInterceptorTxn(txn)
+	return StreamServerInterceptorTxn(txn, options...)
 }
 
-func StreamServerInterceptorTxn(txn *Transaction) grpc.StreamServerInterceptor {
+// StreamServerInterceptorTxn works like StreamServerInterceptor, but new *Transaction instances
+// are created after txn, i.e. inherit its DB and after-commit hooks.
+// Streams cannot be replayed, so WithRetry has no effect.
+func StreamServerInterceptorTxn(txn *Transaction, options ...InterceptorOption) grpc.StreamServerInterceptor {
+	opts := &interceptorOptions{}
+	for _, o := range options {
+		o(opts)
+	}
+	opts.register(txn)
+
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
-		// Deep copy is necessary as a tansaction should be created per request.
-		txn := &Transaction{parent: txn.parent, afterCommitHook: txn.afterCommitHook}
+		var fullMethod string
+		if info != nil {
+			fullMethod = info.FullMethod
+		}
+		txn := opts.newTransaction(stream.Context(), txn, fullMethod)
 		ctx := NewContext(stream.Context(), txn)
 
 		defer func() {
@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errdetails"
 	"github.com/jinzhu/gorm"
+	"github.com/lib/pq"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -76,6 +80,178 @@ func TestUnaryServerInterceptorTxn_success(t *testing.T) {
 	}
 }
 
+func TestUnaryServerInterceptorTxn_retry(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	// statement fails with a serialization failure
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE accounts`).WillReturnError(&pq.Error{Code: "40001"})
+	mock.ExpectRollback()
+	// commit fails with a deadlock
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE accounts`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit().WillReturnError(&pq.Error{Code: "40P01"})
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE accounts`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	txn := NewTransaction(gdb)
+	attempts, hooks := 0, 0
+	interceptor := UnaryServerInterceptorTxn(&txn, WithRetry(2, time.Millisecond))
+	_, err = interceptor(context.Background(), nil, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+		attempts++
+		txn, ok := FromContext(ctx)
+		if !ok {
+			t.Error("failed to extract transaction from context")
+		}
+		txn.AddAfterCommitHook(func(context.Context) { hooks++ })
+		return nil, txn.Begin().Exec("UPDATE accounts SET balance = 0").Error
+	})
+	if err != nil {
+		t.Errorf("unexpected error - %s", err)
+	}
+	if attempts != 3 || hooks != 1 {
+		t.Errorf("unexpected number of attempts %d and after commit hook calls %d", attempts, hooks)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to retry transaction - %s", err)
+	}
+
+	// retries are exhausted
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	attempts = 0
+	interceptor = UnaryServerInterceptorTxn(&txn, WithRetry(1, time.Millisecond))
+	_, err = interceptor(context.Background(), nil, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+		attempts++
+		txn, _ := FromContext(ctx)
+		txn.Begin()
+		return nil, fmt.Errorf("failed to update accounts: %w", &pq.Error{Code: "40001"})
+	})
+	if err == nil || attempts != 2 {
+		t.Errorf("unexpected error %v after %d attempts", err, attempts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to retry transaction - %s", err)
+	}
+
+	if !IsSerializationFailure(&pq.Error{Code: "40P01"}) || IsSerializationFailure(&pq.Error{Code: "23505"}) || IsSerializationFailure(errors.New("40001")) {
+		t.Error("failed to recognize serialization failures")
+	}
+
+	// the backoff grows exponentially up to maxRetryBackoff without overflows
+	o := &interceptorOptions{backoff: time.Millisecond}
+	for attempt, max := range map[int]time.Duration{0: time.Millisecond, 3: 8 * time.Millisecond, 20: maxRetryBackoff, 100: maxRetryBackoff} {
+		if d := o.delay(attempt); d < max/2 || d > max {
+			t.Errorf("unexpected backoff %s of attempt %d - expected: between %s and %s", d, attempt, max/2, max)
+		}
+	}
+	o.backoff = time.Minute
+	if d := o.delay(70); d < 30*time.Second || d > time.Minute {
+		t.Errorf("unexpected backoff %s - expected: between 30s and 1m", d)
+	}
+}
+
+func TestUnaryServerInterceptor_readReplica(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	rdb, rmock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	rgdb, err := gorm.Open("postgres", rdb)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	interceptor := UnaryServerInterceptor(gdb, WithReadReplica(rgdb, nil))
+
+	// List method runs on the replica
+	rmock.ExpectBegin()
+	rmock.ExpectExec(`^SELECT 1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	rmock.ExpectCommit()
+	info := &grpc.UnaryServerInfo{FullMethod: "/example.Contacts/ListContacts"}
+	hooks := 0
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		txn, _ := FromContext(ctx)
+		txn.AddAfterCommitHook(func(context.Context) { hooks++ })
+		if err := Enqueue(ctx, &OutboxEvent{Topic: "contacts"}); err != ErrTxnReadOnly {
+			t.Errorf("unexpected error %v - expected: %s", err, ErrTxnReadOnly)
+		}
+		tx, err := BeginFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, tx.Exec("SELECT 1").Error
+	})
+	if err != nil {
+		t.Errorf("unexpected error - %s", err)
+	}
+	if hooks != 1 {
+		t.Errorf("unexpected number of after commit hook calls %d - expected: 1", hooks)
+	}
+
+	// other methods write to the primary and read from the replica explicitly
+	rmock.ExpectBegin()
+	rmock.ExpectExec(`^SELECT 1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	rmock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO contacts`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	info = &grpc.UnaryServerInfo{FullMethod: "/example.Contacts/CreateContact"}
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		ro, err := BeginReadOnlyFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := ro.Exec("SELECT 1").Error; err != nil {
+			return nil, err
+		}
+		tx, err := BeginFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return nil, tx.Exec("INSERT INTO contacts (name) VALUES ('John')").Error
+	})
+	if err != nil {
+		t.Errorf("unexpected error - %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to manage transaction on the primary - %s", err)
+	}
+	if err := rmock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to manage transaction on the replica - %s", err)
+	}
+}
+
+func TestIsReadOnlyMethod(t *testing.T) {
+	for method, expected := range map[string]bool{
+		"/example.Contacts/ListContacts":  true,
+		"/example.Contacts/Read":          true,
+		"/example.Contacts/CreateContact": false,
+		"/example.Lister/Update":          false,
+		"List":                            true,
+	} {
+		if actual := IsReadOnlyMethod(method); actual != expected {
+			t.Errorf("IsReadOnlyMethod(%q) = %t, expected %t", method, actual, expected)
+		}
+	}
+}
+
 func TestUnaryServerInterceptor_error(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -470,3 +646,101 @@ func TestBeginFromContext_Bad(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction_Savepoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`^SAVEPOINT sp1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^ROLLBACK TO SAVEPOINT sp1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT sp1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	txn := &Transaction{parent: gdb}
+	if err := txn.Savepoint("sp1"); err != ErrTxnNotStarted {
+		t.Errorf("Did not receive proper error for not started transaction - %v", err)
+	}
+
+	txn.Begin()
+	if err := txn.Savepoint("sp1; DROP TABLE users"); err == nil {
+		t.Error("Did not receive an error for invalid savepoint name")
+	}
+	if err := txn.Savepoint("sp1"); err != nil {
+		t.Errorf("failed to create savepoint - %s", err)
+	}
+	if err := txn.RollbackTo("sp1"); err != nil {
+		t.Errorf("failed to rollback to savepoint - %s", err)
+	}
+	if err := txn.Release("sp1"); err != nil {
+		t.Errorf("failed to release savepoint - %s", err)
+	}
+	if err := txn.Commit(context.Background()); err != nil {
+		t.Errorf("failed to commit transaction - %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to use savepoints - %s", err)
+	}
+}
+
+func TestNested(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^DELETE FROM users$`).WillReturnError(errors.New("constraint violation"))
+	mock.ExpectExec(`^ROLLBACK TO SAVEPOINT atlas_sp_1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_1$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^DELETE FROM groups$`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_2$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^SAVEPOINT atlas_sp_3$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^ROLLBACK TO SAVEPOINT atlas_sp_3$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`^RELEASE SAVEPOINT atlas_sp_3$`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	gdb, err := gorm.Open("postgres", db)
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	txn := &Transaction{parent: gdb}
+	ctx := NewContext(context.Background(), txn)
+
+	err = Nested(ctx, func(tx *gorm.DB) error {
+		return tx.Exec("DELETE FROM users").Error
+	})
+	if err == nil || err.Error() != "constraint violation" {
+		t.Errorf("Did not receive an error of nested function - %v", err)
+	}
+	if err := Nested(ctx, func(tx *gorm.DB) error {
+		return tx.Exec("DELETE FROM groups").Error
+	}); err != nil {
+		t.Errorf("unexpected error %s", err)
+	}
+	// savepoints of failed calls are released as well
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Errorf("unexpected panic %v - expected: boom", r)
+			}
+		}()
+		Nested(ctx, func(*gorm.DB) error { panic("boom") })
+	}()
+	if err := txn.Commit(ctx); err != nil {
+		t.Errorf("failed to commit transaction - %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to run nested transactions - %s", err)
+	}
+
+	if err := Nested(context.Background(), func(*gorm.DB) error { return nil }); err != ErrCtxTxnMissing {
+		t.Errorf("Did not receive proper error for missing transaction - %v", err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	jgorm "github.com/jinzhu/gorm"
 	"github.com/jinzhu/gorm/dialects/postgres"
 	"github.com/jinzhu/inflection"
+	"github.com/lib/pq"
 
 	"time"
 
@@ -23,9 +24,6 @@ import (
 // to allow tables joined by a third party.
 // If association join is required to resolve the field path then it's name is returned as a second return value.
 func HandleFieldPath(ctx context.Context, fieldPath []string, obj interface{}) (string, string, error) {
-	if len(fieldPath) > 2 {
-		return "", "", fmt.Errorf("Field path longer than 2 is not supported")
-	}
 	dbPath, err := fieldPathToDBName(fieldPath, obj)
 	if err != nil {
 		switch err.(type) {
@@ -35,8 +33,8 @@ func HandleFieldPath(ctx context.Context, fieldPath []string, obj interface{}) (
 			return strings.Join(fieldPath, "."), "", nil
 		}
 	}
-	if len(fieldPath) == 2 {
-		return dbPath, util.Camel(fieldPath[0]), nil
+	if len(fieldPath) > 1 {
+		return dbPath, associationPath(fieldPath[:len(fieldPath)-1]), nil
 	}
 	return dbPath, "", nil
 }
@@ -47,7 +45,11 @@ func HandleJSONFieldPath(ctx context.Context, fieldPath []string, obj interface{
 	if isRawJSON(values...) {
 		operator = "#>"
 	}
+	return handleJSONFieldPath(fieldPath, obj, operator)
+}
 
+// handleJSONFieldPath translates field path to JSONB path that extracts nested values with operator.
+func handleJSONFieldPath(fieldPath []string, obj interface{}, operator string) (string, string, error) {
 	dbPath, err := fieldPathToDBName(fieldPath[:1], obj)
 	if err != nil {
 		switch err.(type) {
@@ -100,6 +102,45 @@ func IsJSONCondition(ctx context.Context, fieldPath []string, obj interface{}) b
 	return false
 }
 
+// IsArrayCondition reports whether fieldPath refers to a postgres array field of obj, e.g. pq.StringArray.
+func IsArrayCondition(ctx context.Context, fieldPath []string, obj interface{}) bool {
+	if len(fieldPath) != 1 {
+		return false
+	}
+	objType := indirectType(reflect.TypeOf(obj))
+	field, ok := objType.FieldByName(util.Camel(fieldPath[0]))
+	if !ok {
+		return false
+	}
+
+	fType := field.Type
+	for fType.Kind() == reflect.Ptr {
+		fType = fType.Elem()
+	}
+	switch reflect.Zero(fType).Interface().(type) {
+	case pq.StringArray:
+		return true
+	}
+
+	return false
+}
+
+// isArrayField reports whether fieldPath refers to a postgres array field of obj or of its association.
+func isArrayField(ctx context.Context, fieldPath []string, obj interface{}) bool {
+	objType := indirectType(reflect.TypeOf(obj))
+	for _, part := range fieldPath[:len(fieldPath)-1] {
+		sf, ok := objType.FieldByName(util.Camel(part))
+		if !ok {
+			return false
+		}
+		objType = indirectType(sf.Type)
+	}
+	if objType.Kind() != reflect.Struct {
+		return false
+	}
+	return IsArrayCondition(ctx, fieldPath[len(fieldPath)-1:], reflect.New(objType).Interface())
+}
+
 func fieldPathToDBName(fieldPath []string, obj interface{}) (string, error) {
 	objType := indirectType(reflect.TypeOf(obj))
 	pathLength := len(fieldPath)
@@ -114,7 +155,7 @@ func fieldPathToDBName(fieldPath []string, obj interface{}) (string, error) {
 		}
 		if i < pathLength-1 {
 			objType = indirectType(sf.Type)
-			assocAlias = part
+			assocAlias = associationAlias(associationPath(fieldPath[:i+1]))
 		} else {
 			if isModel(indirectType(sf.Type)) {
 				return "", fmt.Errorf("%s: last field of %s field path should be a model", objType, fieldPath)
@@ -131,6 +172,25 @@ func fieldPathToDBName(fieldPath []string, obj interface{}) (string, error) {
 	return "", &EmptyFieldPathError{}
 }
 
+// associationPath returns the path of associations referred to by fieldPath, e.g. "Owner.Org".
+func associationPath(fieldPath []string) string {
+	path := make([]string, len(fieldPath))
+	for i, part := range fieldPath {
+		path[i] = util.Camel(part)
+	}
+	return strings.Join(path, ".")
+}
+
+// associationAlias returns the alias of the table of assoc association path in joins,
+// e.g. owner__org for "Owner.Org".
+func associationAlias(assoc string) string {
+	path := strings.Split(assoc, ".")
+	for i, name := range path {
+		path[i] = jgorm.ToDBName(name)
+	}
+	return strings.Join(path, "__")
+}
+
 func tableName(t reflect.Type) string {
 	table := reflect.Zero(t).Interface()
 	if tn, ok := table.(tableNamer); ok {
@@ -0,0 +1,250 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	atlaserrors "github.com/infobloxopen/atlas-app-toolkit/v2/errors"
+)
+
+// DefaultBatchSize is the number of rows BatchCreate inserts by a single statement by default.
+const DefaultBatchSize = 500
+
+// maxBindParams is the maximum number of arguments of a statement supported by Postgres.
+const maxBindParams = 65535
+
+type conflictAction int
+
+const (
+	conflictError conflictAction = iota
+	conflictDoNothing
+	conflictUpdate
+)
+
+type batchOptions struct {
+	size            int
+	action          conflictAction
+	conflictColumns []string
+	updateColumns   []string
+}
+
+// BatchOption configures BatchCreate.
+type BatchOption func(*batchOptions)
+
+// WithBatchSize sets the number of rows BatchCreate inserts by a single statement, see DefaultBatchSize.
+func WithBatchSize(size int) BatchOption {
+	return func(o *batchOptions) {
+		if size > 0 {
+			o.size = size
+		}
+	}
+}
+
+// OnConflictDoNothing makes BatchCreate skip rows that conflict with existing ones on the unique constraint
+// of columns, or on any unique constraint if columns are not given.
+func OnConflictDoNothing(columns ...string) BatchOption {
+	return func(o *batchOptions) {
+		o.action = conflictDoNothing
+		o.conflictColumns = columns
+	}
+}
+
+// OnConflictUpdate makes BatchCreate upsert rows, i.e. update updateColumns of existing rows that conflict
+// with inserted ones on the unique constraint of columns. All inserted columns except primary keys
+// and the creation timestamp are updated if updateColumns are not given, conflicting rows are skipped
+// if there are no such columns.
+func OnConflictUpdate(columns []string, updateColumns ...string) BatchOption {
+	return func(o *batchOptions) {
+		o.action = conflictUpdate
+		o.conflictColumns = columns
+		o.updateColumns = updateColumns
+	}
+}
+
+// batchRowError is an error of the row of BatchCreate at index.
+type batchRowError struct {
+	index int
+	err   error
+}
+
+// BatchCreate inserts rows, a slice of models or pointers to models, within the transaction from ctx
+// and returns the number of inserted or updated rows. Rows are created by GORM in batches by a single statement
+// per batch, see WithBatchSize, so hooks, timestamps and tenant assignment apply as usual, see WithTenancy.
+// Conflicts with existing rows are handled according to OnConflictDoNothing and OnConflictUpdate.
+//
+// If a batch fails due to invalid data, a constraint violation or rows upserting the same row,
+// its rows are inserted one by one, rows that fail are skipped and the others are inserted.
+// Failed rows are reported by *errors.Container with a detail per row targeted at the index of the row in rows.
+// Other errors abort BatchCreate.
+func BatchCreate(ctx context.Context, rows interface{}, opts ...BatchOption) (int64, error) {
+	o := &batchOptions{size: DefaultBatchSize}
+	for _, opt := range opts {
+		opt(o)
+	}
+	v := reflect.Indirect(reflect.ValueOf(rows))
+	if v.Kind() != reflect.Slice {
+		return 0, fmt.Errorf("BatchCreate expects a slice of models, got %T", rows)
+	}
+
+	var total int64
+	var failed []batchRowError
+	for start := 0; start < v.Len(); start += o.size {
+		end := start + o.size
+		if end > v.Len() {
+			end = v.Len()
+		}
+		n, err := o.insert(ctx, v.Slice(start, end))
+		if err == nil {
+			total += n
+			continue
+		}
+		if !isRowError(err) {
+			return total, err
+		}
+		// the batch is rolled back, so its rows are inserted one by one to find the failing ones
+		for i := start; i < end; i++ {
+			n, err := o.insert(ctx, v.Slice(i, i+1))
+			if err != nil {
+				if !isRowError(err) {
+					return total, err
+				}
+				failed = append(failed, batchRowError{index: i, err: err})
+				continue
+			}
+			total += n
+		}
+	}
+	if len(failed) > 0 {
+		return total, batchError(failed, v.Len())
+	}
+	return total, nil
+}
+
+// isRowError reports whether err is caused by the data of inserted rows, i.e. a data exception (class 22),
+// an integrity constraint violation (class 23) or a cardinality violation (21000) of a batch
+// that upserts the same row more than once.
+func isRowError(err error) bool {
+	state := sqlState(err)
+	return state == "21000" || strings.HasPrefix(state, "22") || strings.HasPrefix(state, "23")
+}
+
+// batchError returns the error container of failed rows out of total rows.
+func batchError(failed []batchRowError, total int) error {
+	code := codes.AlreadyExists
+	for _, f := range failed {
+		if sqlState(f.err) != "23505" {
+			code = codes.InvalidArgument
+		}
+	}
+	c := atlaserrors.NewContainer(code, "%d of %d rows failed", len(failed), total)
+	for _, f := range failed {
+		rowCode := codes.InvalidArgument
+		if sqlState(f.err) == "23505" {
+			rowCode = codes.AlreadyExists
+		}
+		msg := f.err.Error()
+		var pqErr *pq.Error
+		if errors.As(f.err, &pqErr) {
+			msg = pqErr.Message
+		}
+		c.WithDetail(rowCode, strconv.Itoa(f.index), "%s", msg)
+	}
+	return c
+}
+
+// insert inserts models within a savepoint of the transaction from ctx,
+// so the transaction is intact if the statement fails.
+func (o *batchOptions) insert(ctx context.Context, models reflect.Value) (n int64, err error) {
+	err = Nested(ctx, func(tx *gorm.DB) error {
+		n, err = o.insertRows(tx, models)
+		return err
+	})
+	return n, err
+}
+
+// insertRows inserts models by a single statement, or by several ones if the number of arguments may exceed
+// maxBindParams, and returns the number of inserted or updated rows.
+func (o *batchOptions) insertRows(db *gorm.DB, models reflect.Value) (int64, error) {
+	// the slice shares elements with rows, so primary keys are set to rows
+	dest := reflect.New(models.Type())
+	dest.Elem().Set(models)
+	sch, err := parseSchema(dest.Interface())
+	if err != nil {
+		return 0, err
+	}
+	// Postgres limits the number of arguments of a statement, so batches of wide rows are split.
+	// The conflict clause takes at most an argument per column for updates and for the tenant condition.
+	reserved := 0
+	if o.action != conflictError {
+		onConflict, err := o.onConflict(db, sch)
+		if err != nil {
+			return 0, err
+		}
+		db = db.Clauses(onConflict)
+		reserved = 2 * len(sch.DBNames)
+	}
+	size := models.Len()
+	if n := len(sch.DBNames); n > 0 && (maxBindParams-reserved)/n < size {
+		size = (maxBindParams - reserved) / n
+	}
+	// the batch is already inserted within a savepoint
+	res := db.Session(&gorm.Session{SkipDefaultTransaction: true}).CreateInBatches(dest.Interface(), size)
+	return res.RowsAffected, res.Error
+}
+
+// onConflict returns the ON CONFLICT clause of the statement inserting models of sch.
+func (o *batchOptions) onConflict(db *gorm.DB, sch *schema.Schema) (clause.OnConflict, error) {
+	target, err := dbColumns(sch, o.conflictColumns)
+	if err != nil {
+		return clause.OnConflict{}, err
+	}
+	onConflict := clause.OnConflict{DoNothing: o.action == conflictDoNothing}
+	for _, col := range target {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: col})
+	}
+	if onConflict.DoNothing {
+		return onConflict, nil
+	}
+	if len(target) == 0 {
+		return clause.OnConflict{}, errors.New("OnConflictUpdate requires conflict columns")
+	}
+
+	updates, err := dbColumns(sch, o.updateColumns)
+	if err != nil {
+		return clause.OnConflict{}, err
+	}
+	if len(updates) == 0 {
+		onConflict.UpdateAll = true
+	} else {
+		onConflict.DoUpdates = clause.AssignmentColumns(updates)
+	}
+
+	// rows of other tenants are not updated
+	if cond, args := tenantCondition(db, sch, sch.Table); cond != "" {
+		onConflict.Where = clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: cond, Vars: args}}}
+	}
+	return onConflict, nil
+}
+
+// dbColumns returns column names of fields of sch with the given names or column names.
+func dbColumns(sch *schema.Schema, names []string) ([]string, error) {
+	columns := make([]string, len(names))
+	for i, name := range names {
+		field := sch.LookUpField(name)
+		if field == nil {
+			return nil, fmt.Errorf("Cannot find column %s in %s", name, sch.Table)
+		}
+		columns[i] = field.DBName
+	}
+	return columns, nil
+}
@@ -0,0 +1,415 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+type SortingCriteriaConverter interface {
+	SortingCriteriaToGorm(ctx context.Context, cr *query.SortCriteria, obj interface{}) (string, string, error)
+}
+
+type FieldSelectionConverter interface {
+	FieldSelectionToGorm(ctx context.Context, fs *query.FieldSelection, obj interface{}) ([]string, error)
+}
+
+type PaginationConverter interface {
+	PaginationToGorm(ctx context.Context, p *query.Pagination) (offset, limit int32)
+}
+
+// CursorConverter is implemented by pagination converters that support
+// keyset (cursor) pagination.
+type CursorConverter interface {
+	CursorToGorm(ctx context.Context, c *query.PageCursor, s *query.Sorting, obj interface{}) (string, []interface{}, error)
+}
+
+// KeysetConverter is implemented by cursor converters that apply keyset pagination
+// only if it is enabled explicitly, see DefaultPaginationConverter.Keyset.
+type KeysetConverter interface {
+	KeysetPagination() bool
+}
+
+// PageTokenConverter is implemented by pagination converters that encode and
+// decode page tokens. Decoded tokens are expected to be verified against scope,
+// the hash of collection operators of the current request (see query.PageTokenScope).
+type PageTokenConverter interface {
+	PageTokenToGorm(ctx context.Context, p *query.Pagination, scope string) (*query.PageToken, error)
+	PageTokenFromGorm(ctx context.Context, t *query.PageToken, scope string) (string, error)
+}
+
+// CollectionPolicyConverter is implemented by converters that restrict filtering
+// and sorting collection operators, see query.CollectionPolicy.
+type CollectionPolicyConverter interface {
+	ValidateCollectionOperators(ctx context.Context, f *query.Filtering, s *query.Sorting) error
+}
+
+type SearchingConverter interface {
+	SearchingToGorm(ctx context.Context, s *query.Searching, fieldsForFTS []string, obj interface{}) (string, error)
+}
+
+// SearchingQueryConverter is implemented by searching converters that convert the search query
+// to the argument of the condition returned by SearchingToGorm, otherwise SearchQuery is used.
+type SearchingQueryConverter interface {
+	SearchingQueryToGorm(ctx context.Context, s *query.Searching) string
+}
+
+// SearchingRankConverter is implemented by searching converters that rank records matching the search query.
+// The returned expression has the same placeholder for the search query as the condition returned by
+// SearchingToGorm, an empty expression means records are not ranked.
+type SearchingRankConverter interface {
+	SearchingRankToGorm(ctx context.Context, s *query.Searching, fieldsForFTS []string, obj interface{}) (string, error)
+}
+
+type CollectionOperatorsConverter interface {
+	FilteringConditionConverter
+	SortingCriteriaConverter
+	FieldSelectionConverter
+	PaginationConverter
+	SearchingConverter
+}
+
+// ApplyCollectionOperatorsEx applies collection operators to gorm instance db.
+// If p requests server-driven pagination (page token is set), the page token is
+// decoded and verified if c implements PageTokenConverter. If c implements
+// CursorConverter and keyset pagination is not disabled (see KeysetConverter),
+// keyset pagination is applied: sorting is extended with primary key columns
+// of obj and the cursor is turned into a seek predicate.
+// If c implements CollectionPolicyConverter, f and s are validated by c first.
+func ApplyCollectionOperatorsEx(ctx context.Context, db *gorm.DB, obj interface{}, c CollectionOperatorsConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*gorm.DB, error) {
+	if pc, ok := c.(CollectionPolicyConverter); ok {
+		if err := pc.ValidateCollectionOperators(ctx, f, s); err != nil {
+			return nil, err
+		}
+	}
+
+	db, fAssocToJoin, err := ApplyFilteringEx(ctx, db, f, obj, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.GetPageToken() != "" {
+		db, s, p, err = applyPageToken(ctx, db, obj, c, f, s, p, fs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	db, sAssocToJoin, err := ApplySortingEx(ctx, db, s, obj, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if fAssocToJoin == nil && sAssocToJoin != nil {
+		fAssocToJoin = make(map[string]struct{})
+	}
+	for k := range sAssocToJoin {
+		fAssocToJoin[k] = struct{}{}
+	}
+	db, err = JoinAssociations(ctx, db, fAssocToJoin, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	db = ApplyPaginationEx(ctx, db, p, c)
+
+	db, err = ApplyFieldSelectionEx(ctx, db, fs, obj, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// ApplyCollectionOperatorsWithSearchingEx applies collection operators and searching operator sc to gorm instance db.
+// If c implements SearchingRankConverter and neither sorting nor page token is requested, records are ordered by rank.
+func ApplyCollectionOperatorsWithSearchingEx(ctx context.Context, db *gorm.DB, obj interface{}, c CollectionOperatorsConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection, sc *query.Searching, fieldsForFTS []string) (*gorm.DB, error) {
+	db, err := ApplyCollectionOperatorsEx(ctx, db, obj, c, f, s, p, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err = ApplySearchingEx(ctx, db, sc, obj, fieldsForFTS, c)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.GetCriterias()) == 0 && p.GetPageToken() == "" {
+		if rc, ok := c.(SearchingRankConverter); ok {
+			return ApplySearchRankingEx(ctx, db, sc, obj, fieldsForFTS, rc)
+		}
+	}
+
+	return db, nil
+}
+
+// ApplySearchingEx applies searching operator s to gorm instance db.
+func ApplySearchingEx(ctx context.Context, db *gorm.DB, s *query.Searching, obj interface{}, fieldsForFTS []string, c SearchingConverter) (*gorm.DB, error) {
+	str, err := c.SearchingToGorm(ctx, s, fieldsForFTS, obj)
+	if err != nil {
+		return nil, err
+	}
+	if s.GetQuery() == "" {
+		return db, nil
+	}
+	return db.Clauses(where(str, searchingQuery(ctx, s, c))), nil
+}
+
+// ApplySearchRankingEx orders records of gorm instance db by their rank of searching operator s, most relevant first.
+func ApplySearchRankingEx(ctx context.Context, db *gorm.DB, s *query.Searching, obj interface{}, fieldsForFTS []string, c SearchingRankConverter) (*gorm.DB, error) {
+	if s.GetQuery() == "" {
+		return db, nil
+	}
+	rank, err := c.SearchingRankToGorm(ctx, s, fieldsForFTS, obj)
+	if err != nil || rank == "" {
+		return db, err
+	}
+	return db.Clauses(clause.OrderBy{Expression: clause.Expr{
+		SQL:                rank + " DESC",
+		Vars:               []interface{}{searchingQuery(ctx, s, c)},
+		WithoutParentheses: true,
+	}}), nil
+}
+
+// searchingQuery returns the argument of the searching condition of c for search query s.
+func searchingQuery(ctx context.Context, s *query.Searching, c interface{}) string {
+	if qc, ok := c.(SearchingQueryConverter); ok {
+		return qc.SearchingQueryToGorm(ctx, s)
+	}
+	return SearchQuery(s.GetQuery())
+}
+
+// ApplyFilteringEx applies filtering operator f to gorm instance db as a WHERE clause.
+// Soft deleted records are excluded unless f requests to include them.
+// Associations to be joined are returned, unless f refers to has many or many to many associations,
+// then all associations are joined within a subquery that records are matched against by primary key.
+func ApplyFilteringEx(ctx context.Context, db *gorm.DB, f *query.Filtering, obj interface{}, c FilteringConditionConverter) (*gorm.DB, map[string]struct{}, error) {
+	str, args, assocToJoin, err := FilteringToGormEx(ctx, f, obj, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.GetIncludeDeleted() {
+		db = db.Unscoped()
+	}
+	if str == "" {
+		return db, nil, nil
+	}
+	for assoc := range assocToJoin {
+		if !isToManyAssociation(obj, assoc) {
+			continue
+		}
+		str, args, err = associationSubquery(db, assocToJoin, obj, str, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		return db.Clauses(where(str, args...)), nil, nil
+	}
+	return db.Clauses(where(str, args...)), assocToJoin, nil
+}
+
+// ApplySortingEx applies sorting operator s to gorm instance db as an ORDER BY clause.
+// Sorting by has many and many to many associations is not supported.
+func ApplySortingEx(ctx context.Context, db *gorm.DB, s *query.Sorting, obj interface{}, c SortingCriteriaConverter) (*gorm.DB, map[string]struct{}, error) {
+	var crs []string
+	var assocToJoin map[string]struct{}
+	for _, cr := range s.GetCriterias() {
+		dbCr, assoc, err := c.SortingCriteriaToGorm(ctx, cr, obj)
+		if err != nil {
+			return nil, nil, err
+		}
+		if assoc != "" {
+			if isToManyAssociation(obj, assoc) {
+				return nil, nil, fmt.Errorf("Cannot sort by to many association %s", assoc)
+			}
+			if assocToJoin == nil {
+				assocToJoin = make(map[string]struct{})
+			}
+			assocToJoin[assoc] = struct{}{}
+		}
+		crs = append(crs, dbCr)
+	}
+	if len(crs) == 0 {
+		return db, nil, nil
+	}
+	return db.Clauses(clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(crs, ",")}}), assocToJoin, nil
+}
+
+// ApplyCollectionOperators applies collection operators to gorm instance db
+// using the default converter, see NewDefaultPbToOrmConverter.
+func ApplyCollectionOperators(ctx context.Context, db *gorm.DB, obj interface{}, pb proto.Message, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*gorm.DB, error) {
+	return ApplyCollectionOperatorsEx(ctx, db, obj, NewDefaultPbToOrmConverter(pb), f, s, p, fs)
+}
+
+// ApplyFiltering applies filtering operator f to gorm instance db using the default converter.
+func ApplyFiltering(ctx context.Context, db *gorm.DB, f *query.Filtering, obj interface{}, pb proto.Message) (*gorm.DB, map[string]struct{}, error) {
+	c := &DefaultFilteringConditionConverter{&DefaultFilteringConditionProcessor{pb}}
+	return ApplyFilteringEx(ctx, db, f, obj, c)
+}
+
+// ApplySorting applies sorting operator s to gorm instance db using the default converter.
+func ApplySorting(ctx context.Context, db *gorm.DB, s *query.Sorting, obj interface{}) (*gorm.DB, map[string]struct{}, error) {
+	return ApplySortingEx(ctx, db, s, obj, &DefaultSortingCriteriaConverter{})
+}
+
+// applyPageToken applies the page token of p to gorm instance db and returns
+// sorting and pagination that are to be applied afterwards.
+func applyPageToken(ctx context.Context, db *gorm.DB, obj interface{}, c CollectionOperatorsConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) (*gorm.DB, *query.Sorting, *query.Pagination, error) {
+	cc, isCursor := cursorConverter(c)
+	tc, ok := c.(PageTokenConverter)
+	if !ok {
+		if !isCursor {
+			return db, s, p, nil
+		}
+		s = CursorSorting(s, obj)
+		db, err := ApplyCursorEx(ctx, db, p, s, obj, cc)
+		return db, s, p, err
+	}
+
+	token, err := tc.PageTokenToGorm(ctx, p, query.PageTokenScope(f, s, fs))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if isCursor {
+		s = CursorSorting(s, obj)
+	}
+	if token == nil {
+		return db, s, p, nil
+	}
+	if token.Cursor == nil {
+		return db, s, tokenPagination(token, p), nil
+	}
+	if !isCursor {
+		return nil, nil, nil, fmt.Errorf("%T does not support cursor page tokens", c)
+	}
+	str, args, err := cc.CursorToGorm(ctx, token.Cursor, s, obj)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if str != "" {
+		db = db.Clauses(where(str, args...))
+	}
+	return db, s, tokenPagination(token, p), nil
+}
+
+// tokenPagination returns the pagination the page of page token t decoded from p is fetched with.
+// The page size is bound to the token, so it cannot be changed while paging.
+func tokenPagination(t *query.PageToken, p *query.Pagination) *query.Pagination {
+	if t == nil || (t.Cursor != nil && t.Limit <= 0) {
+		return p
+	}
+	return &query.Pagination{
+		PageToken:         p.GetPageToken(),
+		Offset:            t.Offset,
+		Limit:             t.Limit,
+		IsTotalSizeNeeded: p.GetIsTotalSizeNeeded(),
+	}
+}
+
+// cursorConverter returns c as CursorConverter if c supports keyset pagination and it is enabled.
+func cursorConverter(c interface{}) (CursorConverter, bool) {
+	cc, ok := c.(CursorConverter)
+	if kc, isKeyset := c.(KeysetConverter); ok && isKeyset {
+		return cc, kc.KeysetPagination()
+	}
+	return cc, ok
+}
+
+// ApplyCursorEx applies the seek predicate of the cursor page token of p to gorm
+// instance db. s is expected to be the sorting extended by CursorSorting.
+// Page tokens that are not cursor tokens, e.g. "null" for the first page, are ignored.
+func ApplyCursorEx(ctx context.Context, db *gorm.DB, p *query.Pagination, s *query.Sorting, obj interface{}, c CursorConverter) (*gorm.DB, error) {
+	if !query.IsCursorToken(p.GetPageToken()) {
+		return db, nil
+	}
+	cursor, err := query.DecodeCursorToken(p.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	str, args, err := c.CursorToGorm(ctx, cursor, s, obj)
+	if err != nil {
+		return nil, err
+	}
+	if str != "" {
+		return db.Clauses(where(str, args...)), nil
+	}
+	return db, nil
+}
+
+// ApplyPaginationEx applies pagination operator p to gorm instance db.
+func ApplyPaginationEx(ctx context.Context, db *gorm.DB, p *query.Pagination, c PaginationConverter) *gorm.DB {
+	offset, limit := c.PaginationToGorm(ctx, p)
+
+	if offset > 0 {
+		db = db.Offset(int(offset))
+	}
+
+	if limit > 0 {
+		db = db.Limit(int(limit))
+	}
+
+	return db
+}
+
+// ApplyPagination applies pagination operator p to gorm instance db.
+func ApplyPagination(ctx context.Context, db *gorm.DB, p *query.Pagination) *gorm.DB {
+	return ApplyPaginationEx(ctx, db, p, &DefaultPaginationConverter{})
+}
+
+// ApplyFieldSelectionEx applies field selection operator fs to gorm instance db.
+func ApplyFieldSelectionEx(ctx context.Context, db *gorm.DB, fs *query.FieldSelection, obj interface{}, c FieldSelectionConverter) (*gorm.DB, error) {
+	toPreload, err := c.FieldSelectionToGorm(ctx, fs, obj)
+	if err != nil {
+		return nil, err
+	}
+	for _, assoc := range toPreload {
+		db, err = preload(db, obj, assoc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// ApplyFieldSelection applies field selection operator fs to gorm instance db using the default converter.
+func ApplyFieldSelection(ctx context.Context, db *gorm.DB, fs *query.FieldSelection, obj interface{}) (*gorm.DB, error) {
+	return ApplyFieldSelectionEx(ctx, db, fs, obj, &DefaultFieldSelectionConverter{})
+}
+
+// CollectionOperators returns a scope that applies collection operators to a query, e.g.
+//
+//	db.Scopes(gormv2.CollectionOperators(ctx, &PersonORM{}, c, f, s, p, fs)).Find(&people)
+//
+// An error of applying collection operators is added to the query, see gorm.DB.AddError.
+func CollectionOperators(ctx context.Context, obj interface{}, c CollectionOperatorsConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		res, err := ApplyCollectionOperatorsEx(ctx, db, obj, c, f, s, p, fs)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		return res
+	}
+}
+
+// CollectionOperatorsWithSearching works like CollectionOperators, but applies searching operator sc as well.
+func CollectionOperatorsWithSearching(ctx context.Context, obj interface{}, c CollectionOperatorsConverter, f *query.Filtering, s *query.Sorting, p *query.Pagination, fs *query.FieldSelection, sc *query.Searching, fieldsForFTS []string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		res, err := ApplyCollectionOperatorsWithSearchingEx(ctx, db, obj, c, f, s, p, fs, sc, fieldsForFTS)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		return res
+	}
+}
+
+// where returns a WHERE clause of GORM Plain SQL condition str with arguments args.
+func where(str string, args ...interface{}) clause.Where {
+	return clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: str, Vars: args}}}
+}
@@ -0,0 +1,188 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+type Person struct {
+	ID        int64
+	Name      string
+	Age       int
+	ParentID  int64
+	Parent    Parent
+	SubPerson SubPerson
+	Items     []OrderedItem   `atlas:"position:Position"`
+	Tags      pq.StringArray  `gorm:"type:text[]"`
+	Info      json.RawMessage `gorm:"type:jsonb"`
+}
+
+type Parent struct {
+	ID   int64
+	Name string
+}
+
+type SubPerson struct {
+	ID       int64
+	Name     string
+	PersonID int64
+}
+
+type OrderedItem struct {
+	ID       int64
+	Position int
+	PersonID int64
+}
+
+type PersonProto struct {
+}
+
+func (*PersonProto) Reset() {
+}
+
+func (*PersonProto) ProtoMessage() {
+}
+
+func (*PersonProto) String() string {
+	return "Person"
+}
+
+func fixedFullRe(s string) string {
+	return fmt.Sprintf("^%s$", regexp.QuoteMeta(s))
+}
+
+func setUp(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gormDB, mock
+}
+
+func TestApplyCollectionOperators(t *testing.T) {
+	ctx := context.Background()
+	f, err := query.ParseFiltering("age <= 25 and sub_person.name == 'Mike'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := query.ParseSorting("age, sub_person.name, parent.name desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := query.ParsePagination("2", "1", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := query.ParseFieldSelection("id,name,sub_person,items")
+
+	gormDB, mock := setUp(t)
+	gormDB, err = ApplyCollectionOperators(ctx, gormDB, &Person{}, &PersonProto{}, f, s, p, fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT "people"."id","people"."name","people"."age","people"."parent_id","people"."tags","people"."info" FROM "people" `+
+		`LEFT JOIN parents parent ON people.parent_id = parent.id LEFT JOIN sub_people sub_person ON people.id = sub_person.person_id `+
+		`WHERE ((people.age <= $1) AND (sub_person.name = $2)) ORDER BY people.age,sub_person.name,parent.name desc LIMIT $3 OFFSET $4`)).
+		WithArgs(25.0, "Mike", 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(111, "Mike"))
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "ordered_items" WHERE "ordered_items"."person_id" = $1 ORDER BY position`)).
+		WithArgs(111).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "position", "person_id"}))
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "sub_people" WHERE "sub_people"."person_id" = $1`)).
+		WithArgs(111).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "person_id"}))
+
+	var actual []Person
+	assert.NoError(t, gormDB.Find(&actual).Error)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestApplyCollectionOperatorsPolicy(t *testing.T) {
+	ctx := context.Background()
+	c := NewDefaultPbToOrmConverter(&PersonProto{}).(*DefaultPbToOrmConverter)
+	c.Policy = query.NewCollectionPolicy().Filterable("age", "<=", ">").Sortable("age")
+
+	f, err := query.ParseFiltering("age <= 25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := query.ParseSorting("age desc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, mock := setUp(t)
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "people" WHERE (people.age <= $1) ORDER BY people.age desc`)).
+		WithArgs(25.0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	var actual []Person
+	assert.NoError(t, gormDB.Scopes(CollectionOperators(ctx, &Person{}, c, f, s, nil, &query.FieldSelection{Fields: map[string]*query.Field{"id": {Name: "id"}}})).Find(&actual).Error)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	s, err = query.ParseSorting("name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, _ = setUp(t)
+	err = gormDB.Scopes(CollectionOperators(ctx, &Person{}, c, f, s, nil, nil)).Find(&actual).Error
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestApplyFilteringCollectionOperators(t *testing.T) {
+	f, err := query.ParseFiltering("tags has 'a' and info contains ['b', 'c'] and tags in ['d'] and info has 'e'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, mock := setUp(t)
+	gormDB, _, err = ApplyFiltering(context.Background(), gormDB, f, &Person{}, &PersonProto{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "people" WHERE ((((people.tags @> $1) AND (people.info @> $2)) AND (people.tags && $3)) AND (people.info ? $4))`)).
+		WithArgs(`{"a"}`, `["b","c"]`, `{"d"}`, "e").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	var actual []Person
+	assert.NoError(t, gormDB.Find(&actual).Error)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestApplySortingNulls(t *testing.T) {
+	s, err := query.ParseSorting("parent.name desc nulls last, info.address.city")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, mock := setUp(t)
+	gormDB, _, err = ApplySorting(context.Background(), gormDB, s, &Person{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "people" ORDER BY parent.name desc nulls last,people.info #>> '{address,city}'`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}))
+	var actual []Person
+	assert.NoError(t, gormDB.Find(&actual).Error)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,668 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"gorm.io/gorm/clause"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/errors"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/resource"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/util"
+)
+
+// DefaultFilteringConditionProcessor processes filter operator conversion
+type DefaultFilteringConditionProcessor struct {
+	pb proto.Message
+}
+
+// DefaultFilteringConditionConverter performs default convertion for Filter collection operator
+type DefaultFilteringConditionConverter struct {
+	Processor FilteringConditionProcessor
+}
+
+// DefaultSortingCriteriaConverter performs default convertion for Sorting collection operator
+type DefaultSortingCriteriaConverter struct{}
+
+// DefaultPaginationConverter performs default convertion for Paging collection operator.
+// If Codec is set, page tokens are encoded and decoded with it, otherwise only
+// unsigned cursor page tokens are supported.
+// Keyset enables keyset (cursor) pagination, otherwise page tokens are offset ones
+// and sorting is not extended with primary key columns.
+type DefaultPaginationConverter struct {
+	Codec  query.PageTokenCodec
+	Keyset bool
+}
+
+// KeysetPagination reports whether keyset pagination is enabled.
+func (converter *DefaultPaginationConverter) KeysetPagination() bool {
+	return converter.Keyset
+}
+
+// DefaultSearchingConverter performs default convertion for Searching operator.
+// Records are matched against the document built from fieldsForFTS by GetFullTextSearchDBMask or, if Column is set,
+// against the tsvector column, e.g. a generated column covered by a GIN index. Dictionary sets the text search
+// configuration, "simple" by default. The search query matches all its words, the last one as a prefix, see SearchQuery,
+// or, if WebSearch is set, parsed by websearch_to_tsquery, e.g. `"exact phrase" -excluded or alternative`.
+// If Rank is set, records are ordered by ts_rank unless sorting is requested.
+type DefaultSearchingConverter struct {
+	Dictionary string
+	Column     string
+	WebSearch  bool
+	Rank       bool
+}
+
+// DefaultPbToOrmConverter performs default convertion for all collection operators.
+// If Policy is set, filtering and sorting collection operators are validated against it.
+type DefaultPbToOrmConverter struct {
+	DefaultFilteringConditionConverter
+	DefaultSortingCriteriaConverter
+	DefaultFieldSelectionConverter
+	DefaultPaginationConverter
+	DefaultSearchingConverter
+	Policy *query.CollectionPolicy
+}
+
+// NewDefaultPbToOrmConverter creates default converter for all collection operators
+func NewDefaultPbToOrmConverter(pb proto.Message) CollectionOperatorsConverter {
+	return &DefaultPbToOrmConverter{
+		DefaultFilteringConditionConverter{&DefaultFilteringConditionProcessor{pb}},
+		DefaultSortingCriteriaConverter{},
+		DefaultFieldSelectionConverter{},
+		DefaultPaginationConverter{},
+		DefaultSearchingConverter{},
+		nil,
+	}
+}
+
+// ValidateCollectionOperators validates filtering f and sorting s against the policy of the converter.
+func (converter *DefaultPbToOrmConverter) ValidateCollectionOperators(ctx context.Context, f *query.Filtering, s *query.Sorting) error {
+	return converter.Policy.Validate(f, s)
+}
+
+// LogicalOperatorToGorm returns GORM Plain SQL representation of the logical operator.
+func (converter *DefaultFilteringConditionConverter) LogicalOperatorToGorm(ctx context.Context, lop *query.LogicalOperator, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var lres string
+	var largs []interface{}
+	var lAssocToJoin map[string]struct{}
+	var err error
+	switch l := lop.Left.(type) {
+	case *query.LogicalOperator_LeftOperator:
+		lres, largs, lAssocToJoin, err = converter.LogicalOperatorToGorm(ctx, l.LeftOperator, obj)
+	case *query.LogicalOperator_LeftStringCondition:
+		lres, largs, lAssocToJoin, err = converter.StringConditionToGorm(ctx, l.LeftStringCondition, obj)
+	case *query.LogicalOperator_LeftNumberCondition:
+		lres, largs, lAssocToJoin, err = converter.NumberConditionToGorm(ctx, l.LeftNumberCondition, obj)
+	case *query.LogicalOperator_LeftNullCondition:
+		lres, largs, lAssocToJoin, err = converter.NullConditionToGorm(ctx, l.LeftNullCondition, obj)
+	case *query.LogicalOperator_LeftNumberArrayCondition:
+		lres, largs, lAssocToJoin, err = converter.NumberArrayConditionToGorm(ctx, l.LeftNumberArrayCondition, obj)
+	case *query.LogicalOperator_LeftStringArrayCondition:
+		lres, largs, lAssocToJoin, err = converter.StringArrayConditionToGorm(ctx, l.LeftStringArrayCondition, obj)
+	case *query.LogicalOperator_LeftTimeCondition:
+		lres, largs, lAssocToJoin, err = converter.TimeConditionToGorm(ctx, l.LeftTimeCondition, obj)
+	case *query.LogicalOperator_LeftBoolCondition:
+		lres, largs, lAssocToJoin, err = converter.BoolConditionToGorm(ctx, l.LeftBoolCondition, obj)
+	case *query.LogicalOperator_LeftContainsCondition:
+		lres, largs, lAssocToJoin, err = converter.ContainsConditionToGorm(ctx, l.LeftContainsCondition, obj)
+	default:
+		return "", nil, nil, fmt.Errorf("%T type is not supported in Filtering", l)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	var rres string
+	var rargs []interface{}
+	var rAssocToJoin map[string]struct{}
+	switch r := lop.Right.(type) {
+	case *query.LogicalOperator_RightOperator:
+		rres, rargs, rAssocToJoin, err = converter.LogicalOperatorToGorm(ctx, r.RightOperator, obj)
+	case *query.LogicalOperator_RightStringCondition:
+		rres, rargs, rAssocToJoin, err = converter.StringConditionToGorm(ctx, r.RightStringCondition, obj)
+	case *query.LogicalOperator_RightNumberCondition:
+		rres, rargs, rAssocToJoin, err = converter.NumberConditionToGorm(ctx, r.RightNumberCondition, obj)
+	case *query.LogicalOperator_RightNullCondition:
+		rres, rargs, rAssocToJoin, err = converter.NullConditionToGorm(ctx, r.RightNullCondition, obj)
+	case *query.LogicalOperator_RightNumberArrayCondition:
+		rres, rargs, rAssocToJoin, err = converter.NumberArrayConditionToGorm(ctx, r.RightNumberArrayCondition, obj)
+	case *query.LogicalOperator_RightStringArrayCondition:
+		rres, rargs, rAssocToJoin, err = converter.StringArrayConditionToGorm(ctx, r.RightStringArrayCondition, obj)
+	case *query.LogicalOperator_RightTimeCondition:
+		rres, rargs, rAssocToJoin, err = converter.TimeConditionToGorm(ctx, r.RightTimeCondition, obj)
+	case *query.LogicalOperator_RightBoolCondition:
+		rres, rargs, rAssocToJoin, err = converter.BoolConditionToGorm(ctx, r.RightBoolCondition, obj)
+	case *query.LogicalOperator_RightContainsCondition:
+		rres, rargs, rAssocToJoin, err = converter.ContainsConditionToGorm(ctx, r.RightContainsCondition, obj)
+	default:
+		return "", nil, nil, fmt.Errorf("%T type is not supported in Filtering", r)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if lAssocToJoin == nil && rAssocToJoin != nil {
+		lAssocToJoin = make(map[string]struct{})
+	}
+	for k := range rAssocToJoin {
+		lAssocToJoin[k] = struct{}{}
+	}
+
+	var o string
+	switch lop.Type {
+	case query.LogicalOperator_AND:
+		o = "AND"
+	case query.LogicalOperator_OR:
+		o = "OR"
+	}
+	var neg string
+	if lop.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s %s %s)", neg, lres, o, rres), append(largs, rargs...), lAssocToJoin, nil
+}
+
+// StringConditionToGorm returns GORM Plain SQL representation of the string condition.
+func (converter *DefaultFilteringConditionConverter) StringConditionToGorm(ctx context.Context, c *query.StringCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var (
+		assocToJoin   map[string]struct{}
+		dbName, assoc string
+		err           error
+	)
+
+	if IsJSONCondition(ctx, c.FieldPath, obj) {
+		dbName, assoc, err = HandleJSONFieldPath(ctx, c.FieldPath, obj, c.Value)
+	} else {
+		dbName, assoc, err = HandleFieldPath(ctx, c.FieldPath, obj)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	var o string
+	switch c.Type {
+	case query.StringCondition_EQ, query.StringCondition_IEQ:
+		o = "="
+	case query.StringCondition_MATCH:
+		o = "~"
+	case query.StringCondition_GT:
+		o = ">"
+	case query.StringCondition_GE:
+		o = ">="
+	case query.StringCondition_LT:
+		o = "<"
+	case query.StringCondition_LE:
+		o = "<="
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+
+	var value interface{}
+	if v, err := converter.Processor.ProcessStringCondition(ctx, c.FieldPath, c.Value); err != nil {
+		value = c.Value
+	} else {
+		value = v
+	}
+
+	if c.Type == query.StringCondition_IEQ {
+		return converter.insensitiveCaseStringConditionToGorm(neg, dbName, o), []interface{}{value}, assocToJoin, nil
+	}
+
+	// N.B. if the user specifies a value that the codec translates to NULL
+	// (e.g. `field1 == ""` for string columns) instead of using the explicit
+	// support for identity (`field1 == null`), the results of this syntax may
+	// not match user expectations - `(col_name = NULL)` will match no rows,
+	// not even rows with NULL values. Did the user intend to match rows with
+	// NULL values (`field1 IS NULL`)?
+	return fmt.Sprintf("%s(%s %s ?)", neg, dbName, o), []interface{}{value}, assocToJoin, nil
+}
+
+func (converter *DefaultFilteringConditionConverter) insensitiveCaseStringConditionToGorm(neg, dbName, operator string) string {
+	return fmt.Sprintf("%s(lower(%s) %s lower(?))", neg, dbName, operator)
+}
+
+// ProcessStringCondition processes a string condition to GORM Plain SQL representation
+func (p *DefaultFilteringConditionProcessor) ProcessStringCondition(ctx context.Context, fieldPath []string, value string) (interface{}, error) {
+	objType := indirectType(reflect.TypeOf(p.pb))
+	pathLength := len(fieldPath)
+	for i, part := range fieldPath {
+		sf, ok := objType.FieldByName(util.Camel(part))
+		if !ok {
+			return nil, fmt.Errorf("Cannot find field %s in %s", part, objType)
+		}
+		if i < pathLength-1 {
+			objType = indirectType(sf.Type)
+			if !isProtoMessage(objType) {
+				return nil, fmt.Errorf("%s: non-last field of %s field path should be a proto message", objType, fieldPath)
+			}
+		} else {
+			if isIdentifier(indirectType(sf.Type)) {
+				id := &resource.Identifier{}
+				if err := jsonpb.UnmarshalString(fmt.Sprintf("\"%s\"", value), id); err != nil {
+					return nil, err
+				}
+				newPb := reflect.New(objType)
+				v := newPb.Elem().FieldByName(util.Camel(part))
+				v.Set(reflect.ValueOf(id))
+				toOrm := newPb.MethodByName("ToORM")
+				if !toOrm.IsValid() {
+					return nil, fmt.Errorf("ToORM method cannot be found for %s", objType)
+				}
+				res := toOrm.Call([]reflect.Value{reflect.ValueOf(ctx)})
+				if len(res) != 2 {
+					return nil, fmt.Errorf("ToORM signature of %s is unknown", objType)
+				}
+				orm := res[0]
+				err := res[1]
+				if !err.IsNil() {
+					if tErr, ok := err.Interface().(error); ok {
+						return nil, tErr
+					} else {
+						return nil, fmt.Errorf("ToOrm second return value of %s is expected to be error", objType)
+					}
+				}
+				ormId := orm.FieldByName(util.Camel(part))
+				if !ormId.IsValid() {
+					return nil, fmt.Errorf("Cannot find field %s in %s", part, objType)
+				}
+				// For type values where the codec translates a NULL value in
+				// SQL, we receive a pointer of nil value. E.g. `""`.
+				switch ormId.Kind() {
+				case reflect.Ptr, reflect.UnsafePointer:
+					if ormId.IsNil() {
+						return nil, nil
+					}
+				}
+				return reflect.Indirect(ormId).Interface(), nil
+
+			}
+		}
+	}
+	return value, nil
+}
+
+// NumberConditionToGorm returns GORM Plain SQL representation of the number condition.
+func (converter *DefaultFilteringConditionConverter) NumberConditionToGorm(ctx context.Context, c *query.NumberCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var assocToJoin map[string]struct{}
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	var o string
+	switch c.Type {
+	case query.NumberCondition_EQ:
+		o = "="
+	case query.NumberCondition_GT:
+		o = ">"
+	case query.NumberCondition_GE:
+		o = ">="
+	case query.NumberCondition_LT:
+		o = "<"
+	case query.NumberCondition_LE:
+		o = "<="
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s %s ?)", neg, dbName, o), []interface{}{c.Value}, assocToJoin, nil
+}
+
+// TimeConditionToGorm returns GORM Plain SQL representation of the time condition.
+func (converter *DefaultFilteringConditionConverter) TimeConditionToGorm(ctx context.Context, c *query.TimeCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var assocToJoin map[string]struct{}
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	var o string
+	switch c.Type {
+	case query.TimeCondition_EQ:
+		o = "="
+	case query.TimeCondition_GT:
+		o = ">"
+	case query.TimeCondition_GE:
+		o = ">="
+	case query.TimeCondition_LT:
+		o = "<"
+	case query.TimeCondition_LE:
+		o = "<="
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s %s ?)", neg, dbName, o), []interface{}{c.Value.AsTime()}, assocToJoin, nil
+}
+
+// BoolConditionToGorm returns GORM Plain SQL representation of the bool condition.
+func (converter *DefaultFilteringConditionConverter) BoolConditionToGorm(ctx context.Context, c *query.BoolCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var assocToJoin map[string]struct{}
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	var o string
+	switch c.Type {
+	case query.BoolCondition_EQ:
+		o = "="
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s %s ?)", neg, dbName, o), []interface{}{c.Value}, assocToJoin, nil
+}
+
+// NullConditionToGorm returns GORM Plain SQL representation of the null condition.
+func (converter *DefaultFilteringConditionConverter) NullConditionToGorm(ctx context.Context, c *query.NullCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var assocToJoin map[string]struct{}
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	o := "IS NULL"
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+	return fmt.Sprintf("%s(%s %s)", neg, dbName, o), nil, assocToJoin, nil
+}
+
+func (converter *DefaultFilteringConditionConverter) NumberArrayConditionToGorm(ctx context.Context, c *query.NumberArrayCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var assocToJoin map[string]struct{}
+	dbName, assoc, err := HandleFieldPath(ctx, c.FieldPath, obj)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	o := "IN"
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+
+	placeholder := ""
+	values := make([]interface{}, 0, len(c.Values))
+	for _, val := range c.Values {
+		placeholder += "?, "
+		values = append(values, val)
+	}
+
+	return fmt.Sprintf("(%s %s %s (%s))", dbName, neg, o, strings.TrimSuffix(placeholder, ", ")), values, assocToJoin, nil
+}
+
+func (converter *DefaultFilteringConditionConverter) StringArrayConditionToGorm(ctx context.Context, c *query.StringArrayCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var (
+		assocToJoin   map[string]struct{}
+		dbName, assoc string
+		err           error
+	)
+	if IsJSONCondition(ctx, c.FieldPath, obj) {
+		dbName, assoc, err = HandleJSONFieldPath(ctx, c.FieldPath, obj, c.Values...)
+	} else {
+		dbName, assoc, err = HandleFieldPath(ctx, c.FieldPath, obj)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	o := "IN"
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+
+	// in conditions on collection columns test whether the collection has any of the values,
+	// unless values of a jsonb column are JSON documents to compare the column with
+	switch {
+	case IsArrayCondition(ctx, c.FieldPath, obj):
+		return fmt.Sprintf("%s(%s && ?)", neg, dbName), []interface{}{pq.StringArray(c.Values)}, assocToJoin, nil
+	case len(c.FieldPath) == 1 && IsJSONCondition(ctx, c.FieldPath, obj) && !isRawJSON(c.Values...):
+		return fmt.Sprintf("%s(%s ? ?)", neg, dbName), []interface{}{jsonbOperator("?|"), pq.StringArray(c.Values)}, assocToJoin, nil
+	}
+
+	values := make([]interface{}, 0, len(c.Values))
+	placeholder := ""
+	for _, str := range c.Values {
+		placeholder += "?, "
+		if val, err := converter.Processor.ProcessStringCondition(ctx, c.FieldPath, str); err == nil {
+			values = append(values, val)
+			continue
+		}
+
+		values = append(values, str)
+	}
+
+	return fmt.Sprintf("(%s %s %s (%s))", dbName, neg, o, strings.TrimSuffix(placeholder, ", ")), values, assocToJoin, nil
+}
+
+// SortingCriteriaToGorm returns GORM representation of the sort criteria.
+// Tag of the criteria can refer to a field of an association, e.g. owner.name, in which case
+// the association is returned to be joined, or to a nested key of a json field, e.g. info.address.city.
+func (converter *DefaultSortingCriteriaConverter) SortingCriteriaToGorm(ctx context.Context, cr *query.SortCriteria, obj interface{}) (string, string, error) {
+	var (
+		dbCr, assoc string
+		err         error
+	)
+	fieldPath := strings.Split(cr.GetTag(), ".")
+	if len(fieldPath) > 1 && IsJSONCondition(ctx, fieldPath, obj) {
+		dbCr, assoc, err = HandleJSONFieldPath(ctx, fieldPath, obj)
+	} else {
+		dbCr, assoc, err = HandleFieldPath(ctx, fieldPath, obj)
+	}
+	if cr.IsDesc() {
+		dbCr += " desc"
+	}
+	switch cr.GetNulls() {
+	case query.SortCriteria_NULLS_FIRST:
+		dbCr += " nulls first"
+	case query.SortCriteria_NULLS_LAST:
+		dbCr += " nulls last"
+	}
+	return dbCr, assoc, err
+}
+
+func (converter *DefaultPaginationConverter) PaginationToGorm(ctx context.Context, p *query.Pagination) (offset, limit int32) {
+	if p != nil {
+		return p.GetOffset(), p.GetLimit()
+	}
+	return 0, 0
+}
+
+// PageTokenToGorm decodes the page token of p. Tokens decoded by Codec are
+// verified to be issued for collection operators with the given scope.
+// Returns nil if p requests the first page.
+func (converter *DefaultPaginationConverter) PageTokenToGorm(ctx context.Context, p *query.Pagination, scope string) (*query.PageToken, error) {
+	ptoken := p.GetPageToken()
+	if ptoken == "" || ptoken == "null" {
+		return nil, nil
+	}
+	if converter.Codec == nil {
+		if !query.IsCursorToken(ptoken) {
+			return nil, nil
+		}
+		c, err := query.DecodeCursorToken(ptoken)
+		if err != nil {
+			return nil, err
+		}
+		return &query.PageToken{Cursor: c}, nil
+	}
+	t, err := converter.Codec.Decode(ptoken)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Validate(scope); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// PageTokenFromGorm encodes page token t issued for collection operators with the given scope.
+func (converter *DefaultPaginationConverter) PageTokenFromGorm(ctx context.Context, t *query.PageToken, scope string) (string, error) {
+	if converter.Codec == nil {
+		if t.Cursor != nil {
+			return query.EncodeCursorToken(t.Cursor)
+		}
+		return query.EncodePageToken(t.Offset, t.Limit), nil
+	}
+	tc := *t
+	tc.Scope = scope
+	return converter.Codec.Encode(&tc)
+}
+
+// SearchingToGorm returns the full-text search condition of records matching s with a placeholder for the search query.
+func (converter *DefaultSearchingConverter) SearchingToGorm(ctx context.Context, s *query.Searching, fieldsForFTS []string, obj interface{}) (string, error) {
+	document, err := converter.document(fieldsForFTS, obj)
+	if err != nil {
+		return "", err
+	}
+	return document + " @@ " + converter.tsquery(), nil
+}
+
+// SearchingQueryToGorm returns the search query of s in the syntax of the searching condition.
+func (converter *DefaultSearchingConverter) SearchingQueryToGorm(ctx context.Context, s *query.Searching) string {
+	if converter.WebSearch {
+		return strings.TrimSpace(s.GetQuery())
+	}
+	return SearchQuery(s.GetQuery())
+}
+
+// SearchingRankToGorm returns the ts_rank of records matching s if Rank is set.
+func (converter *DefaultSearchingConverter) SearchingRankToGorm(ctx context.Context, s *query.Searching, fieldsForFTS []string, obj interface{}) (string, error) {
+	if !converter.Rank {
+		return "", nil
+	}
+	document, err := converter.document(fieldsForFTS, obj)
+	if err != nil {
+		return "", err
+	}
+	return "ts_rank(" + document + ", " + converter.tsquery() + ")", nil
+}
+
+func (converter *DefaultSearchingConverter) dictionary() string {
+	if converter.Dictionary == "" {
+		return "'simple'"
+	}
+	return quoteLiteral(converter.Dictionary)
+}
+
+// document returns the tsvector records of obj are matched against.
+func (converter *DefaultSearchingConverter) document(fieldsForFTS []string, obj interface{}) (string, error) {
+	if converter.Column == "" {
+		return "to_tsvector(" + converter.dictionary() + ", " + GetFullTextSearchDBMask(obj, fieldsForFTS, " ") + ")", nil
+	}
+	if strings.Contains(converter.Column, ".") {
+		return converter.Column, nil
+	}
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return "", err
+	}
+	return sch.Table + "." + converter.Column, nil
+}
+
+func (converter *DefaultSearchingConverter) tsquery() string {
+	if converter.WebSearch {
+		return "websearch_to_tsquery(" + converter.dictionary() + ", ?)"
+	}
+	return "to_tsquery(" + converter.dictionary() + ", ?)"
+}
+
+// ContainsConditionToGorm returns GORM Plain SQL representation of the contains condition.
+// Conditions on json fields are converted to jsonb @>, ? and ?& operators, conditions
+// on other fields to array @> operator, so that GIN indexes on the columns can be used.
+func (converter *DefaultFilteringConditionConverter) ContainsConditionToGorm(ctx context.Context, c *query.ContainsCondition, obj interface{}) (string, []interface{}, map[string]struct{}, error) {
+	var (
+		assocToJoin   map[string]struct{}
+		dbName, assoc string
+		err           error
+	)
+	if len(c.Values) == 0 {
+		return "", nil, nil, fmt.Errorf("%s condition on %s requires at least one value", strings.ToLower(c.Type.String()), strings.Join(c.FieldPath, "."))
+	}
+	isJSON := IsJSONCondition(ctx, c.FieldPath, obj)
+	if isJSON {
+		dbName, assoc, err = handleJSONFieldPath(c.FieldPath, obj, "#>")
+	} else {
+		dbName, assoc, err = HandleFieldPath(ctx, c.FieldPath, obj)
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+	// containment is defined only for arrays and JSON documents
+	if !isJSON && !isArrayField(ctx, c.FieldPath, obj) {
+		fp := strings.Join(c.FieldPath, ".")
+		return "", nil, nil, errors.NewContainer(codes.InvalidArgument, "Collection operators validation failed.").
+			WithField(fp, "Operator %s is not supported by %s, it is neither an array nor a JSON field.", strings.ToLower(c.Type.String()), fp)
+	}
+
+	if assoc != "" {
+		assocToJoin = make(map[string]struct{})
+		assocToJoin[assoc] = struct{}{}
+	}
+	var neg string
+	if c.IsNegative {
+		neg = "NOT"
+	}
+
+	switch {
+	case isJSON && c.Type == query.ContainsCondition_HAS && len(c.Values) == 1:
+		return fmt.Sprintf("%s(%s ? ?)", neg, dbName), []interface{}{jsonbOperator("?"), c.Values[0]}, assocToJoin, nil
+	case isJSON && c.Type == query.ContainsCondition_HAS:
+		return fmt.Sprintf("%s(%s ? ?)", neg, dbName), []interface{}{jsonbOperator("?&"), pq.StringArray(c.Values)}, assocToJoin, nil
+	case isJSON:
+		value, err := json.Marshal(c.Values)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		return fmt.Sprintf("%s(%s @> ?)", neg, dbName), []interface{}{string(value)}, assocToJoin, nil
+	default:
+		return fmt.Sprintf("%s(%s @> ?)", neg, dbName), []interface{}{pq.StringArray(c.Values)}, assocToJoin, nil
+	}
+}
+
+// jsonbOperator returns an argument of a GORM Plain SQL condition that renders jsonb operator o
+// in place of its placeholder, since GORM treats every question mark of a condition as a placeholder,
+// e.g. ("(tags ? ?)", jsonbOperator("?"), "a") is rendered as tags ? $1.
+func jsonbOperator(o string) interface{} {
+	return clause.Expr{SQL: o}
+}
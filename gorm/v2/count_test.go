@@ -0,0 +1,98 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+func TestCount(t *testing.T) {
+	ctx := context.Background()
+	db, mock := setUp(t)
+	c := NewDefaultPbToOrmConverter(&Contact{})
+	f, err := query.ParseFiltering("name == 'John'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "contacts" WHERE \(contacts.name = \$1\)`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+	total, mode, err := Count(ctx, db, &ContactORM{}, c, f)
+	if err != nil {
+		t.Fatalf("failed to count contacts - %s", err)
+	}
+	if total != 7 || mode != ExactCount {
+		t.Errorf("unexpected count %d (%s) - expected: 7 (exact)", total, mode)
+	}
+
+	// the estimate of the filtered query is taken from its plan
+	mock.ExpectQuery(`^EXPLAIN \(FORMAT JSON\) SELECT \* FROM "contacts" WHERE \(contacts.name = \$1\)`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 5400}}]`))
+	total, mode, err = Count(ctx, db, &ContactORM{}, c, f, WithApproximateCount(1000))
+	if err != nil {
+		t.Fatalf("failed to count contacts - %s", err)
+	}
+	if total != 5400 || mode != PlannerEstimate {
+		t.Errorf("unexpected count %d (%s) - expected: 5400 (planner estimate)", total, mode)
+	}
+
+	// the estimate of the table is below the threshold, so records are counted
+	mock.ExpectQuery(`^SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass\(\$1\)`).
+		WithArgs("contacts").
+		WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(120))
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "contacts"$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(118))
+	total, mode, err = Count(ctx, db, &ContactORM{}, c, nil, WithApproximateCount(1000))
+	if err != nil {
+		t.Fatalf("failed to count contacts - %s", err)
+	}
+	if total != 118 || mode != ExactCount {
+		t.Errorf("unexpected count %d (%s) - expected: 118 (exact)", total, mode)
+	}
+
+	mock.ExpectQuery(`^SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass\(\$1\)`).
+		WithArgs("contacts").
+		WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(2000000))
+	total, mode, err = Count(ctx, db, &ContactORM{}, c, nil, WithApproximateCount(1000))
+	if err != nil {
+		t.Fatalf("failed to count contacts - %s", err)
+	}
+	if total != 2000000 || mode != TableEstimate || !mode.Approximate() {
+		t.Errorf("unexpected count %d (%s) - expected: 2000000 (table estimate)", total, mode)
+	}
+
+	// sorting and pagination of db do not affect the estimate
+	mock.ExpectQuery(`^EXPLAIN \(FORMAT JSON\) SELECT \* FROM "contacts" WHERE \(contacts.name = \$1\)$`).
+		WithArgs("John").
+		WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(`[{"Plan": {"Node Type": "Seq Scan", "Plan Rows": 5400}}]`))
+	total, mode, err = Count(ctx, db.Order("name").Limit(10).Offset(20), &ContactORM{}, c, f, WithApproximateCount(1000))
+	if err != nil {
+		t.Fatalf("failed to count contacts - %s", err)
+	}
+	if total != 5400 || mode != PlannerEstimate {
+		t.Errorf("unexpected count %d (%s) - expected: 5400 (planner estimate)", total, mode)
+	}
+
+	// the table has never been analyzed, so records are counted
+	mock.ExpectQuery(`^SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass\(\$1\)`).
+		WithArgs("contacts").
+		WillReturnRows(sqlmock.NewRows([]string{"reltuples"}).AddRow(-1))
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "contacts"$`).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	total, mode, err = Count(ctx, db, &ContactORM{}, c, nil, WithApproximateCount(0))
+	if err != nil {
+		t.Fatalf("failed to count contacts - %s", err)
+	}
+	if total != 3 || mode != ExactCount {
+		t.Errorf("unexpected count %d (%s) - expected: 3 (exact)", total, mode)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations - %s", err)
+	}
+}
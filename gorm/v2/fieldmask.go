@@ -0,0 +1,313 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	fieldmask "google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/util"
+)
+
+var (
+	protoMessageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+	structpbType     = reflect.TypeOf(&structpb.Struct{})
+	structpbValue    = reflect.TypeOf(&structpb.Value{})
+)
+
+// MaskPathsError is returned by MergeWithMask if paths of the field mask do not exist in the type of merged objects.
+type MaskPathsError struct {
+	Type  reflect.Type
+	Paths []string
+}
+
+func (e *MaskPathsError) Error() string {
+	if len(e.Paths) == 1 {
+		return fmt.Sprintf("Field path %q doesn't exist in type %s", e.Paths[0], e.Type)
+	}
+	quoted := make([]string, len(e.Paths))
+	for i, p := range e.Paths {
+		quoted[i] = strconv.Quote(p)
+	}
+	return fmt.Sprintf("Field paths %s don't exist in type %s", strings.Join(quoted, ", "), e.Type)
+}
+
+type mergeOptions struct {
+	appended map[string]bool
+	// appendPaths are paths of WithAppend in the order they are given
+	appendPaths []string
+}
+
+// MergeOption configures MergeWithMask.
+type MergeOption func(*mergeOptions)
+
+// WithAppend makes MergeWithMask append elements of repeated fields at paths to the destination
+// instead of replacing the whole list. Entries of maps at paths are added to the destination map
+// instead of replacing the whole map.
+func WithAppend(paths ...string) MergeOption {
+	return func(o *mergeOptions) {
+		for _, p := range paths {
+			if !o.appended[p] {
+				o.appended[p] = true
+				o.appendPaths = append(o.appendPaths, p)
+			}
+		}
+	}
+}
+
+// MergeWithMask will take the fields of `source` that are included as
+// paths in `mask` and write them to the corresponding fields of `dest`.
+// Paths are dot separated names of fields, either Go, protobuf or JSON ones. A path may continue with
+// a key of a map or google.protobuf.Struct field, e.g. "Labels.env", but not with an element of a repeated field.
+// Repeated fields and maps are replaced as a whole unless they are appended, see WithAppend.
+// Protobuf messages, including well-known types, are copied, so dest does not share them with source.
+// Fields that are absent in source, e.g. on a path through a nil pointer or a missing map key, are cleared in dest.
+// If paths of mask or WithAppend are invalid, dest is not modified and *MaskPathsError listing the paths
+// in the order they are given is returned.
+func MergeWithMask(source, dest interface{}, mask *fieldmask.FieldMask, opts ...MergeOption) error {
+	if mask == nil || len(mask.Paths) == 0 {
+		return nil
+	}
+	if source == nil {
+		return errors.New("Source object is nil")
+	}
+	if dest == nil {
+		return errors.New("Destination object is nil")
+	}
+	if reflect.TypeOf(source) != reflect.TypeOf(dest) {
+		return errors.New("Types of source and destination objects do not match")
+	}
+	o := &mergeOptions{appended: make(map[string]bool)}
+	for _, opt := range opts {
+		opt(o)
+	}
+	objType := reflect.TypeOf(source)
+	var invalid []string
+	for _, p := range mask.GetPaths() {
+		if _, ok := maskPathType(objType, strings.Split(p, ".")); !ok {
+			invalid = append(invalid, p)
+		}
+	}
+	for _, p := range o.appendPaths {
+		t, ok := maskPathType(objType, strings.Split(p, "."))
+		if ok && (t.Kind() == reflect.Slice || t.Kind() == reflect.Map) {
+			continue
+		}
+		invalid = append(invalid, p)
+	}
+	if len(invalid) > 0 {
+		return &MaskPathsError{Type: objType, Paths: invalid}
+	}
+	for _, p := range mask.GetPaths() {
+		mergePath(reflect.ValueOf(source).Elem(), reflect.ValueOf(dest).Elem(), strings.Split(p, "."), o.appended[p])
+	}
+	return nil
+}
+
+// maskPathType returns the type of the field at path in t, false if there is no such field.
+func maskPathType(t reflect.Type, path []string) (reflect.Type, bool) {
+	for _, name := range path {
+		if t == structpbType || t == structpbValue {
+			t = structpbValue
+			continue
+		}
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			sf, ok := maskField(t, name)
+			if !ok {
+				return nil, false
+			}
+			t = sf.Type
+		case reflect.Map:
+			if _, ok := mapKey(t, name); !ok {
+				return nil, false
+			}
+			t = t.Elem()
+		default:
+			return nil, false
+		}
+	}
+	return t, true
+}
+
+// maskField returns the exported field of struct type t with the Go, protobuf or JSON name.
+func maskField(t reflect.Type, name string) (reflect.StructField, bool) {
+	if sf, ok := t.FieldByName(name); ok && sf.PkgPath == "" {
+		return sf, true
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		for _, opt := range strings.Split(sf.Tag.Get("protobuf"), ",") {
+			if opt == "name="+name || opt == "json="+name {
+				return sf, true
+			}
+		}
+		if strings.Split(sf.Tag.Get("json"), ",")[0] == name {
+			return sf, true
+		}
+	}
+	if sf, ok := t.FieldByName(util.Camel(name)); ok && sf.PkgPath == "" {
+		return sf, true
+	}
+	return reflect.StructField{}, false
+}
+
+// mapKey converts name to the key of map type t.
+func mapKey(t reflect.Type, name string) (reflect.Value, bool) {
+	key := reflect.New(t.Key()).Elem()
+	switch key.Kind() {
+	case reflect.String:
+		key.SetString(name)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(name)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		key.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(name, 10, key.Type().Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		key.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(name, 10, key.Type().Bits())
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		key.SetUint(v)
+	default:
+		return reflect.Value{}, false
+	}
+	return key, true
+}
+
+// mergePath sets the field of dst at path to the one of src, src is invalid if the field is absent in the source.
+func mergePath(src, dst reflect.Value, path []string, appended bool) {
+	if src.IsValid() && src.Kind() == reflect.Ptr && src.IsNil() {
+		src = reflect.Value{}
+	}
+	if len(path) == 0 {
+		mergeValue(src, dst, appended)
+		return
+	}
+	if !src.IsValid() && dst.Kind() == reflect.Ptr && dst.IsNil() {
+		// nothing to clear
+		return
+	}
+	switch {
+	case dst.Type() == structpbType:
+		if dst.IsNil() {
+			dst.Set(reflect.ValueOf(&structpb.Struct{}))
+		}
+		var fields reflect.Value
+		if src.IsValid() {
+			fields = reflect.ValueOf(src.Interface().(*structpb.Struct).GetFields())
+		}
+		mergePath(fields, reflect.ValueOf(&dst.Interface().(*structpb.Struct).Fields).Elem(), path, appended)
+	case dst.Type() == structpbValue:
+		var fields reflect.Value
+		if src.IsValid() {
+			fields = reflect.ValueOf(src.Interface().(*structpb.Value).GetStructValue().GetFields())
+		}
+		s := dst.Interface().(*structpb.Value).GetStructValue()
+		if s == nil {
+			s = &structpb.Struct{}
+			dst.Set(reflect.ValueOf(structpb.NewStructValue(s)))
+		}
+		mergePath(fields, reflect.ValueOf(&s.Fields).Elem(), path, appended)
+	case dst.Kind() == reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		if src.IsValid() {
+			src = src.Elem()
+		}
+		mergePath(src, dst.Elem(), path, appended)
+	case dst.Kind() == reflect.Struct:
+		sf, _ := maskField(dst.Type(), path[0])
+		if src.IsValid() {
+			src = src.FieldByIndex(sf.Index)
+		}
+		mergePath(src, dst.FieldByIndex(sf.Index), path[1:], appended)
+	case dst.Kind() == reflect.Map:
+		key, _ := mapKey(dst.Type(), path[0])
+		if src.IsValid() {
+			src = src.MapIndex(key)
+		}
+		current := dst.MapIndex(key)
+		if !src.IsValid() && (len(path) == 1 || !current.IsValid()) {
+			if current.IsValid() {
+				dst.SetMapIndex(key, reflect.Value{})
+			}
+			return
+		}
+		// map elements are not addressable, so the element is merged into a copy
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		if current.IsValid() {
+			elem.Set(current)
+		}
+		mergePath(src, elem, path[1:], appended)
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		dst.SetMapIndex(key, elem)
+	}
+}
+
+// mergeValue sets dst to src or, if appended, appends elements of src to dst.
+func mergeValue(src, dst reflect.Value, appended bool) {
+	if !src.IsValid() {
+		if !appended {
+			dst.Set(reflect.Zero(dst.Type()))
+		}
+		return
+	}
+	switch {
+	case appended && dst.Kind() == reflect.Slice:
+		dst.Set(reflect.AppendSlice(dst, cloneValue(src)))
+	case appended && dst.Kind() == reflect.Map:
+		if src.Len() > 0 && dst.IsNil() {
+			dst.Set(reflect.MakeMap(dst.Type()))
+		}
+		for iter := src.MapRange(); iter.Next(); {
+			dst.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+	default:
+		dst.Set(cloneValue(src))
+	}
+}
+
+// cloneValue returns a copy of protobuf messages v consists of, other values are returned as is.
+func cloneValue(v reflect.Value) reflect.Value {
+	switch {
+	case v.Type().Implements(protoMessageType):
+		if v.Kind() == reflect.Ptr && !v.IsNil() {
+			return reflect.ValueOf(proto.Clone(v.Interface().(proto.Message)))
+		}
+	case v.Kind() == reflect.Slice && !v.IsNil() && v.Type().Elem().Implements(protoMessageType):
+		clone := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			clone.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return clone
+	case v.Kind() == reflect.Map && !v.IsNil() && v.Type().Elem().Implements(protoMessageType):
+		clone := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			clone.SetMapIndex(iter.Key(), cloneValue(iter.Value()))
+		}
+		return clone
+	}
+	return v
+}
@@ -0,0 +1,152 @@
+package v2
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type childTest struct {
+	FieldOne   int
+	FieldTwo   string
+	FieldThree *int
+	FieldFour  []int
+}
+
+type topTest struct {
+	FieldA childTest
+	FieldB *childTest
+}
+
+func wrapInt(x int) *int {
+	return &x
+}
+
+func TestMergeWithMask(t *testing.T) {
+	source := &topTest{
+		FieldA: childTest{FieldOne: 22, FieldTwo: "catch", FieldThree: wrapInt(2), FieldFour: []int{1, 2, 3}},
+		FieldB: &childTest{FieldOne: 3, FieldTwo: "string", FieldThree: wrapInt(1), FieldFour: []int{3, 2, 1}},
+	}
+	dest := &topTest{}
+	err := MergeWithMask(source, dest, &field_mask.FieldMask{Paths: []string{"FieldB.FieldOne", "FieldA.FieldTwo", "FieldA.FieldThree", "FieldB.FieldFour"}})
+	assert.Equal(t, &topTest{
+		FieldA: childTest{FieldTwo: "catch", FieldThree: wrapInt(2)},
+		FieldB: &childTest{FieldOne: 3, FieldFour: []int{3, 2, 1}},
+	}, dest)
+	assert.Nil(t, err)
+
+	err = MergeWithMask(source, dest, &field_mask.FieldMask{Paths: []string{"FieldB.FieldDNE", "FieldA.FieldTwo"}})
+	assert.EqualError(t, err, "Field path \"FieldB.FieldDNE\" doesn't exist in type *v2.topTest")
+
+	err = MergeWithMask(nil, dest, &field_mask.FieldMask{Paths: []string{"FieldB.FieldDNE"}})
+	assert.Equal(t, errors.New("Source object is nil"), err)
+
+	for _, fm := range []*field_mask.FieldMask{nil, {}} {
+		err = MergeWithMask(nil, nil, fm)
+		assert.Nil(t, err)
+		err = MergeWithMask(nil, dest, fm)
+		assert.Nil(t, err)
+		err = MergeWithMask(source, nil, fm)
+		assert.Nil(t, err)
+		err = MergeWithMask(source, dest.FieldA, fm)
+		assert.Nil(t, err)
+	}
+	err = MergeWithMask(source, nil, &field_mask.FieldMask{Paths: []string{"FieldB"}})
+	assert.Equal(t, errors.New("Destination object is nil"), err)
+	err = MergeWithMask(source, dest.FieldA, &field_mask.FieldMask{Paths: []string{"FieldB"}})
+	assert.Equal(t, errors.New("Types of source and destination objects do not match"), err)
+	// invalid paths are reported together and nothing is merged
+	dest = &topTest{}
+	err = MergeWithMask(source, dest, &field_mask.FieldMask{Paths: []string{"FieldA.FieldTwo", "FieldA.FieldFour.Anything", "FieldC"}})
+	assert.Equal(t, &MaskPathsError{Type: reflect.TypeOf(source), Paths: []string{"FieldA.FieldFour.Anything", "FieldC"}}, err)
+	assert.EqualError(t, err, "Field paths \"FieldA.FieldFour.Anything\", \"FieldC\" don't exist in type *v2.topTest")
+	assert.Equal(t, &topTest{}, dest)
+}
+
+type labeledTest struct {
+	Name       *wrapperspb.StringValue `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tags       []string                `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	Labels     map[string]string       `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty"`
+	Children   map[int32]*childTest    `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	Attributes *structpb.Struct        `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`
+}
+
+func TestMergeWithMaskDeep(t *testing.T) {
+	attributes, err := structpb.NewStruct(map[string]interface{}{
+		"color": "red",
+		"size":  map[string]interface{}{"width": 2, "height": 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := &labeledTest{
+		Name:       wrapperspb.String("source"),
+		Tags:       []string{"c"},
+		Labels:     map[string]string{"env": "prod", "team": "dns"},
+		Children:   map[int32]*childTest{1: {FieldOne: 1, FieldTwo: "one"}},
+		Attributes: attributes,
+	}
+	dest := &labeledTest{
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]string{"env": "dev", "owner": "ops", "region": "us"},
+		Children: map[int32]*childTest{1: {FieldTwo: "uno"}, 2: {FieldTwo: "two"}},
+		Attributes: &structpb.Struct{Fields: map[string]*structpb.Value{
+			"shape": structpb.NewStringValue("round"),
+			"size":  structpb.NewStructValue(&structpb.Struct{Fields: map[string]*structpb.Value{"depth": structpb.NewNumberValue(1)}}),
+		}},
+	}
+	err = MergeWithMask(source, dest, &field_mask.FieldMask{Paths: []string{
+		"name", "tags", "labels.env", "labels.owner", "Children.1.FieldOne", "attributes.color", "attributes.size.width",
+	}}, WithAppend("tags"))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "source", dest.Name.GetValue())
+	source.Name.Value = "changed"
+	assert.Equal(t, "source", dest.Name.GetValue(), "messages are copied")
+	assert.Equal(t, []string{"a", "b", "c"}, dest.Tags)
+	assert.Equal(t, map[string]string{"env": "prod", "region": "us"}, dest.Labels)
+	assert.Equal(t, map[int32]*childTest{1: {FieldOne: 1, FieldTwo: "uno"}, 2: {FieldTwo: "two"}}, dest.Children)
+	assert.Equal(t, map[string]interface{}{
+		"color": "red",
+		"shape": "round",
+		"size":  map[string]interface{}{"width": 2.0, "depth": 1.0},
+	}, dest.Attributes.AsMap())
+
+	// maps are replaced as a whole unless appended
+	err = MergeWithMask(source, dest, &field_mask.FieldMask{Paths: []string{"labels", "tags"}}, WithAppend("labels"))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"env": "prod", "team": "dns", "region": "us"}, dest.Labels)
+	assert.Equal(t, []string{"c"}, dest.Tags)
+
+	// absent fields are cleared
+	err = MergeWithMask(&labeledTest{}, dest, &field_mask.FieldMask{Paths: []string{"name", "labels.team", "attributes.size"}})
+	assert.Nil(t, err)
+	assert.Nil(t, dest.Name)
+	assert.Equal(t, map[string]string{"env": "prod", "region": "us"}, dest.Labels)
+	assert.Equal(t, []string{"color", "shape"}, sortedKeys(dest.Attributes.AsMap()))
+
+	err = MergeWithMask(source, dest, &field_mask.FieldMask{Paths: []string{"tags.0", "children.one", "name.value.x"}}, WithAppend("name"))
+	assert.EqualError(t, err, "Field paths \"tags.0\", \"children.one\", \"name.value.x\", \"name\" don't exist in type *v2.labeledTest")
+
+	// invalid appended paths are reported in the order they are given
+	for i := 0; i < 10; i++ {
+		err = MergeWithMask(source, dest, &field_mask.FieldMask{Paths: []string{"tags"}}, WithAppend("tags", "name", "missing"), WithAppend("attributes", "name", "labels.env"))
+		assert.EqualError(t, err, "Field paths \"name\", \"missing\", \"attributes\", \"labels.env\" don't exist in type *v2.labeledTest")
+	}
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
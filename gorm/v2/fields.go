@@ -0,0 +1,163 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+// DefaultFieldSelectionConverter performs default convertion for FieldSelection collection operator
+type DefaultFieldSelectionConverter struct{}
+
+// FieldSelectionStringToGorm is a shortcut to parse a string into FieldSelection struct and
+// receive a list of associations to preload.
+func FieldSelectionStringToGorm(ctx context.Context, fs string, obj interface{}) ([]string, error) {
+	c := &DefaultFieldSelectionConverter{}
+	return c.FieldSelectionToGorm(ctx, query.ParseFieldSelection(fs), obj)
+}
+
+// FieldSelectionToGorm receives FieldSelection struct and returns a list of associations to preload.
+// Associations are resolved from GORM schema of obj, `gorm:"preload:false"` tag excludes an association
+// from being preloaded when all fields are selected.
+func (converter *DefaultFieldSelectionConverter) FieldSelectionToGorm(ctx context.Context, fs *query.FieldSelection, obj interface{}) ([]string, error) {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return nil, err
+	}
+	selectedFields := fs.GetFields()
+	if selectedFields == nil {
+		return preloadEverything(sch, nil), nil
+	}
+	var toPreload []string
+	fieldNames := getSortedFieldNames(selectedFields)
+	for _, fieldName := range fieldNames {
+		f := selectedFields[fieldName]
+		subPreload, err := handlePreloads(f, sch)
+		if err != nil {
+			return nil, err
+		}
+		toPreload = append(toPreload, subPreload...)
+	}
+	return toPreload, nil
+}
+
+func preloadEverything(sch *schema.Schema, path []*schema.Schema) []string {
+	var toPreload []string
+fields:
+	for _, f := range sch.Fields {
+		rel, ok := sch.Relationships.Relations[f.Name]
+		if !ok {
+			continue
+		}
+		for _, e := range path {
+			if rel.FieldSchema == e {
+				continue fields
+			}
+		}
+		if f.TagSettings["PRELOAD"] == "false" {
+			continue
+		}
+		subPreload := preloadEverything(rel.FieldSchema, append(path, sch))
+		for i, e := range subPreload {
+			subPreload[i] = f.Name + "." + e
+		}
+		toPreload = append(toPreload, subPreload...)
+		toPreload = append(toPreload, f.Name)
+	}
+	return toPreload
+}
+
+func handlePreloads(f *query.Field, sch *schema.Schema) ([]string, error) {
+	queryFieldName := f.GetName()
+
+	// do default(camel-case) search
+	sf := schemaField(sch, queryFieldName)
+	if sf == nil {
+		// do case-insensitive search
+		for _, e := range sch.Fields {
+			if strings.EqualFold(e.Name, strings.ReplaceAll(queryFieldName, "_", "")) {
+				sf = e
+				break
+			}
+		}
+		if sf == nil {
+			return nil, nil
+		}
+	}
+
+	fName := sf.Name
+	rel, isAssoc := sch.Relationships.Relations[fName]
+
+	fieldSubs := f.GetSubs()
+
+	if fieldSubs == nil {
+		if isAssoc {
+			return []string{fName}, nil
+		}
+		return nil, nil
+	}
+	if !isAssoc {
+		return nil, fmt.Errorf("%s is expected to be an association, but got %s ", queryFieldName, sf.FieldType)
+	}
+	var toPreload []string
+	fieldNames := getSortedFieldNames(fieldSubs)
+	for _, fieldName := range fieldNames {
+		subField := fieldSubs[fieldName]
+		subPreload, err := handlePreloads(subField, rel.FieldSchema)
+		if err != nil {
+			return nil, err
+		}
+		for i, e := range subPreload {
+			subPreload[i] = fName + "." + e
+		}
+		toPreload = append(toPreload, subPreload...)
+	}
+	return append(toPreload, fName), nil
+}
+
+func getSortedFieldNames(fields map[string]*query.Field) []string {
+	var keys []string
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// preload preloads assoc association of obj, associations tagged with `atlas:"position:<field>"`
+// are ordered by the position field.
+func preload(db *gorm.DB, obj interface{}, assoc string) (*gorm.DB, error) {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return nil, err
+	}
+	assocPath := strings.Split(assoc, ".")
+	pathLength := len(assocPath)
+	for i, part := range assocPath {
+		rel, ok := sch.Relationships.Relations[part]
+		if !ok {
+			return nil, fmt.Errorf("cannot find association %s in %s", part, sch.Name)
+		}
+		if i == pathLength-1 {
+			ok, pos := atlasTag(&rel.Field.StructField, "position")
+			if !ok {
+				return db.Preload(assoc), nil
+			}
+			column := namer.ColumnName("", pos)
+			if f := schemaField(rel.FieldSchema, pos); f != nil {
+				column = f.DBName
+			}
+			return db.Preload(assoc, func(db *gorm.DB) *gorm.DB {
+				return db.Order(column)
+			}), nil
+		}
+		sch = rel.FieldSchema
+	}
+	return nil, fmt.Errorf("cannot preload empty association")
+}
@@ -0,0 +1,105 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Model struct {
+	ID             int64
+	Property       string
+	SubModel       SubModel
+	SubModels      []SubModel
+	NotPreloadObj  NotPreloadObj `gorm:"preload:false"`
+	NonCAMEL2Model NonCAMEL2Model
+}
+
+type SubModel struct {
+	ID          int64
+	ModelID     int64
+	SubProperty string
+	SubSubModel SubSubModel
+}
+
+type SubSubModel struct {
+	ID             int64
+	SubModelID     int64
+	SubSubProperty string
+}
+
+type NotPreloadObj struct {
+	ID      int64
+	ModelID int64
+}
+
+type NonCAMEL2Model struct {
+	ID      int64
+	ModelID int64
+}
+
+func TestGormFieldSelection(t *testing.T) {
+	tests := []struct {
+		fs        string
+		toPreload []string
+		err       bool
+	}{
+		{
+			"property",
+			nil,
+			false,
+		},
+		{
+			"property,sub_model",
+			[]string{"SubModel"},
+			false,
+		},
+		{
+			"sub_model,sub_models.sub_property",
+			[]string{"SubModel", "SubModels"},
+			false,
+		},
+		{
+			"sub_model.sub_sub_model.sub_sub_property",
+			[]string{"SubModel.SubSubModel", "SubModel"},
+			false,
+		},
+		{
+			"non_CAMEL_2_Model,non_camel2_model",
+			[]string{"NonCAMEL2Model", "NonCAMEL2Model"},
+			false,
+		},
+		{
+			"unknown_property",
+			nil,
+			false,
+		},
+		{
+			"not_preload_obj",
+			[]string{"NotPreloadObj"},
+			false,
+		},
+		{
+			"property.sub_property",
+			nil,
+			true,
+		},
+		{
+			"",
+			[]string{"SubModel.SubSubModel", "SubModel", "SubModels.SubSubModel", "SubModels", "NonCAMEL2Model"},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		toPreload, err := FieldSelectionStringToGorm(context.Background(), test.fs, &Model{})
+		if test.err {
+			assert.Nil(t, toPreload, test.fs)
+			assert.NotNil(t, err, test.fs)
+		} else {
+			assert.Equal(t, test.toPreload, toPreload, test.fs)
+			assert.Nil(t, err, test.fs)
+		}
+	}
+}
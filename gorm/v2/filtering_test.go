@@ -0,0 +1,423 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errfields"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/resource"
+)
+
+type Entity struct {
+	Field1       int
+	Field2       int
+	Field3       int
+	FieldString  string
+	NestedEntity NestedEntity
+	Id           string
+	Ref          *string
+	Tags         json.RawMessage `gorm:"type:jsonb"`
+	Labels       pq.StringArray  `gorm:"type:text[]"`
+}
+
+type EntityProto struct {
+	Id  *resource.Identifier
+	Ref *resource.Identifier
+}
+
+func (*EntityProto) Reset() {
+}
+
+func (*EntityProto) ProtoMessage() {
+}
+
+func (*EntityProto) String() string {
+	return "Entity"
+}
+
+func (*EntityProto) ToORM(ctx context.Context) (Entity, error) {
+	id := "convertedid"
+	ref := "convertedref"
+	return Entity{Id: id, Ref: &ref}, nil
+}
+
+type NestedEntity struct {
+	EntityId     string
+	NestedField1 int
+	NestedField2 int
+}
+
+func TestGormFiltering(t *testing.T) {
+
+	tests := []struct {
+		rest  string
+		gorm  string
+		args  []interface{}
+		assoc map[string]struct{}
+		err   error
+	}{
+		{
+			"not(field1 == 'value1' or field2 == 'value2' and field3 != 'value3')",
+			"NOT((entities.field1 = ?) OR ((entities.field2 = ?) AND NOT(entities.field3 = ?)))",
+			[]interface{}{"value1", "value2", "value3"},
+			nil,
+			nil,
+		},
+		{
+			"field1 ~ 'regex'",
+			"(entities.field1 ~ ?)",
+			[]interface{}{"regex"},
+			nil,
+			nil,
+		},
+		{
+			"field1 !~ 'regex'",
+			"NOT(entities.field1 ~ ?)",
+			[]interface{}{"regex"},
+			nil,
+			nil,
+		},
+		{
+			"field1 == 22",
+			"(entities.field1 = ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"not field1 == 22",
+			"NOT(entities.field1 = ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"field1 > 22",
+			"(entities.field1 > ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"not field1 > 22",
+			"NOT(entities.field1 > ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"field1 >= 22",
+			"(entities.field1 >= ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"not field1 >= 22",
+			"NOT(entities.field1 >= ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"field1 < 22",
+			"(entities.field1 < ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"not field1 < 22",
+			"NOT(entities.field1 < ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"field1 <= 22",
+			"(entities.field1 <= ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"not field1 <= 22",
+			"NOT(entities.field1 <= ?)",
+			[]interface{}{22.0},
+			nil,
+			nil,
+		},
+		{
+			"field_string > 'str'",
+			"(entities.field_string > ?)",
+			[]interface{}{"str"},
+			nil,
+			nil,
+		},
+		{
+			"field_string >= 'str'",
+			"(entities.field_string >= ?)",
+			[]interface{}{"str"},
+			nil,
+			nil,
+		},
+		{
+			"field_string < 'str'",
+			"(entities.field_string < ?)",
+			[]interface{}{"str"},
+			nil,
+			nil,
+		},
+		{
+			"field_string <= 'str'",
+			"(entities.field_string <= ?)",
+			[]interface{}{"str"},
+			nil,
+			nil,
+		},
+		{
+			"field1 == null",
+			"(entities.field1 IS NULL)",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"field1 != null",
+			"NOT(entities.field1 IS NULL)",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"field1 != null",
+			"NOT(entities.field1 IS NULL)",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"nested_entity.nested_field1 == 11 and nested_entity.nested_field2 == 22",
+			"((nested_entity.nested_field1 = ?) AND (nested_entity.nested_field2 = ?))",
+			[]interface{}{11.0, 22.0},
+			map[string]struct{}{"NestedEntity": {}},
+			nil,
+		},
+		{
+			"field1 === null",
+			"",
+			nil,
+			nil,
+			&query.UnexpectedSymbolError{},
+		},
+		{
+			"id == 'id' and ref == 'ref'",
+			"((entities.id = ?) AND (entities.ref = ?))",
+			[]interface{}{"convertedid", "convertedref"},
+			nil,
+			nil,
+		},
+		{
+			"id := 'ID'",
+			"(lower(entities.id) = lower(?))",
+			[]interface{}{"convertedid"},
+			nil,
+			nil,
+		},
+		{
+			"not(id := 'sOmeId')",
+			"NOT(lower(entities.id) = lower(?))",
+			[]interface{}{"convertedid"},
+			nil,
+			nil,
+		},
+		{
+			"id in ['sOmeId', 'egegeg']",
+			"(entities.id  IN (?, ?))",
+			[]interface{}{"convertedid", "convertedid"},
+			nil,
+			nil,
+		},
+		{
+			"not(id in ['sOmeId', 'egegeg'])",
+			"(entities.id NOT IN (?, ?))",
+			[]interface{}{"convertedid", "convertedid"},
+			nil,
+			nil,
+		},
+		{
+			"id in [1, 2]",
+			"(entities.id  IN (?, ?))",
+			[]interface{}{1.0, 2.0},
+			nil,
+			nil,
+		},
+		{
+			"not(id in [1, 2])",
+			"(entities.id NOT IN (?, ?))",
+			[]interface{}{1.0, 2.0},
+			nil,
+			nil,
+		},
+		{
+			`tags == '{"Location": "Tacoma"}'`,
+			`(entities.tags = ?)`,
+			[]interface{}{`{"Location": "Tacoma"}`},
+			nil,
+			nil,
+		},
+		{
+			`tags.Location == 'Tacoma'`,
+			`(entities.tags #>> '{Location}' = ?)`,
+			[]interface{}{"Tacoma"},
+			nil,
+			nil,
+		},
+		{
+			`tags.Location == '{"City": "Tacoma"}'`,
+			`(entities.tags #> '{Location}' = ?)`,
+			[]interface{}{`{"City": "Tacoma"}`},
+			nil,
+			nil,
+		},
+		{
+			`tags in ['{"Location": "Tacoma"}', '{"Location": "Minsk"}']`,
+			`(entities.tags  IN (?, ?))`,
+			[]interface{}{`{"Location": "Tacoma"}`, `{"Location": "Minsk"}`},
+			nil,
+			nil,
+		},
+		{
+			`tags.Location in ['Tacoma', 'Minsk']`,
+			`(entities.tags #>> '{Location}'  IN (?, ?))`,
+			[]interface{}{"Tacoma", "Minsk"},
+			nil,
+			nil,
+		},
+		{
+			`tags.Location in ['{"City": "Tacoma"}', '{"City": "Minsk"}']`,
+			`(entities.tags #> '{Location}'  IN (?, ?))`,
+			[]interface{}{`{"City": "Tacoma"}`, `{"City": "Minsk"}`},
+			nil,
+			nil,
+		},
+		{
+			`not(tags.Location == 'Tacoma')`,
+			`NOT(entities.tags #>> '{Location}' = ?)`,
+			[]interface{}{"Tacoma"},
+			nil,
+			nil,
+		},
+		{
+			`not(tags.Location in ['Tacoma', 'Minsk'])`,
+			`(entities.tags #>> '{Location}' NOT IN (?, ?))`,
+			[]interface{}{"Tacoma", "Minsk"},
+			nil,
+			nil,
+		},
+		{
+			`not(tags.Location in ['{"City": "Tacoma"}', '{"City": "Minsk"}'])`,
+			`(entities.tags #> '{Location}' NOT IN (?, ?))`,
+			[]interface{}{`{"City": "Tacoma"}`, `{"City": "Minsk"}`},
+			nil,
+			nil,
+		},
+		{
+			"field1 >= 2024-01-01T10:00:00Z",
+			"(entities.field1 >= ?)",
+			[]interface{}{time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			nil,
+			nil,
+		},
+		{
+			"field1 < 2024-01-01 and field2 != 2024-01-01T12:00:00+02:00",
+			"((entities.field1 < ?) AND NOT(entities.field2 = ?))",
+			[]interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)},
+			nil,
+			nil,
+		},
+		{
+			"field1 == true and not field2 == false",
+			"((entities.field1 = ?) AND NOT(entities.field2 = ?))",
+			[]interface{}{true, false},
+			nil,
+			nil,
+		},
+		{
+			"field1 != true",
+			"NOT(entities.field1 = ?)",
+			[]interface{}{true},
+			nil,
+			nil,
+		},
+		{
+			"nested_entity.nested_field1 > 2024-01-01",
+			"(nested_entity.nested_field1 > ?)",
+			[]interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			map[string]struct{}{"NestedEntity": {}},
+			nil,
+		},
+		{
+			"labels contains ['a', 'b'] and not labels has 'c'",
+			"((entities.labels @> ?) AND NOT(entities.labels @> ?))",
+			[]interface{}{pq.StringArray{"a", "b"}, pq.StringArray{"c"}},
+			nil,
+			nil,
+		},
+		{
+			"labels in ['a', 'b'] or not labels in ['c']",
+			"((entities.labels && ?) OR NOT(entities.labels && ?))",
+			[]interface{}{pq.StringArray{"a", "b"}, pq.StringArray{"c"}},
+			nil,
+			nil,
+		},
+		{
+			"tags contains ['a', 'b'] and tags.Location contains 'Tacoma'",
+			"((entities.tags @> ?) AND (entities.tags #> '{Location}' @> ?))",
+			[]interface{}{`["a","b"]`, `["Tacoma"]`},
+			nil,
+			nil,
+		},
+		{
+			"tags has 'a' or not tags has 'b'",
+			"((entities.tags ? ?) OR NOT(entities.tags ? ?))",
+			[]interface{}{jsonbOperator("?"), "a", jsonbOperator("?"), "b"},
+			nil,
+			nil,
+		},
+		{
+			"tags in ['a', 'b']",
+			"(entities.tags ? ?)",
+			[]interface{}{jsonbOperator("?|"), pq.StringArray{"a", "b"}},
+			nil,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		gorm, args, assoc, err := FilterStringToGorm(context.Background(), test.rest, &Entity{}, &EntityProto{})
+		assert.Equal(t, test.gorm, gorm)
+		assert.Equal(t, test.args, args)
+		assert.Equal(t, test.assoc, assoc)
+		assert.IsType(t, test.err, err)
+	}
+}
+
+func TestGormFilteringContainsType(t *testing.T) {
+	for _, filter := range []string{"field_string contains 'a'", "not field1 has '1'"} {
+		_, _, _, err := FilterStringToGorm(context.Background(), filter, &Entity{}, &EntityProto{})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code(), filter)
+		if assert.Len(t, st.Details(), 1, filter) {
+			assert.Len(t, st.Details()[0].(*errfields.FieldInfo).GetFields(), 1, filter)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// associationJoin is a join of a table required to resolve an association.
+type associationJoin struct {
+	table      string
+	alias      string
+	sourceKeys []string
+	targetKeys []string
+	// schema of the joined model, nil for join tables of many to many associations
+	schema *schema.Schema
+}
+
+// JoinInfo extracts the following information for assoc association of obj:
+// - association table name
+// - source join keys
+// - target join keys
+// Join keys are resolved from GORM schema of obj, i.e. from `gorm:"foreignKey;references"`
+// tags or GORM naming conventions. assoc may be a path of nested associations, e.g. "Owner.Org",
+// then source keys refer to the alias of the preceding association.
+// Many to many associations require two joins and are supported only by JoinAssociations,
+// polymorphic associations are not supported.
+func JoinInfo(ctx context.Context, obj interface{}, assoc string) (string, []string, []string, error) {
+	joins, err := associationJoins(obj, assoc)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if len(joins) != 1 {
+		return "", nil, nil, fmt.Errorf("many to many association %s cannot be joined with a single join", assoc)
+	}
+	return joins[0].table, joins[0].sourceKeys, joins[0].targetKeys, nil
+}
+
+// associationJoins returns joins of the last association of assoc association path of obj,
+// the preceding associations of the path are expected to be joined separately.
+func associationJoins(obj interface{}, assoc string) ([]associationJoin, error) {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return nil, err
+	}
+	source := sch.Table
+	path := strings.Split(assoc, ".")
+	for i, name := range path {
+		rel, ok := sch.Relationships.Relations[name]
+		if !ok {
+			return nil, fmt.Errorf("Cannot find association %s in %s", name, sch.Name)
+		}
+		if rel.Polymorphic != nil {
+			return nil, fmt.Errorf("%s: %s association %s cannot be joined", sch.Name, rel.Type, name)
+		}
+		alias := associationAlias(strings.Join(path[:i+1], "."))
+		if i < len(path)-1 {
+			sch, source = rel.FieldSchema, alias
+			continue
+		}
+		if rel.JoinTable != nil {
+			return many2manyJoins(rel, source, alias), nil
+		}
+		join := associationJoin{table: rel.FieldSchema.Table, alias: alias, schema: rel.FieldSchema}
+		for _, ref := range rel.References {
+			if ref.OwnPrimaryKey {
+				join.sourceKeys = append(join.sourceKeys, source+"."+ref.PrimaryKey.DBName)
+				join.targetKeys = append(join.targetKeys, alias+"."+ref.ForeignKey.DBName)
+			} else {
+				join.sourceKeys = append(join.sourceKeys, source+"."+ref.ForeignKey.DBName)
+				join.targetKeys = append(join.targetKeys, alias+"."+ref.PrimaryKey.DBName)
+			}
+		}
+		return []associationJoin{join}, nil
+	}
+	return nil, &EmptyFieldPathError{}
+}
+
+// many2manyJoins returns joins of the join table and the table of many to many association rel,
+// the join table is aliased by alias of the association followed by the name of the join table.
+func many2manyJoins(rel *schema.Relationship, source, alias string) []associationJoin {
+	joinTable := associationJoin{table: rel.JoinTable.Table, alias: alias + "__" + rel.JoinTable.Table}
+	join := associationJoin{table: rel.FieldSchema.Table, alias: alias, schema: rel.FieldSchema}
+	for _, ref := range rel.References {
+		if ref.OwnPrimaryKey {
+			joinTable.sourceKeys = append(joinTable.sourceKeys, source+"."+ref.PrimaryKey.DBName)
+			joinTable.targetKeys = append(joinTable.targetKeys, joinTable.alias+"."+ref.ForeignKey.DBName)
+		} else {
+			join.sourceKeys = append(join.sourceKeys, joinTable.alias+"."+ref.ForeignKey.DBName)
+			join.targetKeys = append(join.targetKeys, alias+"."+ref.PrimaryKey.DBName)
+		}
+	}
+	return []associationJoin{joinTable, join}
+}
+
+// JoinAssociations joins obj's associations from assoc to the current gorm query.
+// Associations may be paths of nested associations, e.g. "Owner.Org", every association
+// on a path is joined once and aliased by its path, e.g. owner and owner__org,
+// so the same table can be joined by several associations.
+// Associations are joined in alphabetical order, so the resulting query is stable.
+// Soft deleted records of associations are not joined unless db is unscoped,
+// records of other tenants are not joined if db is scoped to a tenant, see WithTenancy.
+// Joining has many and many to many associations duplicates records of obj's model,
+// ApplyFilteringEx matches them by a subquery instead.
+func JoinAssociations(ctx context.Context, db *gorm.DB, assoc map[string]struct{}, obj interface{}) (*gorm.DB, error) {
+	joins, args, err := associationJoinClauses(db, assoc, obj)
+	if err != nil {
+		return nil, err
+	}
+	for i, join := range joins {
+		db = db.Joins(join, args[i]...)
+	}
+	return db, nil
+}
+
+// associationJoinClauses returns JOIN clauses of obj's associations from assoc along with their arguments,
+// see JoinAssociations.
+func associationJoinClauses(db *gorm.DB, assoc map[string]struct{}, obj interface{}) ([]string, [][]interface{}, error) {
+	var clauses []string
+	var clauseArgs [][]interface{}
+	for _, k := range associationPaths(assoc) {
+		joins, err := associationJoins(obj, k)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, j := range joins {
+			var keyPairs []string
+			for i, k := range j.sourceKeys {
+				keyPairs = append(keyPairs, k+" = "+j.targetKeys[i])
+			}
+			var args []interface{}
+			if j.schema != nil {
+				if cond := softDeleteCondition(j.schema, j.alias); cond != "" && !db.Statement.Unscoped {
+					keyPairs = append(keyPairs, cond)
+				}
+				var cond string
+				cond, args = tenantCondition(db, j.schema, j.alias)
+				if cond != "" {
+					keyPairs = append(keyPairs, cond)
+				}
+			}
+			clauses = append(clauses, fmt.Sprintf("LEFT JOIN %s %s ON %s", j.table, j.alias, strings.Join(keyPairs, " AND ")))
+			clauseArgs = append(clauseArgs, args)
+		}
+	}
+	return clauses, clauseArgs, nil
+}
+
+// associationSubquery returns the condition that matches records of obj's model by primary key
+// against a subquery, which joins associations from assoc and applies filtering condition str,
+// so records are not duplicated by joined has many and many to many associations.
+func associationSubquery(db *gorm.DB, assoc map[string]struct{}, obj interface{}, str string, args []interface{}) (string, []interface{}, error) {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return "", nil, err
+	}
+	keys := primaryKeyTags(obj)
+	if len(keys) == 0 {
+		return "", nil, fmt.Errorf("%s has no primary key to filter by to many associations", sch.Name)
+	}
+	for i, k := range keys {
+		keys[i] = sch.Table + "." + k
+	}
+	joins, joinArgs, err := associationJoinClauses(db, assoc, obj)
+	if err != nil {
+		return "", nil, err
+	}
+	var subArgs []interface{}
+	for _, a := range joinArgs {
+		subArgs = append(subArgs, a...)
+	}
+	key := strings.Join(keys, ", ")
+	if len(keys) > 1 {
+		key = "(" + key + ")"
+	}
+	cond := fmt.Sprintf("%s IN (SELECT %s FROM %s %s WHERE %s)", key, strings.Join(keys, ", "), sch.Table, strings.Join(joins, " "), str)
+	return cond, append(subArgs, args...), nil
+}
+
+// isToManyAssociation reports whether assoc association path of obj contains a has many or
+// many to many association, joining which duplicates records of obj's model.
+func isToManyAssociation(obj interface{}, assoc string) bool {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return false
+	}
+	for _, name := range strings.Split(assoc, ".") {
+		rel, ok := sch.Relationships.Relations[name]
+		if !ok {
+			return false
+		}
+		if rel.Type == schema.HasMany || rel.Type == schema.Many2Many {
+			return true
+		}
+		sch = rel.FieldSchema
+	}
+	return false
+}
+
+// associationPaths returns sorted association paths of assoc along with all their prefixes,
+// so every association is joined once and after the association it is nested in.
+func associationPaths(assoc map[string]struct{}) []string {
+	paths := make(map[string]struct{})
+	for k := range assoc {
+		path := strings.Split(k, ".")
+		for i := range path {
+			paths[strings.Join(path[:i+1], ".")] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(paths))
+	for k := range paths {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
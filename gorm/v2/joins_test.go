@@ -0,0 +1,165 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/query"
+)
+
+type JoinsModel struct {
+	ID         string
+	ParentName string
+	OwnerID    string
+	CreatorID  string
+	Child      JoinsChild  `gorm:"foreignKey:ModelID;references:ID"`
+	Parent     JoinsParent `gorm:"foreignKey:ParentName;references:Name"`
+	Tags       []JoinsTag  `gorm:"many2many:joins_model_tags"`
+	Notes      []JoinsNote `gorm:"foreignKey:ModelID"`
+	Owner      JoinsUser
+	Creator    JoinsUser
+}
+
+type JoinsChild struct {
+	ID      string
+	ModelID string
+}
+
+type JoinsParent struct {
+	ID   string
+	Name string
+}
+
+type JoinsTag struct {
+	ID   string
+	Name string
+}
+
+type JoinsNote struct {
+	ID      string
+	ModelID string
+	Text    string
+}
+
+type JoinsUser struct {
+	ID    string
+	OrgID string
+	Org   JoinsOrg
+}
+
+type JoinsOrg struct {
+	ID   string
+	Name string
+}
+
+func TestJoinInfo(t *testing.T) {
+	tests := []struct {
+		assoc      string
+		tableName  string
+		sourceKeys []string
+		targetKeys []string
+	}{
+		{
+			"Child",
+			"joins_children",
+			[]string{"joins_models.id"},
+			[]string{"child.model_id"},
+		},
+		{
+			"Parent",
+			"joins_parents",
+			[]string{"joins_models.parent_name"},
+			[]string{"parent.name"},
+		},
+		{
+			"Owner.Org",
+			"joins_orgs",
+			[]string{"owner.org_id"},
+			[]string{"owner__org.id"},
+		},
+	}
+	for _, test := range tests {
+		tableName, sourceKeys, targetKeys, err := JoinInfo(context.Background(), &JoinsModel{}, test.assoc)
+		assert.Equal(t, test.tableName, tableName)
+		assert.Equal(t, test.sourceKeys, sourceKeys)
+		assert.Equal(t, test.targetKeys, targetKeys)
+		assert.Nil(t, err)
+	}
+
+	_, _, _, err := JoinInfo(context.Background(), &JoinsModel{}, "Tags")
+	assert.Error(t, err)
+	_, _, _, err = JoinInfo(context.Background(), &JoinsModel{}, "Unknown")
+	assert.Error(t, err)
+}
+
+func TestJoinAssociations(t *testing.T) {
+	ctx := context.Background()
+	f, err := query.ParseFiltering("owner.org.name == 'acme' and creator.org.name == 'acme' and tags.name == 'urgent'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := query.ParseSorting("owner.org.name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gormDB, mock := setUp(t)
+	gormDB, err = ApplyCollectionOperators(ctx, gormDB, &JoinsModel{}, &PersonProto{}, f, s, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT "joins_models"."id","joins_models"."parent_name","joins_models"."owner_id","joins_models"."creator_id" FROM "joins_models" `+
+		`LEFT JOIN joins_users owner ON joins_models.owner_id = owner.id LEFT JOIN joins_orgs owner__org ON owner.org_id = owner__org.id `+
+		`WHERE joins_models.id IN (SELECT joins_models.id FROM joins_models `+
+		`LEFT JOIN joins_users creator ON joins_models.creator_id = creator.id LEFT JOIN joins_orgs creator__org ON creator.org_id = creator__org.id `+
+		`LEFT JOIN joins_users owner ON joins_models.owner_id = owner.id LEFT JOIN joins_orgs owner__org ON owner.org_id = owner__org.id `+
+		`LEFT JOIN joins_model_tags tags__joins_model_tags ON joins_models.id = tags__joins_model_tags.joins_model_id `+
+		`LEFT JOIN joins_tags tags ON tags__joins_model_tags.joins_tag_id = tags.id `+
+		`WHERE (((owner__org.name = $1) AND (creator__org.name = $2)) AND (tags.name = $3))) ORDER BY owner__org.name`)).
+		WithArgs("acme", "acme", "urgent").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	var actual []JoinsModel
+	assert.NoError(t, gormDB.Find(&actual).Error)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestJoinToManyAssociations(t *testing.T) {
+	ctx := context.Background()
+	// both notes of the model match, the model is selected once and counts once toward the limit
+	f, err := query.ParseFiltering("notes.text ~ 'todo' and parent.name == 'acme'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gormDB, mock := setUp(t)
+	gormDB, err = ApplyCollectionOperators(ctx, gormDB, &JoinsModel{}, &PersonProto{}, f, nil, &query.Pagination{Limit: 2}, query.ParseFieldSelection("id"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "joins_models" `+
+		`WHERE joins_models.id IN (SELECT joins_models.id FROM joins_models `+
+		`LEFT JOIN joins_notes notes ON joins_models.id = notes.model_id `+
+		`LEFT JOIN joins_parents parent ON joins_models.parent_name = parent.name `+
+		`WHERE ((notes.text ~ $1) AND (parent.name = $2))) LIMIT $3`)).
+		WithArgs("todo", "acme", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1").AddRow("2"))
+
+	var actual []JoinsModel
+	assert.NoError(t, gormDB.Find(&actual).Error)
+	assert.Len(t, actual, 2)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+
+	s, err := query.ParseSorting("notes.text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ApplyCollectionOperators(ctx, gormDB, &JoinsModel{}, &PersonProto{}, nil, s, nil, nil)
+	assert.EqualError(t, err, "Cannot sort by to many association Notes")
+}
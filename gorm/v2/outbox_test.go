@@ -0,0 +1,167 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type fakePublisher struct {
+	published []*OutboxEvent
+	failOn    int64
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, event *OutboxEvent) error {
+	if event.ID == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+func TestEnqueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^INSERT INTO "outbox_events" \("topic","key","payload","created_at"\) VALUES \(\$1,\$2,\$3,\$4\) RETURNING "id"`).
+		WithArgs("contacts", "1", []byte(`{"id":1}`), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	mock.ExpectCommit()
+
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	event := &OutboxEvent{Topic: "contacts", Key: "1", Payload: []byte(`{"id":1}`)}
+	interceptor := UnaryServerInterceptor(gdb)
+	_, err = interceptor(context.Background(), nil, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, Enqueue(ctx, event)
+	})
+	if err != nil {
+		t.Errorf("unexpected error - %s", err)
+	}
+	if event.ID != 10 {
+		t.Errorf("unexpected event id %d", event.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to enqueue event - %s", err)
+	}
+
+	if err := Enqueue(context.Background(), event); err != ErrCtxTxnMissing {
+		t.Errorf("unexpected error %v, expected %v", err, ErrCtxTxnMissing)
+	}
+}
+
+func TestRelay_Drain(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	publisher := &fakePublisher{failOn: 3}
+	relay := NewRelay(gdb, publisher, WithRelayBatchSize(3))
+
+	// publishing fails on the third event, the first two are deleted
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT pg_try_advisory_xact_lock\(\$1\)`).
+		WithArgs(relayLockKey).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectQuery(`^SELECT \* FROM "outbox_events" ORDER BY id LIMIT \$1$`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic"}).AddRow(1, "a").AddRow(2, "b").AddRow(3, "c"))
+	mock.ExpectExec(`^DELETE FROM "outbox_events" WHERE "outbox_events"."id" IN \(\$1,\$2\)`).
+		WithArgs(1, 2).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	n, err := relay.Drain(context.Background())
+	if err == nil || n != 2 {
+		t.Errorf("unexpected error %v and number of published events %d", err, n)
+	}
+
+	// the failed event is published again
+	publisher.failOn = 0
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT pg_try_advisory_xact_lock\(\$1\)`).
+		WithArgs(relayLockKey).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectQuery(`^SELECT \* FROM "outbox_events" ORDER BY id LIMIT \$1$`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic"}).AddRow(3, "c"))
+	mock.ExpectExec(`^DELETE FROM "outbox_events" WHERE "outbox_events"."id" = \$1`).
+		WithArgs(3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	n, err = relay.Drain(context.Background())
+	if err != nil || n != 1 {
+		t.Errorf("unexpected error %v and number of published events %d", err, n)
+	}
+
+	// another relay is draining the outbox
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT pg_try_advisory_xact_lock\(\$1\)`).
+		WithArgs(relayLockKey).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+	mock.ExpectCommit()
+	n, err = relay.Drain(context.Background())
+	if err != nil || n != 0 {
+		t.Errorf("unexpected error %v and number of published events %d", err, n)
+	}
+
+	var topics []string
+	for _, e := range publisher.published {
+		topics = append(topics, e.Topic)
+	}
+	if len(topics) != 3 || topics[0] != "a" || topics[1] != "b" || topics[2] != "c" {
+		t.Errorf("unexpected published events %v", topics)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to drain outbox - %s", err)
+	}
+}
+
+func TestRelay_Run(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create sqlmock - %s", err)
+	}
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db - %s", err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`^SELECT pg_try_advisory_xact_lock\(\$1\)`).
+		WithArgs(relayLockKey).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectQuery(`^SELECT \* FROM "outbox_events"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic"}).AddRow(1, "a"))
+	mock.ExpectExec(`^DELETE FROM "outbox_events"`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	publisher := &fakePublisher{}
+	var errs []error
+	relay := NewRelay(gdb, publisher, WithRelayInterval(time.Hour), WithRelayErrorHandler(func(err error) {
+		errs = append(errs, err)
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := relay.Run(ctx); err != context.DeadlineExceeded {
+		t.Errorf("unexpected error %v", err)
+	}
+	if len(publisher.published) != 1 || len(errs) != 0 {
+		t.Errorf("unexpected published events %v and errors %v", publisher.published, errs)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to run relay - %s", err)
+	}
+}
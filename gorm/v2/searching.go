@@ -0,0 +1,74 @@
+package v2
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// GetFullTextSearchDBMask returns the document of full-text search built from columns of fields of object
+// joined by separator. Strings, numbers, booleans, timestamps and arrays of strings are supported,
+// fields of other types are skipped. Nullable columns are replaced by empty strings if they are null.
+func GetFullTextSearchDBMask(object interface{}, fields []string, separator string) string {
+	objectType := indirectType(reflect.TypeOf(object))
+	if objectType.Kind() != reflect.Struct {
+		return ""
+	}
+	var parts []string
+	for _, fieldName := range fields {
+		sf, ok := objectType.FieldByName(camelCase(fieldName))
+		if !ok {
+			continue
+		}
+		fieldType, nullable := sf.Type, false
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType, nullable = fieldType.Elem(), true
+		}
+		column := fieldName
+		if nullable {
+			column = "coalesce(" + fieldName + "::text, '')"
+		}
+		switch {
+		case fieldType == reflect.TypeOf(time.Time{}):
+			parts = append(parts, "coalesce(to_char("+fieldName+", 'MM/DD/YY HH:MI pm'), '')")
+		case fieldType.Kind() == reflect.String:
+			parts = append(parts, column+" || '"+separator+"' || "+
+				"replace("+column+", '@', ' ')"+" || '"+separator+"' || "+
+				"replace("+column+", '.', ' ')")
+		case fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.String:
+			parts = append(parts, "coalesce(array_to_string("+fieldName+", '"+separator+"'), '')")
+		case fieldType.Kind() == reflect.Bool,
+			fieldType.Kind() >= reflect.Int && fieldType.Kind() <= reflect.Float64:
+			parts = append(parts, column)
+		}
+	}
+	return strings.Join(parts, " || '"+separator+"' || ")
+}
+
+// FormFullTextSearchQuery returns the condition that matches mask against the search query converted by SearchQuery.
+func FormFullTextSearchQuery(mask string) string {
+	fullTextSearchQuery := "to_tsvector('simple', " + mask + ") @@ to_tsquery('simple', ?)"
+	return fullTextSearchQuery
+}
+
+// SearchQuery converts search query q to the argument of to_tsquery that matches records
+// containing all words of q, the last one as a prefix, e.g. "john smi" is converted to "john & smi:*",
+// so results are narrowed as the query is typed. Queries containing tsquery operators match nothing.
+func SearchQuery(q string) string {
+	q = strings.ReplaceAll(strings.TrimSpace(q), ":", " ")
+	for _, spl := range []string{"(", ")", "|", "+", "<", "'", "&", "!", "%", ";"} {
+		if strings.Contains(q, spl) {
+			return ""
+		}
+	}
+	words := strings.Fields(q)
+	if len(words) == 0 {
+		return ""
+	}
+	return strings.Join(words, " & ") + ":*"
+}
+
+// quoteLiteral returns s quoted as a SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
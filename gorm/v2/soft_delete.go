@@ -0,0 +1,50 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// PurgeDeleted permanently deletes records of obj's model that were soft deleted
+// more than retention ago and returns the number of purged records.
+// An error is returned if obj's model does not support soft delete, i.e. has no gorm.DeletedAt field.
+func PurgeDeleted(ctx context.Context, db *gorm.DB, obj interface{}, retention time.Duration) (int64, error) {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return 0, err
+	}
+	field := softDeleteField(sch)
+	if field == nil {
+		return 0, fmt.Errorf("%s does not support soft delete", sch.Name)
+	}
+	res := db.WithContext(ctx).Unscoped().Where(clause.Lt{
+		Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName},
+		Value:  time.Now().Add(-retention),
+	}).Delete(obj)
+	return res.RowsAffected, res.Error
+}
+
+// softDeleteCondition returns the condition that excludes soft deleted records of
+// sch model joined as alias, or an empty string if the model does not support soft delete.
+func softDeleteCondition(sch *schema.Schema, alias string) string {
+	field := softDeleteField(sch)
+	if field == nil {
+		return ""
+	}
+	return alias + "." + field.DBName + " IS NULL"
+}
+
+// softDeleteField returns gorm.DeletedAt field of sch or nil if sch does not support soft delete.
+func softDeleteField(sch *schema.Schema) *schema.Field {
+	for _, c := range sch.QueryClauses {
+		if sd, ok := c.(gorm.SoftDeleteQueryClause); ok {
+			return sd.Field
+		}
+	}
+	return nil
+}
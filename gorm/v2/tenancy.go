@@ -0,0 +1,159 @@
+package v2
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/auth"
+)
+
+// tenantSetting is the name of the gorm setting that carries the *Tenant of a transaction.
+const tenantSetting = "atlas:tenant"
+
+// tenancyCallback is the name of gorm callbacks that scope statements to the tenant.
+const tenancyCallback = "atlas:tenancy"
+
+// ErrTenantMissing is returned by transactions of requests that have no tenant, see WithTenancy.
+var ErrTenantMissing = status.Error(codes.Unauthenticated, "tenant for request missing in context")
+
+// Tenant is the account and, optionally, the compartment the data of a request belongs to.
+// Models are scoped to the tenant by auth.MultiTenancyField and auth.MultiCompartmentField columns.
+type Tenant struct {
+	AccountID     string
+	CompartmentID string
+}
+
+// TenantFromContext returns the tenant of the authorization token of ctx, see auth.GetAccountID
+// and auth.GetCompartmentID. The token is expected to be verified earlier in the interceptor chain.
+// ErrTenantMissing is returned if the token has no account ID.
+func TenantFromContext(ctx context.Context) (*Tenant, error) {
+	accountID, err := auth.GetAccountID(ctx, nil)
+	if err != nil || accountID == "" {
+		return nil, ErrTenantMissing
+	}
+	compartmentID, err := auth.GetCompartmentID(ctx, nil)
+	if err != nil {
+		return nil, ErrTenantMissing
+	}
+	return &Tenant{AccountID: accountID, CompartmentID: compartmentID}, nil
+}
+
+// WithTenancy makes transaction interceptors scope the data of requests to the tenant returned by tenant,
+// TenantFromContext is used if tenant is nil.
+// Queries, updates and deletes done through the transaction of a request are restricted to the rows of the tenant,
+// as well as associations joined by JoinAssociations, and created rows are assigned to the tenant.
+// Only models that have the tenant columns are scoped, raw SQL is never scoped.
+// If a request has no tenant, its transaction fails to begin with the error returned by tenant.
+func WithTenancy(tenant func(ctx context.Context) (*Tenant, error)) InterceptorOption {
+	return func(o *interceptorOptions) {
+		if tenant == nil {
+			tenant = TenantFromContext
+		}
+		o.tenant = tenant
+	}
+}
+
+// tenantFromDB returns the tenant gorm instance db is scoped to, if any.
+func tenantFromDB(db *gorm.DB) (*Tenant, bool) {
+	v, ok := db.Get(tenantSetting)
+	if !ok {
+		return nil, false
+	}
+	tenant, ok := v.(*Tenant)
+	return tenant, ok && tenant != nil
+}
+
+// columns returns the tenant columns and their values.
+func (t *Tenant) columns() ([]string, []interface{}) {
+	if t.CompartmentID == "" {
+		return []string{auth.MultiTenancyField}, []interface{}{t.AccountID}
+	}
+	return []string{auth.MultiTenancyField, auth.MultiCompartmentField}, []interface{}{t.AccountID, t.CompartmentID}
+}
+
+// tenantCondition returns the condition that restricts sch model joined as alias to the tenant of db,
+// or an empty string if db is not scoped or the model has no tenant columns.
+func tenantCondition(db *gorm.DB, sch *schema.Schema, alias string) (string, []interface{}) {
+	tenant, ok := tenantFromDB(db)
+	if !ok {
+		return "", nil
+	}
+	var conds []string
+	var args []interface{}
+	cols, vals := tenant.columns()
+	for i, col := range cols {
+		if field := sch.LookUpField(col); field != nil {
+			conds = append(conds, alias+"."+field.DBName+" = ?")
+			args = append(args, vals[i])
+		}
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// registerTenancyCallbacks registers callbacks that scope statements of db to the tenant of the transaction.
+func registerTenancyCallbacks(db *gorm.DB) error {
+	if db == nil || db.Callback().Query().Get(tenancyCallback) != nil {
+		return nil
+	}
+	if err := db.Callback().Create().Before("gorm:create").Register(tenancyCallback, tenancyAssignCallback); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register(tenancyCallback, tenancyScopeCallback); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register(tenancyCallback, tenancyScopeCallback); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register(tenancyCallback, func(db *gorm.DB) {
+		tenancyAssignCallback(db)
+		tenancyScopeCallback(db)
+	}); err != nil {
+		return err
+	}
+	return db.Callback().Delete().Before("gorm:delete").Register(tenancyCallback, tenancyScopeCallback)
+}
+
+// tenancyScopeCallback restricts the statement of db to the rows of the tenant.
+func tenancyScopeCallback(db *gorm.DB) {
+	tenant, ok := tenantFromStatement(db)
+	if !ok {
+		return
+	}
+	var exprs []clause.Expression
+	cols, vals := tenant.columns()
+	for i, col := range cols {
+		if field := db.Statement.Schema.LookUpField(col); field != nil {
+			exprs = append(exprs, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: vals[i]})
+		}
+	}
+	if len(exprs) > 0 {
+		db.Statement.AddClause(clause.Where{Exprs: exprs})
+	}
+}
+
+// tenancyAssignCallback assigns the created or updated rows of db to the tenant.
+func tenancyAssignCallback(db *gorm.DB) {
+	tenant, ok := tenantFromStatement(db)
+	if !ok {
+		return
+	}
+	cols, vals := tenant.columns()
+	for i, col := range cols {
+		if field := db.Statement.Schema.LookUpField(col); field != nil {
+			db.Statement.SetColumn(field.DBName, vals[i], true)
+		}
+	}
+}
+
+func tenantFromStatement(db *gorm.DB) (*Tenant, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, false
+	}
+	return tenantFromDB(db)
+}
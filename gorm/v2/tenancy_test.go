@@ -0,0 +1,127 @@
+package v2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	jwt "github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Task struct {
+	ID        int64
+	Title     string
+	AccountID string
+	ProjectID int64
+	Project   Project
+}
+
+type Project struct {
+	ID        int64
+	Name      string
+	AccountID string
+}
+
+func TestWithTenancy(t *testing.T) {
+	gormDB, mock := setUp(t)
+	tenant := func(ctx context.Context) (*Tenant, error) {
+		return &Tenant{AccountID: "acc1"}, nil
+	}
+	interceptor := UnaryServerInterceptor(gormDB, WithTenancy(tenant))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(fixedFullRe(`INSERT INTO "tasks" ("title","account_id","project_id") VALUES ($1,$2,$3) RETURNING "id"`)).
+		WithArgs("write tests", "acc1", 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(fixedFullRe(`SELECT "tasks"."id","tasks"."title","tasks"."account_id","tasks"."project_id" FROM "tasks" LEFT JOIN projects project ON tasks.project_id = project.id AND project.account_id = $1 WHERE project.name = $2 AND "tasks"."account_id" = $3`)).
+		WithArgs("acc1", "toolkit", "acc1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow(1, "write tests"))
+	mock.ExpectExec(fixedFullRe(`UPDATE "tasks" SET "account_id"=$1,"title"=$2 WHERE "tasks"."account_id" = $3 AND "id" = $4`)).
+		WithArgs("acc1", "write more tests", "acc1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(fixedFullRe(`DELETE FROM "tasks" WHERE "tasks"."account_id" = $1 AND "tasks"."id" = $2`)).
+		WithArgs("acc1", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	_, err := interceptor(context.Background(), nil, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+		db, err := BeginFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		task := &Task{Title: "write tests", AccountID: "acc2"}
+		if err := db.Create(task).Error; err != nil {
+			return nil, err
+		}
+		if task.AccountID != "acc1" {
+			t.Errorf("unexpected account of created task %s", task.AccountID)
+		}
+		joined, err := JoinAssociations(ctx, db.Where("project.name = ?", "toolkit"), map[string]struct{}{"Project": {}}, &Task{})
+		if err != nil {
+			return nil, err
+		}
+		var tasks []Task
+		if err := joined.Find(&tasks).Error; err != nil {
+			return nil, err
+		}
+		if err := db.Model(task).Update("title", "write more tests").Error; err != nil {
+			return nil, err
+		}
+		return nil, db.Delete(task).Error
+	})
+	if err != nil {
+		t.Errorf("unexpected error - %s", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("failed to scope statements to the tenant - %s", err)
+	}
+
+	// statements outside of the interceptor are not scoped
+	mock.ExpectQuery(fixedFullRe(`SELECT * FROM "tasks"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	var tasks []Task
+	gormDB.Find(&tasks)
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected query - %s", err)
+	}
+}
+
+func TestWithTenancy_missing(t *testing.T) {
+	gormDB, mock := setUp(t)
+	interceptor := UnaryServerInterceptor(gormDB, WithTenancy(nil))
+	_, err := interceptor(context.Background(), nil, nil, func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, err := BeginFromContext(ctx)
+		return nil, err
+	})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("unexpected error %v, expected Unauthenticated", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected query - %s", err)
+	}
+}
+
+func TestTenantFromContext(t *testing.T) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"account_id":     "acc1",
+		"compartment_id": "cmp1",
+	}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	tenant, err := TenantFromContext(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error - %s", err)
+	}
+	if tenant.AccountID != "acc1" || tenant.CompartmentID != "cmp1" {
+		t.Errorf("unexpected tenant %+v", tenant)
+	}
+
+	if _, err := TenantFromContext(context.Background()); err != ErrTenantMissing {
+		t.Errorf("unexpected error %v, expected %v", err, ErrTenantMissing)
+	}
+}
@@ -0,0 +1,284 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/lib/pq"
+	"gorm.io/gorm/schema"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/resource"
+	"github.com/infobloxopen/atlas-app-toolkit/v2/util"
+)
+
+// schemaCache caches GORM schemas of models parsed by the package.
+var schemaCache = &sync.Map{}
+
+// namer resolves table and column names of models that do not declare them explicitly.
+var namer = schema.NamingStrategy{}
+
+// parseSchema returns GORM schema of obj, table and column names are resolved
+// the same way as with the default gorm.Config.
+func parseSchema(obj interface{}) (*schema.Schema, error) {
+	return schema.Parse(obj, schemaCache, namer)
+}
+
+// HandleFieldPath converts fieldPath to appropriate db string for use in where/order by clauses
+// according to obj GORM model. If fieldPath cannot be found in obj then original fieldPath is returned
+// to allow tables joined by a third party.
+// If association join is required to resolve the field path then it's name is returned as a second return value.
+func HandleFieldPath(ctx context.Context, fieldPath []string, obj interface{}) (string, string, error) {
+	dbPath, err := fieldPathToDBName(fieldPath, obj)
+	if err != nil {
+		switch err.(type) {
+		case *EmptyFieldPathError:
+			return "", "", err
+		default:
+			return strings.Join(fieldPath, "."), "", nil
+		}
+	}
+	if len(fieldPath) > 1 {
+		return dbPath, associationPath(fieldPath[:len(fieldPath)-1]), nil
+	}
+	return dbPath, "", nil
+}
+
+// HandleJSONFieldPath translates field path to JSONB path for postgres jsonb
+func HandleJSONFieldPath(ctx context.Context, fieldPath []string, obj interface{}, values ...string) (string, string, error) {
+	operator := "#>>"
+	if isRawJSON(values...) {
+		operator = "#>"
+	}
+	return handleJSONFieldPath(fieldPath, obj, operator)
+}
+
+// handleJSONFieldPath translates field path to JSONB path that extracts nested values with operator.
+func handleJSONFieldPath(fieldPath []string, obj interface{}, operator string) (string, string, error) {
+	dbPath, err := fieldPathToDBName(fieldPath[:1], obj)
+	if err != nil {
+		switch err.(type) {
+		case *EmptyFieldPathError:
+			return "", "", err
+		default:
+			dbPath = fieldPath[0]
+		}
+	}
+
+	if len(fieldPath) == 1 {
+		return dbPath, "", nil
+	}
+
+	return fmt.Sprintf("%s %s '{%s}'", dbPath, operator, strings.Join(fieldPath[1:], ",")), "", nil
+}
+
+func isRawJSON(values ...string) bool {
+	if len(values) == 0 {
+		return false
+	}
+
+	for _, v := range values {
+		//TODO: this is a very poor check to prevent unexpected errors from Database engine consider to make full validation
+		//TODO: also we need return an error if json invalid to prevent database error for json parsing
+		v = strings.TrimSpace(v)
+		if !strings.HasPrefix(v, "{") || !strings.HasSuffix(v, "}") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsJSONCondition reports whether fieldPath refers to a json or jsonb field of obj,
+// e.g. a field of datatypes.JSON type or a field tagged with `gorm:"type:jsonb"`.
+func IsJSONCondition(ctx context.Context, fieldPath []string, obj interface{}) bool {
+	f := lookUpField(obj, fieldPath[0])
+	if f == nil {
+		return false
+	}
+	switch strings.ToLower(string(f.DataType)) {
+	case "json", "jsonb":
+		return true
+	}
+	return false
+}
+
+// IsArrayCondition reports whether fieldPath refers to a postgres array field of obj,
+// e.g. a field of pq.StringArray type or a field tagged with `gorm:"type:text[]"`.
+func IsArrayCondition(ctx context.Context, fieldPath []string, obj interface{}) bool {
+	if len(fieldPath) != 1 {
+		return false
+	}
+	f := lookUpField(obj, fieldPath[0])
+	if f == nil {
+		return false
+	}
+	if strings.HasSuffix(string(f.DataType), "[]") {
+		return true
+	}
+	switch reflect.Zero(f.IndirectFieldType).Interface().(type) {
+	case pq.StringArray:
+		return true
+	}
+	return false
+}
+
+// isArrayField reports whether fieldPath refers to a postgres array field of obj or of its association.
+func isArrayField(ctx context.Context, fieldPath []string, obj interface{}) bool {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return false
+	}
+	for _, part := range fieldPath[:len(fieldPath)-1] {
+		rel, ok := sch.Relationships.Relations[util.Camel(part)]
+		if !ok {
+			return false
+		}
+		sch = rel.FieldSchema
+	}
+	return IsArrayCondition(ctx, fieldPath[len(fieldPath)-1:], reflect.New(sch.ModelType).Interface())
+}
+
+// lookUpField returns the field of obj referred to by name, nil if there is none.
+func lookUpField(obj interface{}, name string) *schema.Field {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return nil
+	}
+	return schemaField(sch, name)
+}
+
+// schemaField returns the field of sch referred to by name, that is either
+// a snake case or a camel case field name or a column name.
+func schemaField(sch *schema.Schema, name string) *schema.Field {
+	if f, ok := sch.FieldsByName[util.Camel(name)]; ok {
+		return f
+	}
+	return sch.LookUpField(name)
+}
+
+func fieldPathToDBName(fieldPath []string, obj interface{}) (string, error) {
+	sch, err := parseSchema(obj)
+	if err != nil {
+		return "", err
+	}
+	pathLength := len(fieldPath)
+	assocAlias := ""
+	for i, part := range fieldPath {
+		if i < pathLength-1 {
+			rel, ok := sch.Relationships.Relations[util.Camel(part)]
+			if !ok {
+				return "", fmt.Errorf("%s: non-last field of %s field path should be an association", sch.Name, fieldPath)
+			}
+			sch, assocAlias = rel.FieldSchema, associationAlias(associationPath(fieldPath[:i+1]))
+			continue
+		}
+		f := schemaField(sch, part)
+		if f == nil {
+			return "", fmt.Errorf("Cannot find field %s in %s", part, sch.Name)
+		}
+		if f.DBName == "" {
+			return "", fmt.Errorf("%s: last field of %s field path should not be an association", sch.Name, fieldPath)
+		}
+		dbPrefix := sch.Table
+		if assocAlias != "" {
+			dbPrefix = assocAlias
+		}
+		return dbPrefix + "." + f.DBName, nil
+	}
+	return "", &EmptyFieldPathError{}
+}
+
+// associationPath returns the path of associations referred to by fieldPath, e.g. "Owner.Org".
+func associationPath(fieldPath []string) string {
+	path := make([]string, len(fieldPath))
+	for i, part := range fieldPath {
+		path[i] = util.Camel(part)
+	}
+	return strings.Join(path, ".")
+}
+
+// associationAlias returns the alias of the table of assoc association path in joins,
+// e.g. owner__org for "Owner.Org".
+func associationAlias(assoc string) string {
+	path := strings.Split(assoc, ".")
+	for i, name := range path {
+		path[i] = namer.ColumnName("", name)
+	}
+	return strings.Join(path, "__")
+}
+
+func atlasTag(sf *reflect.StructField, tag string) (bool, string) {
+	return extractTag(sf, "atlas", tag)
+}
+
+func extractTag(sf *reflect.StructField, tag string, subTag string) (bool, string) {
+	tags := strings.Split(sf.Tag.Get(tag), ";")
+	for _, t := range tags {
+		var key, value string
+		keyValue := strings.Split(t, ":")
+		switch len(keyValue) {
+		case 2:
+			value = keyValue[1]
+			fallthrough
+		case 1:
+			key = keyValue[0]
+		}
+		if strings.ToLower(key) == strings.ToLower(subTag) {
+			return true, value
+		}
+	}
+	return false, ""
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+func indirectValue(val reflect.Value) reflect.Value {
+	for {
+		switch val.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			val = val.Elem()
+		default:
+			return val
+		}
+	}
+}
+
+func isProtoMessage(t reflect.Type) bool {
+	_, isProtoMessage := reflect.Zero(t).Interface().(proto.Message)
+	return isProtoMessage
+}
+
+func isIdentifier(t reflect.Type) bool {
+	_, isIdentifier := reflect.Zero(t).Interface().(resource.Identifier)
+	return isIdentifier
+}
+
+type EmptyFieldPathError struct {
+}
+
+func (e *EmptyFieldPathError) Error() string {
+	return fmt.Sprintf("Empty field path is not allowed")
+}
+
+func camelCase(v string) string {
+	sp := strings.Split(v, "_")
+	r := make([]string, len(sp))
+	for i, v := range sp {
+		r[i] = strings.ToUpper(v[:1]) + v[1:]
+	}
+
+	return strings.Join(r, "")
+}
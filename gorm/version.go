@@ -1,12 +1,15 @@
 package gorm
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"strconv"
 	"strings"
 
 	jgorm "github.com/jinzhu/gorm"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/gorm/migrate"
 )
 
 // MigrationVersionValidator has a function for checking the database version
@@ -102,3 +105,13 @@ func VerifyMigrationVersion(db *jgorm.DB, v MigrationVersionValidator) error {
 	}
 	return nil
 }
+
+// MigrateUp applies all pending migrations of m to db, see migrate.Migrator
+func MigrateUp(ctx context.Context, db *jgorm.DB, m *migrate.Migrator) error {
+	return m.Up(ctx, db.DB())
+}
+
+// MigrateDown reverts all migrations of m applied to db, see migrate.Migrator
+func MigrateDown(ctx context.Context, db *jgorm.DB, m *migrate.Migrator) error {
+	return m.Down(ctx, db.DB())
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/gorm/migrate"
 )
 
 var (
@@ -206,6 +208,16 @@ func WithMigrateDownFunction(migrateDownFunction func(*sql.DB) error) func(*Post
 	}
 }
 
+// WithMigrations is used to rebuild the test Postgres database with the
+// migrations of m whenever the database is reset with the Reset() function.
+// It sets both the migrateUp and migrateDown functions.
+func WithMigrations(m *migrate.Migrator) func(*PostgresDB) {
+	return func(db *PostgresDB) {
+		db.migrateUpFunction = m.UpFunc()
+		db.migrateDownFunction = m.DownFunc()
+	}
+}
+
 // WithTimeout is used to specify a connection timeout to the database
 func WithTimeout(timeout time.Duration) func(*PostgresDB) {
 	return func(db *PostgresDB) {
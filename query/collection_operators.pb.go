@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.32.0
+// 	protoc-gen-go v1.33.0
 // 	protoc        v3.18.1
 // source: github.com/infobloxopen/atlas-app-toolkit/query/collection_operators.proto
 
@@ -10,6 +10,7 @@ import (
 	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -70,6 +71,59 @@ func (SortCriteria_Order) EnumDescriptor() ([]byte, []int) {
 	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{0, 0}
 }
 
+// Nulls is a position of null values.
+type SortCriteria_Nulls int32
+
+const (
+	// database default position of null values
+	SortCriteria_NULLS_DEFAULT SortCriteria_Nulls = 0
+	// null values go before non-null values
+	SortCriteria_NULLS_FIRST SortCriteria_Nulls = 1
+	// null values go after non-null values
+	SortCriteria_NULLS_LAST SortCriteria_Nulls = 2
+)
+
+// Enum value maps for SortCriteria_Nulls.
+var (
+	SortCriteria_Nulls_name = map[int32]string{
+		0: "NULLS_DEFAULT",
+		1: "NULLS_FIRST",
+		2: "NULLS_LAST",
+	}
+	SortCriteria_Nulls_value = map[string]int32{
+		"NULLS_DEFAULT": 0,
+		"NULLS_FIRST":   1,
+		"NULLS_LAST":    2,
+	}
+)
+
+func (x SortCriteria_Nulls) Enum() *SortCriteria_Nulls {
+	p := new(SortCriteria_Nulls)
+	*p = x
+	return p
+}
+
+func (x SortCriteria_Nulls) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortCriteria_Nulls) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[1].Descriptor()
+}
+
+func (SortCriteria_Nulls) Type() protoreflect.EnumType {
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[1]
+}
+
+func (x SortCriteria_Nulls) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortCriteria_Nulls.Descriptor instead.
+func (SortCriteria_Nulls) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{0, 1}
+}
+
 type LogicalOperator_Type int32
 
 const (
@@ -100,11 +154,11 @@ func (x LogicalOperator_Type) String() string {
 }
 
 func (LogicalOperator_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[1].Descriptor()
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[2].Descriptor()
 }
 
 func (LogicalOperator_Type) Type() protoreflect.EnumType {
-	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[1]
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[2]
 }
 
 func (x LogicalOperator_Type) Number() protoreflect.EnumNumber {
@@ -161,11 +215,11 @@ func (x StringCondition_Type) String() string {
 }
 
 func (StringCondition_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[2].Descriptor()
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[3].Descriptor()
 }
 
 func (StringCondition_Type) Type() protoreflect.EnumType {
-	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[2]
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[3]
 }
 
 func (x StringCondition_Type) Number() protoreflect.EnumNumber {
@@ -216,11 +270,11 @@ func (x NumberCondition_Type) String() string {
 }
 
 func (NumberCondition_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[3].Descriptor()
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[4].Descriptor()
 }
 
 func (NumberCondition_Type) Type() protoreflect.EnumType {
-	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[3]
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[4]
 }
 
 func (x NumberCondition_Type) Number() protoreflect.EnumNumber {
@@ -259,11 +313,11 @@ func (x StringArrayCondition_Type) String() string {
 }
 
 func (StringArrayCondition_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[4].Descriptor()
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[5].Descriptor()
 }
 
 func (StringArrayCondition_Type) Type() protoreflect.EnumType {
-	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[4]
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[5]
 }
 
 func (x StringArrayCondition_Type) Number() protoreflect.EnumNumber {
@@ -302,11 +356,11 @@ func (x NumberArrayCondition_Type) String() string {
 }
 
 func (NumberArrayCondition_Type) Descriptor() protoreflect.EnumDescriptor {
-	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[5].Descriptor()
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[6].Descriptor()
 }
 
 func (NumberArrayCondition_Type) Type() protoreflect.EnumType {
-	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[5]
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[6]
 }
 
 func (x NumberArrayCondition_Type) Number() protoreflect.EnumNumber {
@@ -318,6 +372,150 @@ func (NumberArrayCondition_Type) EnumDescriptor() ([]byte, []int) {
 	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{10, 0}
 }
 
+type TimeCondition_Type int32
+
+const (
+	TimeCondition_EQ TimeCondition_Type = 0
+	TimeCondition_GT TimeCondition_Type = 1
+	TimeCondition_GE TimeCondition_Type = 2
+	TimeCondition_LT TimeCondition_Type = 3
+	TimeCondition_LE TimeCondition_Type = 4
+)
+
+// Enum value maps for TimeCondition_Type.
+var (
+	TimeCondition_Type_name = map[int32]string{
+		0: "EQ",
+		1: "GT",
+		2: "GE",
+		3: "LT",
+		4: "LE",
+	}
+	TimeCondition_Type_value = map[string]int32{
+		"EQ": 0,
+		"GT": 1,
+		"GE": 2,
+		"LT": 3,
+		"LE": 4,
+	}
+)
+
+func (x TimeCondition_Type) Enum() *TimeCondition_Type {
+	p := new(TimeCondition_Type)
+	*p = x
+	return p
+}
+
+func (x TimeCondition_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeCondition_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[7].Descriptor()
+}
+
+func (TimeCondition_Type) Type() protoreflect.EnumType {
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[7]
+}
+
+func (x TimeCondition_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeCondition_Type.Descriptor instead.
+func (TimeCondition_Type) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type BoolCondition_Type int32
+
+const (
+	BoolCondition_EQ BoolCondition_Type = 0
+)
+
+// Enum value maps for BoolCondition_Type.
+var (
+	BoolCondition_Type_name = map[int32]string{
+		0: "EQ",
+	}
+	BoolCondition_Type_value = map[string]int32{
+		"EQ": 0,
+	}
+)
+
+func (x BoolCondition_Type) Enum() *BoolCondition_Type {
+	p := new(BoolCondition_Type)
+	*p = x
+	return p
+}
+
+func (x BoolCondition_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BoolCondition_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[8].Descriptor()
+}
+
+func (BoolCondition_Type) Type() protoreflect.EnumType {
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[8]
+}
+
+func (x BoolCondition_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BoolCondition_Type.Descriptor instead.
+func (BoolCondition_Type) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{12, 0}
+}
+
+type ContainsCondition_Type int32
+
+const (
+	ContainsCondition_CONTAINS ContainsCondition_Type = 0
+	ContainsCondition_HAS      ContainsCondition_Type = 1
+)
+
+// Enum value maps for ContainsCondition_Type.
+var (
+	ContainsCondition_Type_name = map[int32]string{
+		0: "CONTAINS",
+		1: "HAS",
+	}
+	ContainsCondition_Type_value = map[string]int32{
+		"CONTAINS": 0,
+		"HAS":      1,
+	}
+)
+
+func (x ContainsCondition_Type) Enum() *ContainsCondition_Type {
+	p := new(ContainsCondition_Type)
+	*p = x
+	return p
+}
+
+func (x ContainsCondition_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContainsCondition_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[9].Descriptor()
+}
+
+func (ContainsCondition_Type) Type() protoreflect.EnumType {
+	return &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes[9]
+}
+
+func (x ContainsCondition_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContainsCondition_Type.Descriptor instead.
+func (ContainsCondition_Type) EnumDescriptor() ([]byte, []int) {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{13, 0}
+}
+
 // SortCriteria represents sort criteria
 type SortCriteria struct {
 	state         protoimpl.MessageState
@@ -327,6 +525,7 @@ type SortCriteria struct {
 	// Tag is a JSON tag.
 	Tag   string             `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
 	Order SortCriteria_Order `protobuf:"varint,2,opt,name=order,proto3,enum=infoblox.api.SortCriteria_Order" json:"order,omitempty"`
+	Nulls SortCriteria_Nulls `protobuf:"varint,3,opt,name=nulls,proto3,enum=infoblox.api.SortCriteria_Nulls" json:"nulls,omitempty"`
 }
 
 func (x *SortCriteria) Reset() {
@@ -375,6 +574,13 @@ func (x *SortCriteria) GetOrder() SortCriteria_Order {
 	return SortCriteria_ASC
 }
 
+func (x *SortCriteria) GetNulls() SortCriteria_Nulls {
+	if x != nil {
+		return x.Nulls
+	}
+	return SortCriteria_NULLS_DEFAULT
+}
+
 // Sorting represents list of sort criterias.
 type Sorting struct {
 	state         protoimpl.MessageState
@@ -545,7 +751,12 @@ type Filtering struct {
 	//	*Filtering_NullCondition
 	//	*Filtering_StringArrayCondition
 	//	*Filtering_NumberArrayCondition
+	//	*Filtering_TimeCondition
+	//	*Filtering_BoolCondition
+	//	*Filtering_ContainsCondition
 	Root isFiltering_Root `protobuf_oneof:"root"`
+	// include_deleted requests soft deleted resources to be included, they are excluded by default.
+	IncludeDeleted bool `protobuf:"varint,10,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
 }
 
 func (x *Filtering) Reset() {
@@ -629,6 +840,34 @@ func (x *Filtering) GetNumberArrayCondition() *NumberArrayCondition {
 	return nil
 }
 
+func (x *Filtering) GetTimeCondition() *TimeCondition {
+	if x, ok := x.GetRoot().(*Filtering_TimeCondition); ok {
+		return x.TimeCondition
+	}
+	return nil
+}
+
+func (x *Filtering) GetBoolCondition() *BoolCondition {
+	if x, ok := x.GetRoot().(*Filtering_BoolCondition); ok {
+		return x.BoolCondition
+	}
+	return nil
+}
+
+func (x *Filtering) GetContainsCondition() *ContainsCondition {
+	if x, ok := x.GetRoot().(*Filtering_ContainsCondition); ok {
+		return x.ContainsCondition
+	}
+	return nil
+}
+
+func (x *Filtering) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
 type isFiltering_Root interface {
 	isFiltering_Root()
 }
@@ -657,6 +896,18 @@ type Filtering_NumberArrayCondition struct {
 	NumberArrayCondition *NumberArrayCondition `protobuf:"bytes,6,opt,name=number_array_condition,json=numberArrayCondition,proto3,oneof"`
 }
 
+type Filtering_TimeCondition struct {
+	TimeCondition *TimeCondition `protobuf:"bytes,7,opt,name=time_condition,json=timeCondition,proto3,oneof"`
+}
+
+type Filtering_BoolCondition struct {
+	BoolCondition *BoolCondition `protobuf:"bytes,8,opt,name=bool_condition,json=boolCondition,proto3,oneof"`
+}
+
+type Filtering_ContainsCondition struct {
+	ContainsCondition *ContainsCondition `protobuf:"bytes,9,opt,name=contains_condition,json=containsCondition,proto3,oneof"`
+}
+
 func (*Filtering_Operator) isFiltering_Root() {}
 
 func (*Filtering_StringCondition) isFiltering_Root() {}
@@ -669,6 +920,12 @@ func (*Filtering_StringArrayCondition) isFiltering_Root() {}
 
 func (*Filtering_NumberArrayCondition) isFiltering_Root() {}
 
+func (*Filtering_TimeCondition) isFiltering_Root() {}
+
+func (*Filtering_BoolCondition) isFiltering_Root() {}
+
+func (*Filtering_ContainsCondition) isFiltering_Root() {}
+
 // LogicalOperator represents binary logical operator, either AND or OR depending on type.
 // left and right are respectively left and right operands of the operator, could be
 // either LogicalOperator or one of the supported conditions.
@@ -685,6 +942,9 @@ type LogicalOperator struct {
 	//	*LogicalOperator_LeftNullCondition
 	//	*LogicalOperator_LeftStringArrayCondition
 	//	*LogicalOperator_LeftNumberArrayCondition
+	//	*LogicalOperator_LeftTimeCondition
+	//	*LogicalOperator_LeftBoolCondition
+	//	*LogicalOperator_LeftContainsCondition
 	Left isLogicalOperator_Left `protobuf_oneof:"left"`
 	// Types that are assignable to Right:
 	//	*LogicalOperator_RightOperator
@@ -693,6 +953,9 @@ type LogicalOperator struct {
 	//	*LogicalOperator_RightNullCondition
 	//	*LogicalOperator_RightStringArrayCondition
 	//	*LogicalOperator_RightNumberArrayCondition
+	//	*LogicalOperator_RightTimeCondition
+	//	*LogicalOperator_RightBoolCondition
+	//	*LogicalOperator_RightContainsCondition
 	Right      isLogicalOperator_Right `protobuf_oneof:"right"`
 	Type       LogicalOperator_Type    `protobuf:"varint,9,opt,name=type,proto3,enum=infoblox.api.LogicalOperator_Type" json:"type,omitempty"`
 	IsNegative bool                    `protobuf:"varint,10,opt,name=is_negative,json=isNegative,proto3" json:"is_negative,omitempty"`
@@ -779,6 +1042,27 @@ func (x *LogicalOperator) GetLeftNumberArrayCondition() *NumberArrayCondition {
 	return nil
 }
 
+func (x *LogicalOperator) GetLeftTimeCondition() *TimeCondition {
+	if x, ok := x.GetLeft().(*LogicalOperator_LeftTimeCondition); ok {
+		return x.LeftTimeCondition
+	}
+	return nil
+}
+
+func (x *LogicalOperator) GetLeftBoolCondition() *BoolCondition {
+	if x, ok := x.GetLeft().(*LogicalOperator_LeftBoolCondition); ok {
+		return x.LeftBoolCondition
+	}
+	return nil
+}
+
+func (x *LogicalOperator) GetLeftContainsCondition() *ContainsCondition {
+	if x, ok := x.GetLeft().(*LogicalOperator_LeftContainsCondition); ok {
+		return x.LeftContainsCondition
+	}
+	return nil
+}
+
 func (m *LogicalOperator) GetRight() isLogicalOperator_Right {
 	if m != nil {
 		return m.Right
@@ -828,6 +1112,27 @@ func (x *LogicalOperator) GetRightNumberArrayCondition() *NumberArrayCondition {
 	return nil
 }
 
+func (x *LogicalOperator) GetRightTimeCondition() *TimeCondition {
+	if x, ok := x.GetRight().(*LogicalOperator_RightTimeCondition); ok {
+		return x.RightTimeCondition
+	}
+	return nil
+}
+
+func (x *LogicalOperator) GetRightBoolCondition() *BoolCondition {
+	if x, ok := x.GetRight().(*LogicalOperator_RightBoolCondition); ok {
+		return x.RightBoolCondition
+	}
+	return nil
+}
+
+func (x *LogicalOperator) GetRightContainsCondition() *ContainsCondition {
+	if x, ok := x.GetRight().(*LogicalOperator_RightContainsCondition); ok {
+		return x.RightContainsCondition
+	}
+	return nil
+}
+
 func (x *LogicalOperator) GetType() LogicalOperator_Type {
 	if x != nil {
 		return x.Type
@@ -870,6 +1175,18 @@ type LogicalOperator_LeftNumberArrayCondition struct {
 	LeftNumberArrayCondition *NumberArrayCondition `protobuf:"bytes,12,opt,name=left_number_array_condition,json=leftNumberArrayCondition,proto3,oneof"`
 }
 
+type LogicalOperator_LeftTimeCondition struct {
+	LeftTimeCondition *TimeCondition `protobuf:"bytes,15,opt,name=left_time_condition,json=leftTimeCondition,proto3,oneof"`
+}
+
+type LogicalOperator_LeftBoolCondition struct {
+	LeftBoolCondition *BoolCondition `protobuf:"bytes,17,opt,name=left_bool_condition,json=leftBoolCondition,proto3,oneof"`
+}
+
+type LogicalOperator_LeftContainsCondition struct {
+	LeftContainsCondition *ContainsCondition `protobuf:"bytes,19,opt,name=left_contains_condition,json=leftContainsCondition,proto3,oneof"`
+}
+
 func (*LogicalOperator_LeftOperator) isLogicalOperator_Left() {}
 
 func (*LogicalOperator_LeftStringCondition) isLogicalOperator_Left() {}
@@ -882,6 +1199,12 @@ func (*LogicalOperator_LeftStringArrayCondition) isLogicalOperator_Left() {}
 
 func (*LogicalOperator_LeftNumberArrayCondition) isLogicalOperator_Left() {}
 
+func (*LogicalOperator_LeftTimeCondition) isLogicalOperator_Left() {}
+
+func (*LogicalOperator_LeftBoolCondition) isLogicalOperator_Left() {}
+
+func (*LogicalOperator_LeftContainsCondition) isLogicalOperator_Left() {}
+
 type isLogicalOperator_Right interface {
 	isLogicalOperator_Right()
 }
@@ -910,6 +1233,18 @@ type LogicalOperator_RightNumberArrayCondition struct {
 	RightNumberArrayCondition *NumberArrayCondition `protobuf:"bytes,14,opt,name=right_number_array_condition,json=rightNumberArrayCondition,proto3,oneof"`
 }
 
+type LogicalOperator_RightTimeCondition struct {
+	RightTimeCondition *TimeCondition `protobuf:"bytes,16,opt,name=right_time_condition,json=rightTimeCondition,proto3,oneof"`
+}
+
+type LogicalOperator_RightBoolCondition struct {
+	RightBoolCondition *BoolCondition `protobuf:"bytes,18,opt,name=right_bool_condition,json=rightBoolCondition,proto3,oneof"`
+}
+
+type LogicalOperator_RightContainsCondition struct {
+	RightContainsCondition *ContainsCondition `protobuf:"bytes,20,opt,name=right_contains_condition,json=rightContainsCondition,proto3,oneof"`
+}
+
 func (*LogicalOperator_RightOperator) isLogicalOperator_Right() {}
 
 func (*LogicalOperator_RightStringCondition) isLogicalOperator_Right() {}
@@ -922,6 +1257,12 @@ func (*LogicalOperator_RightStringArrayCondition) isLogicalOperator_Right() {}
 
 func (*LogicalOperator_RightNumberArrayCondition) isLogicalOperator_Right() {}
 
+func (*LogicalOperator_RightTimeCondition) isLogicalOperator_Right() {}
+
+func (*LogicalOperator_RightBoolCondition) isLogicalOperator_Right() {}
+
+func (*LogicalOperator_RightContainsCondition) isLogicalOperator_Right() {}
+
 // StringCondition represents a condition with a string literal, e.g. field == 'string'.
 // field_path is a reference to a value of a resource.
 // value is the string literal.
@@ -1280,6 +1621,246 @@ func (x *NumberArrayCondition) GetIsNegative() bool {
 	return false
 }
 
+// TimeCondition represents a condition with a timestamp literal, e.g. field > 2024-01-01T00:00:00Z
+// or field >= now()-7d.
+// field_path is a reference to a value of a resource.
+// value is the timestamp literal, relative literals are resolved at parse time.
+// type is a type of the condition.
+// is_negative is set to true if the condition is negated.
+// relative is the relative literal value is resolved from, e.g. now()-7d, empty for absolute literals.
+type TimeCondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FieldPath  []string               `protobuf:"bytes,1,rep,name=field_path,json=fieldPath,proto3" json:"field_path,omitempty"`
+	Value      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Type       TimeCondition_Type     `protobuf:"varint,3,opt,name=type,proto3,enum=infoblox.api.TimeCondition_Type" json:"type,omitempty"`
+	IsNegative bool                   `protobuf:"varint,4,opt,name=is_negative,json=isNegative,proto3" json:"is_negative,omitempty"`
+	Relative   string                 `protobuf:"bytes,5,opt,name=relative,proto3" json:"relative,omitempty"`
+}
+
+func (x *TimeCondition) Reset() {
+	*x = TimeCondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeCondition) ProtoMessage() {}
+
+func (x *TimeCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeCondition.ProtoReflect.Descriptor instead.
+func (*TimeCondition) Descriptor() ([]byte, []int) {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TimeCondition) GetFieldPath() []string {
+	if x != nil {
+		return x.FieldPath
+	}
+	return nil
+}
+
+func (x *TimeCondition) GetValue() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TimeCondition) GetType() TimeCondition_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TimeCondition_EQ
+}
+
+func (x *TimeCondition) GetIsNegative() bool {
+	if x != nil {
+		return x.IsNegative
+	}
+	return false
+}
+
+func (x *TimeCondition) GetRelative() string {
+	if x != nil {
+		return x.Relative
+	}
+	return ""
+}
+
+// BoolCondition represents a condition with a boolean literal, e.g. field == true.
+// field_path is a reference to a value of a resource.
+// value is the boolean literal.
+// type is a type of the condition.
+// is_negative is set to true if the condition is negated.
+type BoolCondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FieldPath  []string           `protobuf:"bytes,1,rep,name=field_path,json=fieldPath,proto3" json:"field_path,omitempty"`
+	Value      bool               `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
+	Type       BoolCondition_Type `protobuf:"varint,3,opt,name=type,proto3,enum=infoblox.api.BoolCondition_Type" json:"type,omitempty"`
+	IsNegative bool               `protobuf:"varint,4,opt,name=is_negative,json=isNegative,proto3" json:"is_negative,omitempty"`
+}
+
+func (x *BoolCondition) Reset() {
+	*x = BoolCondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BoolCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BoolCondition) ProtoMessage() {}
+
+func (x *BoolCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BoolCondition.ProtoReflect.Descriptor instead.
+func (*BoolCondition) Descriptor() ([]byte, []int) {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BoolCondition) GetFieldPath() []string {
+	if x != nil {
+		return x.FieldPath
+	}
+	return nil
+}
+
+func (x *BoolCondition) GetValue() bool {
+	if x != nil {
+		return x.Value
+	}
+	return false
+}
+
+func (x *BoolCondition) GetType() BoolCondition_Type {
+	if x != nil {
+		return x.Type
+	}
+	return BoolCondition_EQ
+}
+
+func (x *BoolCondition) GetIsNegative() bool {
+	if x != nil {
+		return x.IsNegative
+	}
+	return false
+}
+
+// ContainsCondition represents a condition on a collection value, e.g. field contains ['a', 'b']
+// or field has 'a'.
+// field_path is a reference to a value of a resource.
+// values are the string literals, CONTAINS requires all of them to be elements of the collection,
+// HAS requires the only value to be either an element or a key of the collection.
+// type is a type of the condition.
+// is_negative is set to true if the condition is negated.
+type ContainsCondition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FieldPath  []string               `protobuf:"bytes,1,rep,name=field_path,json=fieldPath,proto3" json:"field_path,omitempty"`
+	Values     []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	Type       ContainsCondition_Type `protobuf:"varint,3,opt,name=type,proto3,enum=infoblox.api.ContainsCondition_Type" json:"type,omitempty"`
+	IsNegative bool                   `protobuf:"varint,4,opt,name=is_negative,json=isNegative,proto3" json:"is_negative,omitempty"`
+}
+
+func (x *ContainsCondition) Reset() {
+	*x = ContainsCondition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContainsCondition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainsCondition) ProtoMessage() {}
+
+func (x *ContainsCondition) ProtoReflect() protoreflect.Message {
+	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainsCondition.ProtoReflect.Descriptor instead.
+func (*ContainsCondition) Descriptor() ([]byte, []int) {
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ContainsCondition) GetFieldPath() []string {
+	if x != nil {
+		return x.FieldPath
+	}
+	return nil
+}
+
+func (x *ContainsCondition) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *ContainsCondition) GetType() ContainsCondition_Type {
+	if x != nil {
+		return x.Type
+	}
+	return ContainsCondition_CONTAINS
+}
+
+func (x *ContainsCondition) GetIsNegative() bool {
+	if x != nil {
+		return x.IsNegative
+	}
+	return false
+}
+
 // Pagination represents both server-driven and client-driven pagination request.
 // Server-driven pagination is a model in which the server returns some
 // amount of data along with an token indicating there is more data
@@ -1309,7 +1890,7 @@ type Pagination struct {
 func (x *Pagination) Reset() {
 	*x = Pagination{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[11]
+		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1322,7 +1903,7 @@ func (x *Pagination) String() string {
 func (*Pagination) ProtoMessage() {}
 
 func (x *Pagination) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[11]
+	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1335,7 +1916,7 @@ func (x *Pagination) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
 func (*Pagination) Descriptor() ([]byte, []int) {
-	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{11}
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Pagination) GetPageToken() string {
@@ -1359,9 +1940,9 @@ func (x *Pagination) GetLimit() int32 {
 	return 0
 }
 
-func (m *Pagination) GetIsTotalSizeNeeded() bool {
-	if m != nil {
-		return m.IsTotalSizeNeeded
+func (x *Pagination) GetIsTotalSizeNeeded() bool {
+	if x != nil {
+		return x.IsTotalSizeNeeded
 	}
 	return false
 }
@@ -1388,12 +1969,14 @@ type PageInfo struct {
 	Offset int32 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
 	// total_size indicates the total records present.
 	TotalSize int64 `protobuf:"varint,4,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	// total_size_approximate indicates total_size is an estimate of the total records present.
+	TotalSizeApproximate bool `protobuf:"varint,5,opt,name=total_size_approximate,json=totalSizeApproximate,proto3" json:"total_size_approximate,omitempty"`
 }
 
 func (x *PageInfo) Reset() {
 	*x = PageInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[12]
+		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1406,7 +1989,7 @@ func (x *PageInfo) String() string {
 func (*PageInfo) ProtoMessage() {}
 
 func (x *PageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[12]
+	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1419,7 +2002,7 @@ func (x *PageInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
 func (*PageInfo) Descriptor() ([]byte, []int) {
-	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{12}
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *PageInfo) GetPageToken() string {
@@ -1443,13 +2026,20 @@ func (x *PageInfo) GetOffset() int32 {
 	return 0
 }
 
-func (m *PageInfo) GetTotalSize() int64 {
-	if m != nil {
-		return m.TotalSize
+func (x *PageInfo) GetTotalSize() int64 {
+	if x != nil {
+		return x.TotalSize
 	}
 	return 0
 }
 
+func (x *PageInfo) GetTotalSizeApproximate() bool {
+	if x != nil {
+		return x.TotalSizeApproximate
+	}
+	return false
+}
+
 // Searching represents search by.
 type Searching struct {
 	state         protoimpl.MessageState
@@ -1462,7 +2052,7 @@ type Searching struct {
 func (x *Searching) Reset() {
 	*x = Searching{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[13]
+		mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1475,7 +2065,7 @@ func (x *Searching) String() string {
 func (*Searching) ProtoMessage() {}
 
 func (x *Searching) ProtoReflect() protoreflect.Message {
-	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[13]
+	mi := &file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1488,7 +2078,7 @@ func (x *Searching) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Searching.ProtoReflect.Descriptor instead.
 func (*Searching) Descriptor() ([]byte, []int) {
-	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{13}
+	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *Searching) GetQuery() string {
@@ -1506,232 +2096,337 @@ var file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_pr
 	0x61, 0x70, 0x70, 0x2d, 0x74, 0x6f, 0x6f, 0x6c, 0x6b, 0x69, 0x74, 0x2f, 0x71, 0x75, 0x65, 0x72,
 	0x79, 0x2f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x65,
 	0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x69, 0x6e,
-	0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32,
-	0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x74, 0x0a, 0x0c, 0x53, 0x6f,
-	0x72, 0x74, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61,
-	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x36, 0x0a, 0x05,
-	0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x69, 0x6e,
-	0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x43,
-	0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f,
-	0x72, 0x64, 0x65, 0x72, 0x22, 0x1a, 0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x07, 0x0a,
-	0x03, 0x41, 0x53, 0x43, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x45, 0x53, 0x43, 0x10, 0x01,
-	0x22, 0x61, 0x0a, 0x07, 0x53, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x38, 0x0a, 0x09, 0x63,
-	0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a,
-	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x6f,
-	0x72, 0x74, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x52, 0x09, 0x63, 0x72, 0x69, 0x74,
-	0x65, 0x72, 0x69, 0x61, 0x73, 0x3a, 0x1c, 0x92, 0x41, 0x19, 0x0a, 0x17, 0x32, 0x11, 0x61, 0x74,
-	0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x9a,
-	0x02, 0x01, 0x07, 0x22, 0xc8, 0x01, 0x0a, 0x0e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x65, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f,
-	0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x4e, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62,
-	0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a, 0x24, 0x92, 0x41, 0x21, 0x0a, 0x1f, 0x32,
-	0x19, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66, 0x69, 0x65, 0x6c, 0x64,
-	0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x9a, 0x02, 0x01, 0x07, 0x22, 0x9c,
-	0x01, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x04,
-	0x73, 0x75, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66,
-	0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x2e,
-	0x53, 0x75, 0x62, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x73, 0x75, 0x62, 0x73, 0x1a,
-	0x4c, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
-	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76,
+	0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe9, 0x01, 0x0a, 0x0c,
+	0x53, 0x6f, 0x72, 0x74, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x61, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x36,
+	0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e,
+	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x6f, 0x72,
+	0x74, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52,
+	0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x36, 0x0a, 0x05, 0x6e, 0x75, 0x6c, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69,
+	0x61, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x73, 0x52, 0x05, 0x6e, 0x75, 0x6c, 0x6c, 0x73, 0x22, 0x1a,
+	0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x53, 0x43, 0x10, 0x00,
+	0x12, 0x08, 0x0a, 0x04, 0x44, 0x45, 0x53, 0x43, 0x10, 0x01, 0x22, 0x3b, 0x0a, 0x05, 0x4e, 0x75,
+	0x6c, 0x6c, 0x73, 0x12, 0x11, 0x0a, 0x0d, 0x4e, 0x55, 0x4c, 0x4c, 0x53, 0x5f, 0x44, 0x45, 0x46,
+	0x41, 0x55, 0x4c, 0x54, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4e, 0x55, 0x4c, 0x4c, 0x53, 0x5f,
+	0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x55, 0x4c, 0x4c, 0x53,
+	0x5f, 0x4c, 0x41, 0x53, 0x54, 0x10, 0x02, 0x22, 0x61, 0x0a, 0x07, 0x53, 0x6f, 0x72, 0x74, 0x69,
+	0x6e, 0x67, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x43, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69,
+	0x61, 0x52, 0x09, 0x63, 0x72, 0x69, 0x74, 0x65, 0x72, 0x69, 0x61, 0x73, 0x3a, 0x1c, 0x92, 0x41,
+	0x19, 0x0a, 0x17, 0x32, 0x11, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x6f, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x9a, 0x02, 0x01, 0x07, 0x22, 0xc8, 0x01, 0x0a, 0x0e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e,
 	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65,
-	0x6c, 0x64, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x86, 0x04,
-	0x0a, 0x09, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x3b, 0x0a, 0x08, 0x6f,
-	0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
-	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67,
-	0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x08,
-	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x4a, 0x0a, 0x10, 0x73, 0x74, 0x72, 0x69,
-	0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x10, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x63,
-	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d,
-	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
-	0x0f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x44, 0x0a, 0x0e, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62,
-	0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0d, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5a, 0x0a, 0x16, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f,
-	0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61,
-	0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x14, 0x73, 0x74,
-	0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x5a, 0x0a, 0x16, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x61, 0x72, 0x72,
-	0x61, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x14, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x3a, 0x1e,
-	0x92, 0x41, 0x1b, 0x0a, 0x19, 0x32, 0x13, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x9a, 0x02, 0x01, 0x07, 0x42, 0x06,
-	0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x22, 0xb2, 0x09, 0x0a, 0x0f, 0x4c, 0x6f, 0x67, 0x69, 0x63,
-	0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x44, 0x0a, 0x0d, 0x6c, 0x65,
-	0x66, 0x74, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x6c, 0x64, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a,
+	0x4e, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x3a,
+	0x24, 0x92, 0x41, 0x21, 0x0a, 0x1f, 0x32, 0x19, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x9a, 0x02, 0x01, 0x07, 0x22, 0x9c, 0x01, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x04, 0x73, 0x75, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
 	0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
-	0x48, 0x00, 0x52, 0x0c, 0x6c, 0x65, 0x66, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
-	0x12, 0x53, 0x0a, 0x15, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f,
-	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00,
-	0x52, 0x13, 0x6c, 0x65, 0x66, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x53, 0x0a, 0x15, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x04, 0x73, 0x75, 0x62, 0x73, 0x1a, 0x4c, 0x0a, 0x09, 0x53, 0x75, 0x62, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x8d, 0x06, 0x0a, 0x09, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x69,
+	0x6e, 0x67, 0x12, 0x3b, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x13, 0x6c, 0x65, 0x66, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4d, 0x0a, 0x13, 0x6c, 0x65,
-	0x66, 0x74, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c,
-	0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x11, 0x6c, 0x65, 0x66, 0x74, 0x4e, 0x75, 0x6c, 0x6c,
-	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x63, 0x0a, 0x1b, 0x6c, 0x65, 0x66,
-	0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x63,
-	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
-	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74,
-	0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x18, 0x6c, 0x65, 0x66, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
-	0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x63,
-	0x0a, 0x1b, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x61, 0x72,
-	0x72, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f,
-	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x18, 0x6c, 0x65, 0x66, 0x74, 0x4e,
-	0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x0e, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e,
-	0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63,
-	0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x01, 0x52, 0x0d, 0x72, 0x69,
-	0x67, 0x68, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x55, 0x0a, 0x16, 0x72,
-	0x69, 0x67, 0x68, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6e, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e,
-	0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e,
-	0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x14, 0x72, 0x69,
-	0x67, 0x68, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x55, 0x0a, 0x16, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x48, 0x00, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12,
+	0x4a, 0x0a, 0x10, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f,
+	0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4a, 0x0a, 0x10, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x0e, 0x6e, 0x75, 0x6c, 0x6c, 0x5f,
+	0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e,
+	0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0d,
+	0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5a, 0x0a,
+	0x16, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x14, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x5a, 0x0a, 0x16, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f,
+	0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41,
+	0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52,
+	0x14, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0d, 0x74, 0x69,
+	0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x0e, 0x62,
+	0x6f, 0x6f, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x00, 0x52, 0x0d, 0x62, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x50, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x5f, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00,
+	0x52, 0x11, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x3a, 0x1e, 0x92, 0x41,
+	0x1b, 0x0a, 0x19, 0x32, 0x13, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x9a, 0x02, 0x01, 0x07, 0x42, 0x06, 0x0a, 0x04,
+	0x72, 0x6f, 0x6f, 0x74, 0x22, 0xaa, 0x0d, 0x0a, 0x0f, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c,
+	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x44, 0x0a, 0x0d, 0x6c, 0x65, 0x66, 0x74,
+	0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c,
+	0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x00,
+	0x52, 0x0c, 0x6c, 0x65, 0x66, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x53,
+	0x0a, 0x15, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x13,
+	0x6c, 0x65, 0x66, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x53, 0x0a, 0x15, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70,
 	0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x48, 0x01, 0x52, 0x14, 0x72, 0x69, 0x67, 0x68, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4f, 0x0a, 0x14, 0x72, 0x69, 0x67,
-	0x68, 0x74, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c,
-	0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x12, 0x72, 0x69, 0x67, 0x68, 0x74, 0x4e, 0x75, 0x6c,
-	0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x65, 0x0a, 0x1c, 0x72, 0x69,
-	0x67, 0x68, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79,
-	0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x19, 0x72, 0x69, 0x67, 0x68, 0x74, 0x53, 0x74, 0x72,
-	0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x65, 0x0a, 0x1c, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c,
-	0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72,
-	0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x19, 0x72,
-	0x69, 0x67, 0x68, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43,
-	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f,
-	0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76,
-	0x65, 0x22, 0x17, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x44,
-	0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x52, 0x10, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x6c, 0x65,
-	0x66, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x22, 0xe3, 0x01, 0x0a, 0x0f,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70,
+	0x6e, 0x48, 0x00, 0x52, 0x13, 0x6c, 0x65, 0x66, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4d, 0x0a, 0x13, 0x6c, 0x65, 0x66, 0x74,
+	0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x00, 0x52, 0x11, 0x6c, 0x65, 0x66, 0x74, 0x4e, 0x75, 0x6c, 0x6c, 0x43, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x63, 0x0a, 0x1b, 0x6c, 0x65, 0x66, 0x74, 0x5f,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69,
+	0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x48, 0x00, 0x52, 0x18, 0x6c, 0x65, 0x66, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72,
+	0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x63, 0x0a, 0x1b,
+	0x6c, 0x65, 0x66, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x61, 0x72, 0x72, 0x61,
+	0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x18, 0x6c, 0x65, 0x66, 0x74, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x4d, 0x0a, 0x13, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x11, 0x6c,
+	0x65, 0x66, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x4d, 0x0a, 0x13, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x6f, 0x6f,
+	0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x11, 0x6c, 0x65,
+	0x66, 0x74, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x59, 0x0a, 0x17, 0x6c, 0x65, 0x66, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73,
+	0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x48, 0x00, 0x52, 0x15, 0x6c, 0x65, 0x66, 0x74, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x46, 0x0a, 0x0e, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f,
+	0x72, 0x48, 0x01, 0x52, 0x0d, 0x72, 0x69, 0x67, 0x68, 0x74, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x12, 0x55, 0x0a, 0x16, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70,
 	0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
-	0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x42, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x45, 0x51, 0x10, 0x00, 0x12, 0x09, 0x0a,
-	0x05, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54, 0x10, 0x02,
-	0x12, 0x06, 0x0a, 0x02, 0x47, 0x45, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x04,
-	0x12, 0x06, 0x0a, 0x02, 0x4c, 0x45, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x49, 0x45, 0x51, 0x10,
-	0x06, 0x22, 0xcf, 0x01, 0x0a, 0x0f, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64,
+	0x6e, 0x48, 0x01, 0x52, 0x14, 0x72, 0x69, 0x67, 0x68, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x55, 0x0a, 0x16, 0x72, 0x69, 0x67,
+	0x68, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x6e, 0x66, 0x6f,
+	0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x14, 0x72, 0x69, 0x67, 0x68,
+	0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x4f, 0x0a, 0x14, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75,
+	0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x12, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x4e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x65, 0x0a, 0x1c, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c,
+	0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72,
+	0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x19, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x65, 0x0a, 0x1c, 0x72, 0x69, 0x67, 0x68,
+	0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x48, 0x01, 0x52, 0x19, 0x72, 0x69, 0x67, 0x68, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x4f, 0x0a, 0x14, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x63, 0x6f,
+	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x12, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x4f, 0x0a, 0x14, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x62, 0x6f, 0x6f, 0x6c, 0x5f, 0x63,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x42, 0x6f,
+	0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x12, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x5b, 0x0a, 0x18, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x14, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x48, 0x01, 0x52, 0x16, 0x72, 0x69, 0x67, 0x68, 0x74, 0x43, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x36,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x69,
+	0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4c, 0x6f, 0x67, 0x69,
+	0x63, 0x61, 0x6c, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x17, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x07, 0x0a, 0x03, 0x41, 0x4e, 0x44, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x52, 0x10, 0x01,
+	0x42, 0x06, 0x0a, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x72, 0x69, 0x67, 0x68,
+	0x74, 0x22, 0xe3, 0x01, 0x0a, 0x0f, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x64,
 	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70,
 	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64,
 	0x50, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x79,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x79,
 	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62,
-	0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f,
+	0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x43, 0x6f,
 	0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
 	0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76,
 	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e, 0x65, 0x67, 0x61, 0x74,
-	0x69, 0x76, 0x65, 0x22, 0x2e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x45,
-	0x51, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x47,
-	0x45, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x4c,
-	0x45, 0x10, 0x04, 0x22, 0x4f, 0x0a, 0x0d, 0x4e, 0x75, 0x6c, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61,
-	0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50,
-	0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69,
-	0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e, 0x65, 0x67, 0x61,
-	0x74, 0x69, 0x76, 0x65, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41,
-	0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
-	0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x27, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69,
-	0x76, 0x65, 0x22, 0x0e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x49, 0x4e,
-	0x10, 0x00, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72,
-	0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
+	0x69, 0x76, 0x65, 0x22, 0x42, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x45,
+	0x51, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x01, 0x12, 0x06,
+	0x0a, 0x02, 0x47, 0x54, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x45, 0x10, 0x03, 0x12, 0x06,
+	0x0a, 0x02, 0x4c, 0x54, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x45, 0x10, 0x05, 0x12, 0x07,
+	0x0a, 0x03, 0x49, 0x45, 0x51, 0x10, 0x06, 0x22, 0xcf, 0x01, 0x0a, 0x0f, 0x4e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66,
 	0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x73, 0x12, 0x3b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x27, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69,
+	0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x36, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x22,
+	0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69,
+	0x73, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x2e, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x06, 0x0a, 0x02, 0x45, 0x51, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54, 0x10,
+	0x01, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x45, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54, 0x10,
+	0x03, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x45, 0x10, 0x04, 0x22, 0x4f, 0x0a, 0x0d, 0x4e, 0x75, 0x6c,
+	0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f,
+	0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x69, 0x73, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62,
+	0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x41, 0x72,
+	0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73,
+	0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x0e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x06, 0x0a, 0x02, 0x49, 0x4e, 0x10, 0x00, 0x22, 0xbb, 0x01, 0x0a, 0x14, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61, 0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x3b, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x27, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f,
+	0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x41, 0x72, 0x72, 0x61,
+	0x79, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e, 0x65,
+	0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x0e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x06,
+	0x0a, 0x02, 0x49, 0x4e, 0x10, 0x00, 0x22, 0x83, 0x02, 0x0a, 0x0d, 0x54, 0x69, 0x6d, 0x65, 0x43,
+	0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c,
+	0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x43, 0x6f, 0x6e, 0x64, 0x69,
 	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
 	0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04,
 	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65,
-	0x22, 0x0e, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x49, 0x4e, 0x10, 0x00,
-	0x22, 0x76, 0x0a, 0x0a, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d,
-	0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a,
-	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f,
-	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x3a, 0x1b, 0x92, 0x41, 0x18,
-	0x0a, 0x16, 0x32, 0x10, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x61,
-	0x67, 0x69, 0x6e, 0x67, 0x9a, 0x02, 0x01, 0x07, 0x22, 0x55, 0x0a, 0x08, 0x50, 0x61, 0x67, 0x65,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
-	0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22,
-	0x41, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05,
-	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
-	0x72, 0x79, 0x3a, 0x1e, 0x92, 0x41, 0x1b, 0x0a, 0x19, 0x32, 0x13, 0x61, 0x74, 0x6c, 0x61, 0x73,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x62, 0x79, 0x9a, 0x02,
-	0x01, 0x07, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x6f, 0x70, 0x65, 0x6e, 0x2f, 0x61, 0x74,
-	0x6c, 0x61, 0x73, 0x2d, 0x61, 0x70, 0x70, 0x2d, 0x74, 0x6f, 0x6f, 0x6c, 0x6b, 0x69, 0x74, 0x2f,
-	0x76, 0x32, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x3b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x2e, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x45, 0x51, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02,
+	0x47, 0x54, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x45, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02,
+	0x4c, 0x54, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x45, 0x10, 0x04, 0x22, 0xab, 0x01, 0x0a,
+	0x0d, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d,
+	0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f,
+	0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
+	0x69, 0x73, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x22, 0x0e, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x45, 0x51, 0x10, 0x00, 0x22, 0xc4, 0x01, 0x0a, 0x11, 0x43,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c, 0x6f, 0x78,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x43, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4e, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x76, 0x65, 0x22, 0x1d, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x4f,
+	0x4e, 0x54, 0x41, 0x49, 0x4e, 0x53, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x48, 0x41, 0x53, 0x10,
+	0x01, 0x22, 0xa7, 0x01, 0x0a, 0x0a, 0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x2f, 0x0a,
+	0x14, 0x69, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6e,
+	0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x73, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x4e, 0x65, 0x65, 0x64, 0x65, 0x64, 0x3a, 0x1b,
+	0x92, 0x41, 0x18, 0x0a, 0x16, 0x32, 0x10, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x70, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x9a, 0x02, 0x01, 0x07, 0x22, 0xaa, 0x01, 0x0a, 0x08,
+	0x50, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66,
+	0x73, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x41, 0x70, 0x70,
+	0x72, 0x6f, 0x78, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x22, 0x41, 0x0a, 0x09, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x3a, 0x1e, 0x92, 0x41, 0x1b,
+	0x0a, 0x19, 0x32, 0x13, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x5f, 0x62, 0x79, 0x9a, 0x02, 0x01, 0x07, 0x42, 0x3a, 0x5a, 0x38, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6f, 0x62, 0x6c,
+	0x6f, 0x78, 0x6f, 0x70, 0x65, 0x6e, 0x2f, 0x61, 0x74, 0x6c, 0x61, 0x73, 0x2d, 0x61, 0x70, 0x70,
+	0x2d, 0x74, 0x6f, 0x6f, 0x6c, 0x6b, 0x69, 0x74, 0x2f, 0x76, 0x32, 0x2f, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x3b, 0x71, 0x75, 0x65, 0x72, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1746,67 +2441,89 @@ func file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_p
 	return file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDescData
 }
 
-var file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_enumTypes = make([]protoimpl.EnumInfo, 10)
+var file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_goTypes = []interface{}{
 	(SortCriteria_Order)(0),        // 0: infoblox.api.SortCriteria.Order
-	(LogicalOperator_Type)(0),      // 1: infoblox.api.LogicalOperator.Type
-	(StringCondition_Type)(0),      // 2: infoblox.api.StringCondition.Type
-	(NumberCondition_Type)(0),      // 3: infoblox.api.NumberCondition.Type
-	(StringArrayCondition_Type)(0), // 4: infoblox.api.StringArrayCondition.Type
-	(NumberArrayCondition_Type)(0), // 5: infoblox.api.NumberArrayCondition.Type
-	(*SortCriteria)(nil),           // 6: infoblox.api.SortCriteria
-	(*Sorting)(nil),                // 7: infoblox.api.Sorting
-	(*FieldSelection)(nil),         // 8: infoblox.api.FieldSelection
-	(*Field)(nil),                  // 9: infoblox.api.Field
-	(*Filtering)(nil),              // 10: infoblox.api.Filtering
-	(*LogicalOperator)(nil),        // 11: infoblox.api.LogicalOperator
-	(*StringCondition)(nil),        // 12: infoblox.api.StringCondition
-	(*NumberCondition)(nil),        // 13: infoblox.api.NumberCondition
-	(*NullCondition)(nil),          // 14: infoblox.api.NullCondition
-	(*StringArrayCondition)(nil),   // 15: infoblox.api.StringArrayCondition
-	(*NumberArrayCondition)(nil),   // 16: infoblox.api.NumberArrayCondition
-	(*Pagination)(nil),             // 17: infoblox.api.Pagination
-	(*PageInfo)(nil),               // 18: infoblox.api.PageInfo
-	(*Searching)(nil),              // 19: infoblox.api.Searching
-	nil,                            // 20: infoblox.api.FieldSelection.FieldsEntry
-	nil,                            // 21: infoblox.api.Field.SubsEntry
+	(SortCriteria_Nulls)(0),        // 1: infoblox.api.SortCriteria.Nulls
+	(LogicalOperator_Type)(0),      // 2: infoblox.api.LogicalOperator.Type
+	(StringCondition_Type)(0),      // 3: infoblox.api.StringCondition.Type
+	(NumberCondition_Type)(0),      // 4: infoblox.api.NumberCondition.Type
+	(StringArrayCondition_Type)(0), // 5: infoblox.api.StringArrayCondition.Type
+	(NumberArrayCondition_Type)(0), // 6: infoblox.api.NumberArrayCondition.Type
+	(TimeCondition_Type)(0),        // 7: infoblox.api.TimeCondition.Type
+	(BoolCondition_Type)(0),        // 8: infoblox.api.BoolCondition.Type
+	(ContainsCondition_Type)(0),    // 9: infoblox.api.ContainsCondition.Type
+	(*SortCriteria)(nil),           // 10: infoblox.api.SortCriteria
+	(*Sorting)(nil),                // 11: infoblox.api.Sorting
+	(*FieldSelection)(nil),         // 12: infoblox.api.FieldSelection
+	(*Field)(nil),                  // 13: infoblox.api.Field
+	(*Filtering)(nil),              // 14: infoblox.api.Filtering
+	(*LogicalOperator)(nil),        // 15: infoblox.api.LogicalOperator
+	(*StringCondition)(nil),        // 16: infoblox.api.StringCondition
+	(*NumberCondition)(nil),        // 17: infoblox.api.NumberCondition
+	(*NullCondition)(nil),          // 18: infoblox.api.NullCondition
+	(*StringArrayCondition)(nil),   // 19: infoblox.api.StringArrayCondition
+	(*NumberArrayCondition)(nil),   // 20: infoblox.api.NumberArrayCondition
+	(*TimeCondition)(nil),          // 21: infoblox.api.TimeCondition
+	(*BoolCondition)(nil),          // 22: infoblox.api.BoolCondition
+	(*ContainsCondition)(nil),      // 23: infoblox.api.ContainsCondition
+	(*Pagination)(nil),             // 24: infoblox.api.Pagination
+	(*PageInfo)(nil),               // 25: infoblox.api.PageInfo
+	(*Searching)(nil),              // 26: infoblox.api.Searching
+	nil,                            // 27: infoblox.api.FieldSelection.FieldsEntry
+	nil,                            // 28: infoblox.api.Field.SubsEntry
+	(*timestamppb.Timestamp)(nil),  // 29: google.protobuf.Timestamp
 }
 var file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_depIdxs = []int32{
 	0,  // 0: infoblox.api.SortCriteria.order:type_name -> infoblox.api.SortCriteria.Order
-	6,  // 1: infoblox.api.Sorting.criterias:type_name -> infoblox.api.SortCriteria
-	20, // 2: infoblox.api.FieldSelection.fields:type_name -> infoblox.api.FieldSelection.FieldsEntry
-	21, // 3: infoblox.api.Field.subs:type_name -> infoblox.api.Field.SubsEntry
-	11, // 4: infoblox.api.Filtering.operator:type_name -> infoblox.api.LogicalOperator
-	12, // 5: infoblox.api.Filtering.string_condition:type_name -> infoblox.api.StringCondition
-	13, // 6: infoblox.api.Filtering.number_condition:type_name -> infoblox.api.NumberCondition
-	14, // 7: infoblox.api.Filtering.null_condition:type_name -> infoblox.api.NullCondition
-	15, // 8: infoblox.api.Filtering.string_array_condition:type_name -> infoblox.api.StringArrayCondition
-	16, // 9: infoblox.api.Filtering.number_array_condition:type_name -> infoblox.api.NumberArrayCondition
-	11, // 10: infoblox.api.LogicalOperator.left_operator:type_name -> infoblox.api.LogicalOperator
-	12, // 11: infoblox.api.LogicalOperator.left_string_condition:type_name -> infoblox.api.StringCondition
-	13, // 12: infoblox.api.LogicalOperator.left_number_condition:type_name -> infoblox.api.NumberCondition
-	14, // 13: infoblox.api.LogicalOperator.left_null_condition:type_name -> infoblox.api.NullCondition
-	15, // 14: infoblox.api.LogicalOperator.left_string_array_condition:type_name -> infoblox.api.StringArrayCondition
-	16, // 15: infoblox.api.LogicalOperator.left_number_array_condition:type_name -> infoblox.api.NumberArrayCondition
-	11, // 16: infoblox.api.LogicalOperator.right_operator:type_name -> infoblox.api.LogicalOperator
-	12, // 17: infoblox.api.LogicalOperator.right_string_condition:type_name -> infoblox.api.StringCondition
-	13, // 18: infoblox.api.LogicalOperator.right_number_condition:type_name -> infoblox.api.NumberCondition
-	14, // 19: infoblox.api.LogicalOperator.right_null_condition:type_name -> infoblox.api.NullCondition
-	15, // 20: infoblox.api.LogicalOperator.right_string_array_condition:type_name -> infoblox.api.StringArrayCondition
-	16, // 21: infoblox.api.LogicalOperator.right_number_array_condition:type_name -> infoblox.api.NumberArrayCondition
-	1,  // 22: infoblox.api.LogicalOperator.type:type_name -> infoblox.api.LogicalOperator.Type
-	2,  // 23: infoblox.api.StringCondition.type:type_name -> infoblox.api.StringCondition.Type
-	3,  // 24: infoblox.api.NumberCondition.type:type_name -> infoblox.api.NumberCondition.Type
-	4,  // 25: infoblox.api.StringArrayCondition.type:type_name -> infoblox.api.StringArrayCondition.Type
-	5,  // 26: infoblox.api.NumberArrayCondition.type:type_name -> infoblox.api.NumberArrayCondition.Type
-	9,  // 27: infoblox.api.FieldSelection.FieldsEntry.value:type_name -> infoblox.api.Field
-	9,  // 28: infoblox.api.Field.SubsEntry.value:type_name -> infoblox.api.Field
-	29, // [29:29] is the sub-list for method output_type
-	29, // [29:29] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	1,  // 1: infoblox.api.SortCriteria.nulls:type_name -> infoblox.api.SortCriteria.Nulls
+	10, // 2: infoblox.api.Sorting.criterias:type_name -> infoblox.api.SortCriteria
+	27, // 3: infoblox.api.FieldSelection.fields:type_name -> infoblox.api.FieldSelection.FieldsEntry
+	28, // 4: infoblox.api.Field.subs:type_name -> infoblox.api.Field.SubsEntry
+	15, // 5: infoblox.api.Filtering.operator:type_name -> infoblox.api.LogicalOperator
+	16, // 6: infoblox.api.Filtering.string_condition:type_name -> infoblox.api.StringCondition
+	17, // 7: infoblox.api.Filtering.number_condition:type_name -> infoblox.api.NumberCondition
+	18, // 8: infoblox.api.Filtering.null_condition:type_name -> infoblox.api.NullCondition
+	19, // 9: infoblox.api.Filtering.string_array_condition:type_name -> infoblox.api.StringArrayCondition
+	20, // 10: infoblox.api.Filtering.number_array_condition:type_name -> infoblox.api.NumberArrayCondition
+	21, // 11: infoblox.api.Filtering.time_condition:type_name -> infoblox.api.TimeCondition
+	22, // 12: infoblox.api.Filtering.bool_condition:type_name -> infoblox.api.BoolCondition
+	23, // 13: infoblox.api.Filtering.contains_condition:type_name -> infoblox.api.ContainsCondition
+	15, // 14: infoblox.api.LogicalOperator.left_operator:type_name -> infoblox.api.LogicalOperator
+	16, // 15: infoblox.api.LogicalOperator.left_string_condition:type_name -> infoblox.api.StringCondition
+	17, // 16: infoblox.api.LogicalOperator.left_number_condition:type_name -> infoblox.api.NumberCondition
+	18, // 17: infoblox.api.LogicalOperator.left_null_condition:type_name -> infoblox.api.NullCondition
+	19, // 18: infoblox.api.LogicalOperator.left_string_array_condition:type_name -> infoblox.api.StringArrayCondition
+	20, // 19: infoblox.api.LogicalOperator.left_number_array_condition:type_name -> infoblox.api.NumberArrayCondition
+	21, // 20: infoblox.api.LogicalOperator.left_time_condition:type_name -> infoblox.api.TimeCondition
+	22, // 21: infoblox.api.LogicalOperator.left_bool_condition:type_name -> infoblox.api.BoolCondition
+	23, // 22: infoblox.api.LogicalOperator.left_contains_condition:type_name -> infoblox.api.ContainsCondition
+	15, // 23: infoblox.api.LogicalOperator.right_operator:type_name -> infoblox.api.LogicalOperator
+	16, // 24: infoblox.api.LogicalOperator.right_string_condition:type_name -> infoblox.api.StringCondition
+	17, // 25: infoblox.api.LogicalOperator.right_number_condition:type_name -> infoblox.api.NumberCondition
+	18, // 26: infoblox.api.LogicalOperator.right_null_condition:type_name -> infoblox.api.NullCondition
+	19, // 27: infoblox.api.LogicalOperator.right_string_array_condition:type_name -> infoblox.api.StringArrayCondition
+	20, // 28: infoblox.api.LogicalOperator.right_number_array_condition:type_name -> infoblox.api.NumberArrayCondition
+	21, // 29: infoblox.api.LogicalOperator.right_time_condition:type_name -> infoblox.api.TimeCondition
+	22, // 30: infoblox.api.LogicalOperator.right_bool_condition:type_name -> infoblox.api.BoolCondition
+	23, // 31: infoblox.api.LogicalOperator.right_contains_condition:type_name -> infoblox.api.ContainsCondition
+	2,  // 32: infoblox.api.LogicalOperator.type:type_name -> infoblox.api.LogicalOperator.Type
+	3,  // 33: infoblox.api.StringCondition.type:type_name -> infoblox.api.StringCondition.Type
+	4,  // 34: infoblox.api.NumberCondition.type:type_name -> infoblox.api.NumberCondition.Type
+	5,  // 35: infoblox.api.StringArrayCondition.type:type_name -> infoblox.api.StringArrayCondition.Type
+	6,  // 36: infoblox.api.NumberArrayCondition.type:type_name -> infoblox.api.NumberArrayCondition.Type
+	29, // 37: infoblox.api.TimeCondition.value:type_name -> google.protobuf.Timestamp
+	7,  // 38: infoblox.api.TimeCondition.type:type_name -> infoblox.api.TimeCondition.Type
+	8,  // 39: infoblox.api.BoolCondition.type:type_name -> infoblox.api.BoolCondition.Type
+	9,  // 40: infoblox.api.ContainsCondition.type:type_name -> infoblox.api.ContainsCondition.Type
+	13, // 41: infoblox.api.FieldSelection.FieldsEntry.value:type_name -> infoblox.api.Field
+	13, // 42: infoblox.api.Field.SubsEntry.value:type_name -> infoblox.api.Field
+	43, // [43:43] is the sub-list for method output_type
+	43, // [43:43] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_init() }
@@ -1948,7 +2665,7 @@ func file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_p
 			}
 		}
 		file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Pagination); i {
+			switch v := v.(*TimeCondition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1960,7 +2677,7 @@ func file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_p
 			}
 		}
 		file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PageInfo); i {
+			switch v := v.(*BoolCondition); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1972,6 +2689,42 @@ func file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_p
 			}
 		}
 		file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainsCondition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Pagination); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PageInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Searching); i {
 			case 0:
 				return &v.state
@@ -1991,6 +2744,9 @@ func file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_p
 		(*Filtering_NullCondition)(nil),
 		(*Filtering_StringArrayCondition)(nil),
 		(*Filtering_NumberArrayCondition)(nil),
+		(*Filtering_TimeCondition)(nil),
+		(*Filtering_BoolCondition)(nil),
+		(*Filtering_ContainsCondition)(nil),
 	}
 	file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_msgTypes[5].OneofWrappers = []interface{}{
 		(*LogicalOperator_LeftOperator)(nil),
@@ -1999,20 +2755,26 @@ func file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_p
 		(*LogicalOperator_LeftNullCondition)(nil),
 		(*LogicalOperator_LeftStringArrayCondition)(nil),
 		(*LogicalOperator_LeftNumberArrayCondition)(nil),
+		(*LogicalOperator_LeftTimeCondition)(nil),
+		(*LogicalOperator_LeftBoolCondition)(nil),
+		(*LogicalOperator_LeftContainsCondition)(nil),
 		(*LogicalOperator_RightOperator)(nil),
 		(*LogicalOperator_RightStringCondition)(nil),
 		(*LogicalOperator_RightNumberCondition)(nil),
 		(*LogicalOperator_RightNullCondition)(nil),
 		(*LogicalOperator_RightStringArrayCondition)(nil),
 		(*LogicalOperator_RightNumberArrayCondition)(nil),
+		(*LogicalOperator_RightTimeCondition)(nil),
+		(*LogicalOperator_RightBoolCondition)(nil),
+		(*LogicalOperator_RightContainsCondition)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_github_com_infobloxopen_atlas_app_toolkit_query_collection_operators_proto_rawDesc,
-			NumEnums:      6,
-			NumMessages:   16,
+			NumEnums:      10,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
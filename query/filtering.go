@@ -5,8 +5,10 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Filter is a shortcut to parse a filter string using default FilteringParser implementation
@@ -232,6 +234,109 @@ func floatInSlice(digit float64, slice []float64) bool {
 	return false
 }
 
+// Filter evaluates time condition against obj.
+// Supported field types are time.Time, *timestamppb.Timestamp and strings in RFC3339 format.
+// If obj is a proto message, then 'protobuf' tag is used to map FieldPath to obj's struct fields,
+// otherwise 'json' tag is used.
+func (c *TimeCondition) Filter(obj interface{}) (bool, error) {
+	fv := fieldByFieldPath(obj, c.FieldPath)
+	fv = dereferenceValue(fv)
+	var t time.Time
+	switch {
+	case !fv.IsValid():
+		return false, &TypeMismatchError{"time", c.FieldPath}
+	case fv.Type() == reflect.TypeOf(time.Time{}):
+		t = fv.Interface().(time.Time)
+	case fv.Type() == reflect.TypeOf(timestamppb.Timestamp{}):
+		if !fv.CanAddr() {
+			// the field of a struct passed by value is not addressable, so it is copied
+			addr := reflect.New(fv.Type())
+			addr.Elem().Set(fv)
+			fv = addr.Elem()
+		}
+		t = fv.Addr().Interface().(*timestamppb.Timestamp).AsTime()
+	case fv.Kind() == reflect.String:
+		var err error
+		if t, err = time.Parse(time.RFC3339Nano, fv.String()); err != nil {
+			return false, &TypeMismatchError{"time", c.FieldPath}
+		}
+	default:
+		return false, &TypeMismatchError{"time", c.FieldPath}
+	}
+	v := c.Value.AsTime()
+	switch c.Type {
+	case TimeCondition_EQ:
+		return negateIfNeeded(t.Equal(v), c.IsNegative), nil
+	case TimeCondition_GT:
+		return negateIfNeeded(t.After(v), c.IsNegative), nil
+	case TimeCondition_GE:
+		return negateIfNeeded(!t.Before(v), c.IsNegative), nil
+	case TimeCondition_LT:
+		return negateIfNeeded(t.Before(v), c.IsNegative), nil
+	case TimeCondition_LE:
+		return negateIfNeeded(!t.After(v), c.IsNegative), nil
+	default:
+		return false, &UnsupportedOperatorError{"time", c.Type.String()}
+	}
+}
+
+// Filter evaluates bool condition against obj.
+// If obj is a proto message, then 'protobuf' tag is used to map FieldPath to obj's struct fields,
+// otherwise 'json' tag is used.
+func (c *BoolCondition) Filter(obj interface{}) (bool, error) {
+	fv := fieldByFieldPath(obj, c.FieldPath)
+	fv = dereferenceValue(fv)
+	if fv.Kind() != reflect.Bool {
+		return false, &TypeMismatchError{"bool", c.FieldPath}
+	}
+	switch c.Type {
+	case BoolCondition_EQ:
+		return negateIfNeeded(fv.Bool() == c.Value, c.IsNegative), nil
+	default:
+		return false, &UnsupportedOperatorError{"bool", c.Type.String()}
+	}
+}
+
+// Filter evaluates contains condition against obj.
+// Supported field types are slices and arrays of strings and, for HAS conditions, maps with string keys.
+// If obj is a proto message, then 'protobuf' tag is used to map FieldPath to obj's struct fields,
+// otherwise 'json' tag is used.
+func (c *ContainsCondition) Filter(obj interface{}) (bool, error) {
+	fv := fieldByFieldPath(obj, c.FieldPath)
+	fv = dereferenceValue(fv)
+	switch fv.Kind() {
+	case reflect.Slice, reflect.Array:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return false, &TypeMismatchError{"[]string", c.FieldPath}
+		}
+		elems := make([]string, fv.Len())
+		for i := range elems {
+			elems[i] = fv.Index(i).String()
+		}
+		for _, v := range c.Values {
+			if !stringInSlice(v, elems) {
+				return negateIfNeeded(false, c.IsNegative), nil
+			}
+		}
+		return negateIfNeeded(true, c.IsNegative), nil
+	case reflect.Map:
+		if c.Type != ContainsCondition_HAS {
+			return false, &UnsupportedOperatorError{"map", c.Type.String()}
+		}
+		if fv.Type().Key().Kind() != reflect.String {
+			return false, &TypeMismatchError{"map[string]", c.FieldPath}
+		}
+		for _, v := range c.Values {
+			if !fv.MapIndex(reflect.ValueOf(v).Convert(fv.Type().Key())).IsValid() {
+				return negateIfNeeded(false, c.IsNegative), nil
+			}
+		}
+		return negateIfNeeded(true, c.IsNegative), nil
+	default:
+		return false, &TypeMismatchError{"collection", c.FieldPath}
+	}
+}
+
 func fieldByFieldPath(obj interface{}, fieldPath []string) reflect.Value {
 	switch obj.(type) {
 	case proto.Message:
@@ -314,6 +419,18 @@ func (m *Filtering_NumberArrayCondition) Filter(obj interface{}) (bool, error) {
 	return m.NumberArrayCondition.Filter(obj)
 }
 
+func (m *Filtering_TimeCondition) Filter(obj interface{}) (bool, error) {
+	return m.TimeCondition.Filter(obj)
+}
+
+func (m *Filtering_BoolCondition) Filter(obj interface{}) (bool, error) {
+	return m.BoolCondition.Filter(obj)
+}
+
+func (m *Filtering_ContainsCondition) Filter(obj interface{}) (bool, error) {
+	return m.ContainsCondition.Filter(obj)
+}
+
 func (m *LogicalOperator_LeftOperator) Filter(obj interface{}) (bool, error) {
 	return m.LeftOperator.Filter(obj)
 }
@@ -338,6 +455,18 @@ func (m *LogicalOperator_LeftNumberArrayCondition) Filter(obj interface{}) (bool
 	return m.LeftNumberArrayCondition.Filter(obj)
 }
 
+func (m *LogicalOperator_LeftTimeCondition) Filter(obj interface{}) (bool, error) {
+	return m.LeftTimeCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_LeftBoolCondition) Filter(obj interface{}) (bool, error) {
+	return m.LeftBoolCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_LeftContainsCondition) Filter(obj interface{}) (bool, error) {
+	return m.LeftContainsCondition.Filter(obj)
+}
+
 func (m *LogicalOperator_RightOperator) Filter(obj interface{}) (bool, error) {
 	return m.RightOperator.Filter(obj)
 }
@@ -362,6 +491,18 @@ func (m *LogicalOperator_RightNumberArrayCondition) Filter(obj interface{}) (boo
 	return m.RightNumberArrayCondition.Filter(obj)
 }
 
+func (m *LogicalOperator_RightTimeCondition) Filter(obj interface{}) (bool, error) {
+	return m.RightTimeCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_RightBoolCondition) Filter(obj interface{}) (bool, error) {
+	return m.RightBoolCondition.Filter(obj)
+}
+
+func (m *LogicalOperator_RightContainsCondition) Filter(obj interface{}) (bool, error) {
+	return m.RightContainsCondition.Filter(obj)
+}
+
 // SetRoot automatically wraps r into appropriate oneof structure and sets it to Root.
 func (m *Filtering) SetRoot(r interface{}) error {
 	switch x := r.(type) {
@@ -377,6 +518,12 @@ func (m *Filtering) SetRoot(r interface{}) error {
 		m.Root = &Filtering_StringArrayCondition{x}
 	case *NumberArrayCondition:
 		m.Root = &Filtering_NumberArrayCondition{x}
+	case *TimeCondition:
+		m.Root = &Filtering_TimeCondition{x}
+	case *BoolCondition:
+		m.Root = &Filtering_BoolCondition{x}
+	case *ContainsCondition:
+		m.Root = &Filtering_ContainsCondition{x}
 	case nil:
 		m.Root = nil
 	default:
@@ -400,6 +547,12 @@ func (m *LogicalOperator) SetLeft(l interface{}) error {
 		m.Left = &LogicalOperator_LeftStringArrayCondition{x}
 	case *NumberArrayCondition:
 		m.Left = &LogicalOperator_LeftNumberArrayCondition{x}
+	case *TimeCondition:
+		m.Left = &LogicalOperator_LeftTimeCondition{x}
+	case *BoolCondition:
+		m.Left = &LogicalOperator_LeftBoolCondition{x}
+	case *ContainsCondition:
+		m.Left = &LogicalOperator_LeftContainsCondition{x}
 	case nil:
 		m.Left = nil
 	default:
@@ -423,6 +576,12 @@ func (m *LogicalOperator) SetRight(r interface{}) error {
 		m.Right = &LogicalOperator_RightStringArrayCondition{x}
 	case *NumberArrayCondition:
 		m.Right = &LogicalOperator_RightNumberArrayCondition{x}
+	case *TimeCondition:
+		m.Right = &LogicalOperator_RightTimeCondition{x}
+	case *BoolCondition:
+		m.Right = &LogicalOperator_RightBoolCondition{x}
+	case *ContainsCondition:
+		m.Right = &LogicalOperator_RightContainsCondition{x}
 	case nil:
 		m.Right = nil
 	default:
@@ -430,3 +589,78 @@ func (m *LogicalOperator) SetRight(r interface{}) error {
 	}
 	return nil
 }
+
+func rootExpression(f *Filtering) FilteringExpression {
+	switch r := f.GetRoot().(type) {
+	case *Filtering_Operator:
+		return r.Operator
+	case *Filtering_StringCondition:
+		return r.StringCondition
+	case *Filtering_NumberCondition:
+		return r.NumberCondition
+	case *Filtering_NullCondition:
+		return r.NullCondition
+	case *Filtering_StringArrayCondition:
+		return r.StringArrayCondition
+	case *Filtering_NumberArrayCondition:
+		return r.NumberArrayCondition
+	case *Filtering_TimeCondition:
+		return r.TimeCondition
+	case *Filtering_BoolCondition:
+		return r.BoolCondition
+	case *Filtering_ContainsCondition:
+		return r.ContainsCondition
+	default:
+		return nil
+	}
+}
+
+func leftExpression(lop *LogicalOperator) FilteringExpression {
+	switch l := lop.GetLeft().(type) {
+	case *LogicalOperator_LeftOperator:
+		return l.LeftOperator
+	case *LogicalOperator_LeftStringCondition:
+		return l.LeftStringCondition
+	case *LogicalOperator_LeftNumberCondition:
+		return l.LeftNumberCondition
+	case *LogicalOperator_LeftNullCondition:
+		return l.LeftNullCondition
+	case *LogicalOperator_LeftStringArrayCondition:
+		return l.LeftStringArrayCondition
+	case *LogicalOperator_LeftNumberArrayCondition:
+		return l.LeftNumberArrayCondition
+	case *LogicalOperator_LeftTimeCondition:
+		return l.LeftTimeCondition
+	case *LogicalOperator_LeftBoolCondition:
+		return l.LeftBoolCondition
+	case *LogicalOperator_LeftContainsCondition:
+		return l.LeftContainsCondition
+	default:
+		return nil
+	}
+}
+
+func rightExpression(lop *LogicalOperator) FilteringExpression {
+	switch r := lop.GetRight().(type) {
+	case *LogicalOperator_RightOperator:
+		return r.RightOperator
+	case *LogicalOperator_RightStringCondition:
+		return r.RightStringCondition
+	case *LogicalOperator_RightNumberCondition:
+		return r.RightNumberCondition
+	case *LogicalOperator_RightNullCondition:
+		return r.RightNullCondition
+	case *LogicalOperator_RightStringArrayCondition:
+		return r.RightStringArrayCondition
+	case *LogicalOperator_RightNumberArrayCondition:
+		return r.RightNumberArrayCondition
+	case *LogicalOperator_RightTimeCondition:
+		return r.RightTimeCondition
+	case *LogicalOperator_RightBoolCondition:
+		return r.RightBoolCondition
+	case *LogicalOperator_RightContainsCondition:
+		return r.RightContainsCondition
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,321 @@
+package query
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FieldBuilder starts a condition on a field of a resource, see FilterField.
+type FieldBuilder struct {
+	fieldPath []string
+}
+
+// FilterBuilder builds a filtering expression in a fluent way, e.g.
+//
+//	query.FilterField("a", "b").Eq("x").And(query.FilterField("n").Gt(3)).Not()
+//
+// FilterBuilder is immutable, every method returns a new builder that shares
+// no mutable state with its operands, so builders can be reused safely.
+// The first error that occurs while building is returned by Build.
+type FilterBuilder struct {
+	expr           FilteringExpression
+	includeDeleted bool
+	err            error
+}
+
+// FilterField returns a FieldBuilder for the field referenced by fieldPath,
+// e.g. FilterField("nested", "name") refers to nested.name.
+func FilterField(fieldPath ...string) *FieldBuilder {
+	return &FieldBuilder{fieldPath: fieldPath}
+}
+
+// FromFiltering returns a FilterBuilder initialized with a copy of f, e.g. to add
+// server-side constraints to a filter received from a client:
+//
+//	f, err := query.FromFiltering(userFilter).And(query.FilterField("account_id").Eq(id)).Build()
+//
+// A nil or empty f produces an empty builder, And and Or with an empty builder
+// return the other operand. The IncludeDeleted flag of f is kept by the builder
+// and by every builder combined with it.
+func FromFiltering(f *Filtering) *FilterBuilder {
+	if f == nil || f.Root == nil {
+		return &FilterBuilder{includeDeleted: f.GetIncludeDeleted()}
+	}
+	expr := rootExpression(f)
+	if expr == nil {
+		return &FilterBuilder{err: fmt.Errorf("%T type does not implement FilteringExpression", f.Root)}
+	}
+	return &FilterBuilder{expr: clone(expr), includeDeleted: f.GetIncludeDeleted()}
+}
+
+// Eq returns a builder of the condition field == v.
+// v can be a string, a number, a bool, a time.Time, a *timestamppb.Timestamp or nil.
+func (b *FieldBuilder) Eq(v interface{}) *FilterBuilder {
+	return b.compare(EqToken{}, v)
+}
+
+// Ne returns a builder of the condition field != v, see Eq for supported types of v.
+func (b *FieldBuilder) Ne(v interface{}) *FilterBuilder {
+	return b.compare(NeToken{}, v)
+}
+
+// Gt returns a builder of the condition field > v.
+// v can be a string, a number, a time.Time or a *timestamppb.Timestamp.
+func (b *FieldBuilder) Gt(v interface{}) *FilterBuilder {
+	return b.compare(GtToken{}, v)
+}
+
+// Ge returns a builder of the condition field >= v, see Gt for supported types of v.
+func (b *FieldBuilder) Ge(v interface{}) *FilterBuilder {
+	return b.compare(GeToken{}, v)
+}
+
+// Lt returns a builder of the condition field < v, see Gt for supported types of v.
+func (b *FieldBuilder) Lt(v interface{}) *FilterBuilder {
+	return b.compare(LtToken{}, v)
+}
+
+// Le returns a builder of the condition field <= v, see Gt for supported types of v.
+func (b *FieldBuilder) Le(v interface{}) *FilterBuilder {
+	return b.compare(LeToken{}, v)
+}
+
+// Match returns a builder of the condition field ~ regex.
+func (b *FieldBuilder) Match(regex string) *FilterBuilder {
+	return &FilterBuilder{expr: &StringCondition{FieldPath: b.fieldPath, Value: regex, Type: StringCondition_MATCH}}
+}
+
+// Ieq returns a builder of the case insensitive condition field := v.
+func (b *FieldBuilder) Ieq(v string) *FilterBuilder {
+	return &FilterBuilder{expr: &StringCondition{FieldPath: b.fieldPath, Value: v, Type: StringCondition_IEQ}}
+}
+
+// IsNull returns a builder of the condition field == null.
+func (b *FieldBuilder) IsNull() *FilterBuilder {
+	return b.compare(EqToken{}, nil)
+}
+
+// IsNotNull returns a builder of the condition field != null.
+func (b *FieldBuilder) IsNotNull() *FilterBuilder {
+	return b.compare(NeToken{}, nil)
+}
+
+// In returns a builder of the condition field in [values...].
+// values must be either all strings or all numbers.
+func (b *FieldBuilder) In(values ...interface{}) *FilterBuilder {
+	if len(values) == 0 {
+		return &FilterBuilder{err: fmt.Errorf("in condition on %s requires at least one value", strings.Join(b.fieldPath, "."))}
+	}
+	if _, ok := values[0].(string); ok {
+		c := &StringArrayCondition{FieldPath: b.fieldPath, Type: StringArrayCondition_IN}
+		for _, v := range values {
+			s, ok := v.(string)
+			if !ok {
+				return &FilterBuilder{err: &TypeMismatchError{"string", b.fieldPath}}
+			}
+			c.Values = append(c.Values, s)
+		}
+		return &FilterBuilder{expr: c}
+	}
+	c := &NumberArrayCondition{FieldPath: b.fieldPath, Type: NumberArrayCondition_IN}
+	for _, v := range values {
+		f, ok := toFloat64(v)
+		if !ok {
+			return &FilterBuilder{err: &TypeMismatchError{"number", b.fieldPath}}
+		}
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return &FilterBuilder{err: fmt.Errorf("%v has no number literal", f)}
+		}
+		c.Values = append(c.Values, f)
+	}
+	return &FilterBuilder{expr: c}
+}
+
+// Contains returns a builder of the condition field contains [values...]
+// that requires all values to be elements of a collection field.
+func (b *FieldBuilder) Contains(values ...string) *FilterBuilder {
+	if len(values) == 0 {
+		return &FilterBuilder{err: fmt.Errorf("contains condition on %s requires at least one value", strings.Join(b.fieldPath, "."))}
+	}
+	return &FilterBuilder{expr: &ContainsCondition{FieldPath: b.fieldPath, Values: append([]string(nil), values...), Type: ContainsCondition_CONTAINS}}
+}
+
+// Has returns a builder of the condition field has v
+// that requires v to be either an element or a key of a collection field.
+func (b *FieldBuilder) Has(v string) *FilterBuilder {
+	return &FilterBuilder{expr: &ContainsCondition{FieldPath: b.fieldPath, Values: []string{v}, Type: ContainsCondition_HAS}}
+}
+
+func (b *FieldBuilder) compare(o Token, v interface{}) *FilterBuilder {
+	neg := false
+	if _, ok := o.(NeToken); ok {
+		o, neg = EqToken{}, true
+	}
+	unsupported := func(t string) *FilterBuilder {
+		return &FilterBuilder{err: &UnsupportedOperatorError{t, fmt.Sprint(o)}}
+	}
+
+	switch x := v.(type) {
+	case nil:
+		if _, ok := o.(EqToken); !ok {
+			return unsupported("null")
+		}
+		return &FilterBuilder{expr: &NullCondition{FieldPath: b.fieldPath, IsNegative: neg}}
+	case string:
+		c := &StringCondition{FieldPath: b.fieldPath, Value: x, IsNegative: neg}
+		switch o.(type) {
+		case EqToken:
+			c.Type = StringCondition_EQ
+		case GtToken:
+			c.Type = StringCondition_GT
+		case GeToken:
+			c.Type = StringCondition_GE
+		case LtToken:
+			c.Type = StringCondition_LT
+		case LeToken:
+			c.Type = StringCondition_LE
+		}
+		return &FilterBuilder{expr: c}
+	case bool:
+		if _, ok := o.(EqToken); !ok {
+			return unsupported("bool")
+		}
+		return &FilterBuilder{expr: &BoolCondition{FieldPath: b.fieldPath, Value: x, Type: BoolCondition_EQ, IsNegative: neg}}
+	case time.Time:
+		return b.compareTime(o, timestamppb.New(x), neg)
+	case *timestamppb.Timestamp:
+		return b.compareTime(o, proto.Clone(x).(*timestamppb.Timestamp), neg)
+	}
+
+	f, ok := toFloat64(v)
+	if !ok {
+		return unsupported(fmt.Sprintf("%T", v))
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return &FilterBuilder{err: fmt.Errorf("%v has no number literal", f)}
+	}
+	c := &NumberCondition{FieldPath: b.fieldPath, Value: f, IsNegative: neg}
+	switch o.(type) {
+	case EqToken:
+		c.Type = NumberCondition_EQ
+	case GtToken:
+		c.Type = NumberCondition_GT
+	case GeToken:
+		c.Type = NumberCondition_GE
+	case LtToken:
+		c.Type = NumberCondition_LT
+	case LeToken:
+		c.Type = NumberCondition_LE
+	}
+	return &FilterBuilder{expr: c}
+}
+
+func (b *FieldBuilder) compareTime(o Token, v *timestamppb.Timestamp, neg bool) *FilterBuilder {
+	c := &TimeCondition{FieldPath: b.fieldPath, Value: v, IsNegative: neg}
+	switch o.(type) {
+	case EqToken:
+		c.Type = TimeCondition_EQ
+	case GtToken:
+		c.Type = TimeCondition_GT
+	case GeToken:
+		c.Type = TimeCondition_GE
+	case LtToken:
+		c.Type = TimeCondition_LT
+	case LeToken:
+		c.Type = TimeCondition_LE
+	}
+	return &FilterBuilder{expr: c}
+}
+
+// And returns a builder of the conjunction of b and other.
+func (b *FilterBuilder) And(other *FilterBuilder) *FilterBuilder {
+	return b.combine(LogicalOperator_AND, other)
+}
+
+// Or returns a builder of the disjunction of b and other.
+func (b *FilterBuilder) Or(other *FilterBuilder) *FilterBuilder {
+	return b.combine(LogicalOperator_OR, other)
+}
+
+// Not returns a builder of the negation of b.
+func (b *FilterBuilder) Not() *FilterBuilder {
+	if b.err != nil || b.expr == nil {
+		return b
+	}
+	expr := clone(b.expr)
+	negateExpression(expr)
+	return &FilterBuilder{expr: expr, includeDeleted: b.includeDeleted}
+}
+
+func (b *FilterBuilder) combine(t LogicalOperator_Type, other *FilterBuilder) *FilterBuilder {
+	switch {
+	case b.err != nil:
+		return b
+	case other == nil:
+		return b
+	case other.err != nil:
+		return other
+	}
+	includeDeleted := b.includeDeleted || other.includeDeleted
+	switch {
+	case b.expr == nil:
+		return &FilterBuilder{expr: other.expr, includeDeleted: includeDeleted}
+	case other.expr == nil:
+		return &FilterBuilder{expr: b.expr, includeDeleted: includeDeleted}
+	}
+	lop := &LogicalOperator{Type: t}
+	if err := lop.SetLeft(b.expr); err != nil {
+		return &FilterBuilder{err: err}
+	}
+	if err := lop.SetRight(other.expr); err != nil {
+		return &FilterBuilder{err: err}
+	}
+	return &FilterBuilder{expr: lop, includeDeleted: includeDeleted}
+}
+
+// Build returns the filtering expression built by b.
+// An empty builder produces nil Filtering that matches everything, unless
+// it includes deleted records, then the Filtering has no root.
+func (b *FilterBuilder) Build() (*Filtering, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.expr == nil {
+		if b.includeDeleted {
+			return &Filtering{IncludeDeleted: true}, nil
+		}
+		return nil, nil
+	}
+	f := &Filtering{IncludeDeleted: b.includeDeleted}
+	if err := f.SetRoot(clone(b.expr)); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func clone(expr FilteringExpression) FilteringExpression {
+	if m, ok := expr.(proto.Message); ok {
+		return proto.Clone(m).(FilteringExpression)
+	}
+	return expr
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
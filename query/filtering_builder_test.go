@@ -0,0 +1,151 @@
+package query
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterBuilder(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		b   *FilterBuilder
+		exp string
+	}{
+		{
+			b:   FilterField("a", "b").Eq("x").And(FilterField("n").Gt(3)).Not(),
+			exp: "not (a.b == 'x' and n > 3)",
+		},
+		{
+			b:   FilterField("a").Ne("x").Or(FilterField("b").Match("^y").Not()).And(FilterField("c").Ieq("Z")),
+			exp: "(a != 'x' or b !~ '^y') and c := 'Z'",
+		},
+		{
+			b:   FilterField("n").Ge(uint8(1)).And(FilterField("n").Lt(2.5)).And(FilterField("n").Le(int64(10)).Not()),
+			exp: "n >= 1 and n < 2.5 and not n <= 10",
+		},
+		{
+			b:   FilterField("p").IsNull().Or(FilterField("p").IsNotNull().And(FilterField("p").Ne(nil))),
+			exp: "p == null or p != null and p != null",
+		},
+		{
+			b:   FilterField("s").In("x", "y").And(FilterField("n").In(1, 2.5)),
+			exp: "s in ['x', 'y'] and n in [1, 2.5]",
+		},
+		{
+			b:   FilterField("t").Gt(ts).And(FilterField("t").Ne(ts.Add(time.Hour))).And(FilterField("enabled").Eq(true)),
+			exp: "t > 2024-01-01T00:00:00Z and t != 2024-01-01T01:00:00Z and enabled == true",
+		},
+		{
+			b:   FilterField("a").Eq(1).And(FilterField("b").Eq(2).Or(FilterField("c").Eq(3))),
+			exp: "a == 1 and (b == 2 or c == 3)",
+		},
+		{
+			b:   FilterField("tags").Contains("x", "y").And(FilterField("labels").Has("env").Not()),
+			exp: "tags contains ['x', 'y'] and not labels has 'env'",
+		},
+	}
+
+	for _, test := range tests {
+		f, err := test.b.Build()
+		assert.NoError(t, err)
+		assert.Equal(t, test.exp, f.GoString())
+
+		parsed, err := ParseFiltering(test.exp)
+		assert.NoError(t, err)
+		assert.True(t, proto.Equal(parsed, f), test.exp)
+	}
+}
+
+func TestFilterBuilderNegative(t *testing.T) {
+	tests := []struct {
+		b   *FilterBuilder
+		err error
+	}{
+		{FilterField("a").Gt(true), &UnsupportedOperatorError{}},
+		{FilterField("a").Lt(nil), &UnsupportedOperatorError{}},
+		{FilterField("a").Eq(struct{}{}), &UnsupportedOperatorError{}},
+		{FilterField("a").In("x", 1), &TypeMismatchError{}},
+		{FilterField("a").In(1, "x"), &TypeMismatchError{}},
+		{FilterField("a").Eq(1).And(FilterField("b").Gt(false)).Not(), &UnsupportedOperatorError{}},
+		{FilterField("b").Gt(false).Or(FilterField("a").Eq(1)), &UnsupportedOperatorError{}},
+	}
+
+	for _, test := range tests {
+		f, err := test.b.Build()
+		assert.Nil(t, f)
+		assert.IsType(t, test.err, err)
+	}
+
+	_, err := FilterField("a").In().Build()
+	assert.Error(t, err)
+	_, err = FilterField("a").Contains().Build()
+	assert.Error(t, err)
+	_, err = FilterField("a").Gt(math.NaN()).Build()
+	assert.Error(t, err)
+	_, err = FilterField("a").In(1, math.Inf(-1)).Build()
+	assert.Error(t, err)
+}
+
+func TestFromFiltering(t *testing.T) {
+	user, err := ParseFiltering("name == 'x' or not id == 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := user.GoString()
+
+	constrained := FromFiltering(user).And(FilterField("account_id").Eq("acc"))
+	f, err := constrained.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "(name == 'x' or id != 1) and account_id == 'acc'", f.GoString())
+
+	// neither the user filter nor the builder are affected by further changes
+	f, err = constrained.Not().Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "not ((name == 'x' or id != 1) and account_id == 'acc')", f.GoString())
+	f.Root.(*Filtering_Operator).Operator.IsNegative = false
+	f, err = constrained.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "(name == 'x' or id != 1) and account_id == 'acc'", f.GoString())
+	assert.Equal(t, orig, user.GoString())
+
+	f, err = FromFiltering(nil).And(FilterField("account_id").Eq("acc")).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "account_id == 'acc'", f.GoString())
+
+	f, err = FromFiltering(&Filtering{}).Build()
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+}
+
+func TestFromFilteringIncludeDeleted(t *testing.T) {
+	user, err := ParseFiltering("name == 'x'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user.IncludeDeleted = true
+
+	f, err := FromFiltering(user).And(FilterField("account_id").Eq("acc")).Not().Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "not (name == 'x' and account_id == 'acc')", f.GoString())
+	assert.True(t, f.GetIncludeDeleted())
+
+	f, err = FilterField("account_id").Eq("acc").And(FromFiltering(&Filtering{IncludeDeleted: true})).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "account_id == 'acc'", f.GoString())
+	assert.True(t, f.GetIncludeDeleted())
+
+	f, err = FromFiltering(&Filtering{IncludeDeleted: true}).Build()
+	assert.NoError(t, err)
+	if assert.NotNil(t, f) {
+		assert.Nil(t, f.Root)
+		assert.True(t, f.GetIncludeDeleted())
+	}
+
+	f, err = FilterField("account_id").Eq("acc").Build()
+	assert.NoError(t, err)
+	assert.False(t, f.GetIncludeDeleted())
+}
@@ -0,0 +1,208 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatFiltering is a shortcut to get a canonical string representation of the filtering expression,
+// see Filtering.GoString.
+func FormatFiltering(f *Filtering) string {
+	return f.GoString()
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the filtering expression
+// that conforms to REST API Syntax Specification and parses back into an equal expression.
+// Parentheses are only used where required by operator precedence, negated == and ~ conditions
+// are formatted as != and !~ respectively, time literals are formatted in RFC3339 format in UTC.
+// NaN and infinite numbers have no literal, expressions that contain them do not parse back.
+func (m *Filtering) GoString() string {
+	if expr, ok := rootExpression(m).(fmt.GoStringer); ok {
+		return expr.GoString()
+	}
+	return ""
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the logical operator, see Filtering.GoString.
+func (m *LogicalOperator) GoString() string {
+	left, _ := leftExpression(m).(fmt.GoStringer)
+	right, _ := rightExpression(m).(fmt.GoStringer)
+
+	o := "and"
+	if m.GetType() == LogicalOperator_OR {
+		o = "or"
+	}
+	s := fmt.Sprintf("%s %s %s", m.formatOperand(left, false), o, m.formatOperand(right, true))
+	if m.GetIsNegative() {
+		return "not (" + s + ")"
+	}
+	return s
+}
+
+// formatOperand encloses operand in parentheses if it is a logical operator that
+// binds weaker than m, or is a right operand of the same type, since the parser
+// builds left-associative trees.
+func (m *LogicalOperator) formatOperand(operand fmt.GoStringer, right bool) string {
+	if operand == nil {
+		return ""
+	}
+	if lop, ok := operand.(*LogicalOperator); ok && !lop.GetIsNegative() {
+		if (lop.GetType() == LogicalOperator_OR && m.GetType() == LogicalOperator_AND) ||
+			(right && lop.GetType() == m.GetType()) {
+			return "(" + lop.GoString() + ")"
+		}
+	}
+	return operand.GoString()
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the string condition, see Filtering.GoString.
+func (c *StringCondition) GoString() string {
+	var o string
+	neg := c.GetIsNegative()
+	switch c.GetType() {
+	case StringCondition_EQ:
+		o = "=="
+		if neg {
+			o, neg = "!=", false
+		}
+	case StringCondition_MATCH:
+		o = "~"
+		if neg {
+			o, neg = "!~", false
+		}
+	case StringCondition_GT:
+		o = ">"
+	case StringCondition_GE:
+		o = ">="
+	case StringCondition_LT:
+		o = "<"
+	case StringCondition_LE:
+		o = "<="
+	case StringCondition_IEQ:
+		o = ":="
+	}
+	return formatCondition(c.GetFieldPath(), o, quoteString(c.GetValue()), neg)
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the number condition, see Filtering.GoString.
+func (c *NumberCondition) GoString() string {
+	var o string
+	neg := c.GetIsNegative()
+	switch c.GetType() {
+	case NumberCondition_EQ:
+		o = "=="
+		if neg {
+			o, neg = "!=", false
+		}
+	case NumberCondition_GT:
+		o = ">"
+	case NumberCondition_GE:
+		o = ">="
+	case NumberCondition_LT:
+		o = "<"
+	case NumberCondition_LE:
+		o = "<="
+	}
+	return formatCondition(c.GetFieldPath(), o, formatNumber(c.GetValue()), neg)
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the null condition, see Filtering.GoString.
+func (c *NullCondition) GoString() string {
+	if c.GetIsNegative() {
+		return formatCondition(c.GetFieldPath(), "!=", "null", false)
+	}
+	return formatCondition(c.GetFieldPath(), "==", "null", false)
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the string array condition, see Filtering.GoString.
+func (c *StringArrayCondition) GoString() string {
+	values := make([]string, 0, len(c.GetValues()))
+	for _, v := range c.GetValues() {
+		values = append(values, quoteString(v))
+	}
+	return formatCondition(c.GetFieldPath(), "in", "["+strings.Join(values, ", ")+"]", c.GetIsNegative())
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the number array condition, see Filtering.GoString.
+func (c *NumberArrayCondition) GoString() string {
+	values := make([]string, 0, len(c.GetValues()))
+	for _, v := range c.GetValues() {
+		values = append(values, formatNumber(v))
+	}
+	return formatCondition(c.GetFieldPath(), "in", "["+strings.Join(values, ", ")+"]", c.GetIsNegative())
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the time condition, see Filtering.GoString.
+func (c *TimeCondition) GoString() string {
+	var o string
+	neg := c.GetIsNegative()
+	switch c.GetType() {
+	case TimeCondition_EQ:
+		o = "=="
+		if neg {
+			o, neg = "!=", false
+		}
+	case TimeCondition_GT:
+		o = ">"
+	case TimeCondition_GE:
+		o = ">="
+	case TimeCondition_LT:
+		o = "<"
+	case TimeCondition_LE:
+		o = "<="
+	}
+	if c.GetRelative() != "" {
+		return formatCondition(c.GetFieldPath(), o, c.GetRelative(), neg)
+	}
+	return formatCondition(c.GetFieldPath(), o, c.GetValue().AsTime().Format(time.RFC3339Nano), neg)
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the bool condition, see Filtering.GoString.
+func (c *BoolCondition) GoString() string {
+	o := "=="
+	if c.GetIsNegative() {
+		o = "!="
+	}
+	return formatCondition(c.GetFieldPath(), o, strconv.FormatBool(c.GetValue()), false)
+}
+
+// GoString implements fmt.GoStringer interface
+// Returns a canonical string representation of the contains condition, see Filtering.GoString.
+func (c *ContainsCondition) GoString() string {
+	values := make([]string, 0, len(c.GetValues()))
+	for _, v := range c.GetValues() {
+		values = append(values, quoteString(v))
+	}
+	if c.GetType() == ContainsCondition_HAS && len(values) == 1 {
+		return formatCondition(c.GetFieldPath(), "has", values[0], c.GetIsNegative())
+	}
+	return formatCondition(c.GetFieldPath(), "contains", "["+strings.Join(values, ", ")+"]", c.GetIsNegative())
+}
+
+func formatCondition(fieldPath []string, o, value string, neg bool) string {
+	s := fmt.Sprintf("%s %s %s", strings.Join(fieldPath, "."), o, value)
+	if neg {
+		return "not " + s
+	}
+	return s
+}
+
+// quoteString returns a single-quoted string literal with single quotes escaped by duplication.
+func quoteString(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatFiltering(t *testing.T) {
+	tests := []struct {
+		text string
+		exp  string
+	}{
+		{
+			text: "not(not(not field1 == 'abc' or not field2 == 'bcd') and (field3 != 'cde'))",
+			exp:  "not (not (field1 != 'abc' or field2 != 'bcd') and field3 != 'cde')",
+		},
+		{
+			text: "(a == 1 or b == 2) and (c == 3 or d == 4)",
+			exp:  "(a == 1 or b == 2) and (c == 3 or d == 4)",
+		},
+		{
+			text: "a == 1 or (b == 2 or c == 3)",
+			exp:  "a == 1 or (b == 2 or c == 3)",
+		},
+		{
+			text: "((a == 1 and b == 2) and c == 3) or d == 4 and e == 5",
+			exp:  "a == 1 and b == 2 and c == 3 or d == 4 and e == 5",
+		},
+		{
+			text: "not a ~ 'x.*' and not b !~ 'y' and c := 'AbC' and not d > 'e'",
+			exp:  "a !~ 'x.*' and b ~ 'y' and c := 'AbC' and not d > 'e'",
+		},
+		{
+			text: "n.m >= 1.50 and n.m < 100000000 and not n.m ne null",
+			exp:  "n.m >= 1.5 and n.m < 100000000 and n.m == null",
+		},
+		{
+			text: `s in ["it's", 'a''b'] or not n in [1,2.5]`,
+			exp:  `s in ['it''s', 'a''b'] or not n in [1, 2.5]`,
+		},
+		{
+			text: "t >= 2024-01-01 and t < 2024-01-01T12:00:00.5+02:00 and b != false",
+			exp:  "t >= 2024-01-01T00:00:00Z and t < 2024-01-01T10:00:00.5Z and b != false",
+		},
+		{
+			text: "tags contains 'a' and not tags contains [\"b\", 'c'] and labels has 'it''s'",
+			exp:  "tags contains ['a'] and not tags contains ['b', 'c'] and labels has 'it''s'",
+		},
+		{
+			text: "n > -3 and n <= -0.25 and not n in [-1, 0, 1]",
+			exp:  "n > -3 and n <= -0.25 and not n in [-1, 0, 1]",
+		},
+		{
+			text: "",
+			exp:  "",
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.text)
+		if !assert.NoError(t, err, test.text) {
+			continue
+		}
+		assert.Equal(t, test.exp, FormatFiltering(f), test.text)
+	}
+}
+
+func TestFormatFilteringRoundTrip(t *testing.T) {
+	for _, test := range filteringParserTests {
+		s := test.exp.GoString()
+		f, err := ParseFiltering(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, test.exp, f, "%s formatted as %s", test.text, s)
+		assert.Equal(t, s, f.GoString())
+	}
+
+	// right-nested trees are not produced by the parser, but must be preserved
+	f := &Filtering{}
+	f.SetRoot(&LogicalOperator{
+		Left: &LogicalOperator_LeftNullCondition{&NullCondition{FieldPath: []string{"a"}}},
+		Right: &LogicalOperator_RightOperator{&LogicalOperator{
+			Left:  &LogicalOperator_LeftBoolCondition{&BoolCondition{FieldPath: []string{"b"}, Value: true}},
+			Right: &LogicalOperator_RightNumberCondition{&NumberCondition{FieldPath: []string{"c"}, Value: 1, Type: NumberCondition_GT, IsNegative: true}},
+		}},
+	})
+	assert.Equal(t, "a == null and (b == true and not c > 1)", f.GoString())
+	res, err := ParseFiltering(f.GoString())
+	assert.NoError(t, err)
+	assert.Equal(t, f, res)
+
+	for _, b := range []*FilterBuilder{
+		FilterField("n").Gt(-3),
+		FilterField("n").Le(-1e-7),
+		FilterField("n").Ne(-1234567.125),
+		FilterField("n").In(-1, 2, -3.5),
+	} {
+		f, err := b.Build()
+		if !assert.NoError(t, err) {
+			continue
+		}
+		res, err := ParseFiltering(f.GoString())
+		assert.NoError(t, err, f.GoString())
+		assert.True(t, proto.Equal(f, res), f.GoString())
+	}
+}
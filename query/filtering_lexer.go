@@ -3,9 +3,14 @@ package query
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 	"unicode"
 )
 
+// timeNow returns the current time that relative timestamp literals are resolved against.
+var timeNow = time.Now
+
 // FilteringLexer is impemented by lexical analyzers that are used by filtering expression parsers.
 type FilteringLexer interface {
 	NextToken() (Token, error)
@@ -85,6 +90,34 @@ func (t StringToken) String() string {
 	return fmt.Sprint(t.Value)
 }
 
+// TimeToken represents a timestamp literal, either absolute in RFC3339 format
+// (e.g. 2024-01-01T00:00:00Z or 2024-01-01) or relative to the current time
+// (e.g. now(), now()-7d, now()+1h30m).
+// Value is a value of the literal, Relative is the relative literal Value is resolved from.
+type TimeToken struct {
+	TokenBase
+	Value    time.Time
+	Relative string
+}
+
+func (t TimeToken) String() string {
+	if t.Relative != "" {
+		return t.Relative
+	}
+	return t.Value.Format(time.RFC3339Nano)
+}
+
+// BoolToken represents a boolean literal, either true or false.
+// Value is a value of the literal.
+type BoolToken struct {
+	TokenBase
+	Value bool
+}
+
+func (t BoolToken) String() string {
+	return fmt.Sprint(t.Value)
+}
+
 // FieldToken represents a reference to a value of a resource.
 // Value is a value of the reference.
 type FieldToken struct {
@@ -221,6 +254,24 @@ func (t InToken) String() string {
 	return "in"
 }
 
+// ContainsToken represents contains operation for collections, e.g. tags contains ['a', 'b'].
+type ContainsToken struct {
+	TokenBase
+}
+
+func (t ContainsToken) String() string {
+	return "contains"
+}
+
+// HasToken represents has operation for collections, e.g. labels has 'env'.
+type HasToken struct {
+	TokenBase
+}
+
+func (t HasToken) String() string {
+	return "has"
+}
+
 // NumberArrayToken represent number array e.g. [1,2,5]
 type StringArrayToken struct {
 	TokenBase
@@ -267,11 +318,23 @@ func (lexer *filteringLexer) advance() {
 	}
 }
 
+// number lexes a number literal with an optional leading minus sign.
 func (lexer *filteringLexer) number() (Token, error) {
+	sign := ""
+	if lexer.curChar == '-' {
+		sign = "-"
+		lexer.advance()
+		if !unicode.IsDigit(lexer.curChar) {
+			return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+		}
+	}
 	number := string(lexer.curChar)
 	metDot := false
 	lexer.advance()
 	for !lexer.eof {
+		if sign == "" && !metDot && len(number) == 4 && lexer.curChar == '-' {
+			return lexer.timestamp(number)
+		}
 		if unicode.IsDigit(lexer.curChar) {
 			number += string(lexer.curChar)
 		} else if !metDot && lexer.curChar == '.' {
@@ -282,13 +345,81 @@ func (lexer *filteringLexer) number() (Token, error) {
 		}
 		lexer.advance()
 	}
-	parsed, err := strconv.ParseFloat(number, 64)
+	parsed, err := strconv.ParseFloat(sign+number, 64)
 	if err != nil {
 		return nil, err
 	}
 	return NumberToken{Value: parsed}, nil
 }
 
+func (lexer *filteringLexer) timestamp(prefix string) (Token, error) {
+	pos := lexer.pos - len(prefix)
+	ts := prefix
+	for !lexer.eof {
+		if unicode.IsDigit(lexer.curChar) || strings.ContainsRune("-:.+TZtz", lexer.curChar) {
+			ts += string(lexer.curChar)
+		} else {
+			break
+		}
+		lexer.advance()
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.ToUpper(ts)); err == nil {
+			return TimeToken{Value: t}, nil
+		}
+	}
+	return nil, &UnexpectedSymbolError{[]rune(ts)[0], pos}
+}
+
+// relativeTimestamp lexes the remainder of now() literal with an optional offset,
+// e.g. now()-7d. Supported offset units are w, d, h, m, s and their combinations.
+func (lexer *filteringLexer) relativeTimestamp() (Token, error) {
+	start := lexer.pos
+	lexer.advance()
+	if lexer.curChar != ')' {
+		return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+	}
+	lexer.advance()
+	t := timeNow().UTC()
+	if lexer.curChar != '-' && lexer.curChar != '+' {
+		return TimeToken{Value: t, Relative: "now()"}, nil
+	}
+	sign := time.Duration(1)
+	if lexer.curChar == '-' {
+		sign = -1
+	}
+	lexer.advance()
+	if !unicode.IsDigit(lexer.curChar) {
+		return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+	}
+	var offset time.Duration
+	for !lexer.eof && unicode.IsDigit(lexer.curChar) {
+		n := 0
+		for !lexer.eof && unicode.IsDigit(lexer.curChar) {
+			n = n*10 + int(lexer.curChar-'0')
+			lexer.advance()
+		}
+		var unit time.Duration
+		switch lexer.curChar {
+		case 'w':
+			unit = 7 * 24 * time.Hour
+		case 'd':
+			unit = 24 * time.Hour
+		case 'h':
+			unit = time.Hour
+		case 'm':
+			unit = time.Minute
+		case 's':
+			unit = time.Second
+		default:
+			return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
+		}
+		lexer.advance()
+		offset += time.Duration(n) * unit
+	}
+	return TimeToken{Value: t.Add(sign * offset), Relative: "now" + string(lexer.text[start:lexer.pos])}, nil
+}
+
 func (lexer *filteringLexer) string() (Token, error) {
 	// Add quote escaping support
 	term := lexer.curChar
@@ -318,7 +449,7 @@ func (lexer *filteringLexer) array() (Token, error) {
 		return nil, &UnexpectedSymbolError{lexer.curChar, lexer.pos}
 	}
 
-	if unicode.IsDigit(lexer.curChar) {
+	if unicode.IsDigit(lexer.curChar) || lexer.curChar == '-' {
 		values := make([]float64, 0)
 		for lexer.curChar != term {
 			if unicode.IsSpace(lexer.curChar) || lexer.curChar == ',' {
@@ -414,6 +545,10 @@ func (lexer *filteringLexer) fieldOrReserved() (Token, error) {
 		return NotToken{}, nil
 	case "null":
 		return NullToken{}, nil
+	case "true":
+		return BoolToken{Value: true}, nil
+	case "false":
+		return BoolToken{Value: false}, nil
 	case "eq":
 		return EqToken{}, nil
 	case "ne":
@@ -432,8 +567,17 @@ func (lexer *filteringLexer) fieldOrReserved() (Token, error) {
 		return NmatchToken{}, nil
 	case "in":
 		return InToken{}, nil
+	case "contains":
+		return ContainsToken{}, nil
+	case "has":
+		return HasToken{}, nil
 	case "ieq":
 		return InsensitiveEqToken{}, nil
+	case "now":
+		if lexer.curChar == '(' {
+			return lexer.relativeTimestamp()
+		}
+		return FieldToken{Value: s}, nil
 	default:
 		return FieldToken{Value: s}, nil
 	}
@@ -497,7 +641,7 @@ func (lexer *filteringLexer) NextToken() (Token, error) {
 			return lexer.string()
 		case lexer.curChar == '[':
 			return lexer.array()
-		case unicode.IsDigit(lexer.curChar):
+		case unicode.IsDigit(lexer.curChar) || lexer.curChar == '-':
 			return lexer.number()
 		case unicode.IsLetter(lexer.curChar):
 			return lexer.fieldOrReserved()
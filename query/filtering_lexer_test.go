@@ -2,12 +2,13 @@ package query
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestFilteringLexer(t *testing.T) {
-	lexer := NewFilteringLexer(`()14 13.23 'abc'"bcd" field1 and or  not == eq ne != match ~ nomatch !~ gt > ge >= lt < le <= null := ieq [1,5, 6] ['Hello','World'] in '''""' """''"`)
+	lexer := NewFilteringLexer(`()14 13.23 'abc'"bcd" field1 and or  not == eq ne != match ~ nomatch !~ gt > ge >= lt < le <= null true false := ieq [1,5, 6] ['Hello','World'] -7 -0.5 [-1, 2] in contains has '''""' """''"`)
 	tests := []Token{
 		LparenToken{},
 		RparenToken{},
@@ -36,11 +37,18 @@ func TestFilteringLexer(t *testing.T) {
 		LeToken{},
 		LeToken{},
 		NullToken{},
+		BoolToken{Value: true},
+		BoolToken{Value: false},
 		InsensitiveEqToken{},
 		InsensitiveEqToken{},
 		NumberArrayToken{Values: []float64{1, 5, 6}},
 		StringArrayToken{Values: []string{"Hello", "World"}},
+		NumberToken{Value: -7},
+		NumberToken{Value: -0.5},
+		NumberArrayToken{Values: []float64{-1, 2}},
 		InToken{},
+		ContainsToken{},
+		HasToken{},
 		// duplicate terminator to escape
 		StringToken{Value: `'""`},
 		StringToken{Value: `"''`},
@@ -64,6 +72,9 @@ func TestFilteringLexerNegative(t *testing.T) {
 		"['Hello', 1, 2]",
 		"[1, 2",
 		"['Hello'",
+		"-",
+		"- 1",
+		"[1, -]",
 	}
 
 	for _, test := range tests {
@@ -74,3 +85,41 @@ func TestFilteringLexerNegative(t *testing.T) {
 	}
 
 }
+
+func TestFilteringLexerTime(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	lexer := NewFilteringLexer(`2024-01-01T10:20:30Z 2024-01-01T10:20:30.5+02:00 2024-01-01 now() now()-7d now()+1h30m now 2024`)
+	tests := []Token{
+		TimeToken{Value: time.Date(2024, 1, 1, 10, 20, 30, 0, time.UTC)},
+		TimeToken{Value: time.Date(2024, 1, 1, 10, 20, 30, 500000000, time.FixedZone("", 2*60*60))},
+		TimeToken{Value: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		TimeToken{Value: now, Relative: "now()"},
+		TimeToken{Value: now.Add(-7 * 24 * time.Hour), Relative: "now()-7d"},
+		TimeToken{Value: now.Add(90 * time.Minute), Relative: "now()+1h30m"},
+		FieldToken{Value: "now"},
+		NumberToken{Value: 2024},
+		EOFToken{},
+	}
+
+	for _, test := range tests {
+		token, err := lexer.NextToken()
+		assert.Nil(t, err)
+		if tt, ok := test.(TimeToken); ok {
+			assert.IsType(t, TimeToken{}, token)
+			assert.True(t, tt.Value.Equal(token.(TimeToken).Value), "%s != %s", tt, token)
+			assert.Equal(t, tt.Relative, token.(TimeToken).Relative)
+			continue
+		}
+		assert.Equal(t, test, token)
+	}
+
+	for _, test := range []string{"2024-13-01", "2024-01-01T10", "now(", "now()-7", "now()-7y"} {
+		lexer := NewFilteringLexer(test)
+		token, err := lexer.NextToken()
+		assert.Nil(t, token)
+		assert.IsType(t, &UnexpectedSymbolError{}, err)
+	}
+}
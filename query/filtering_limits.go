@@ -0,0 +1,123 @@
+package query
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/errors"
+)
+
+// filteringLimitsTarget is a target of field errors that are not related to a particular field path.
+const filteringLimitsTarget = "_filter"
+
+// FilteringLimits restricts complexity of filtering expressions.
+// Zero value of a limit means that it is not enforced.
+type FilteringLimits struct {
+	// MaxDepth is a maximum nesting depth of an expression, a single condition has depth 1,
+	// a chain of the same logical operator adds one level, e.g. a == 1 and b == 2 and (c == 3 or d == 4)
+	// has depth 3.
+	MaxDepth int
+	// MaxConditions is a maximum number of conditions in an expression.
+	MaxConditions int
+	// MaxInListSize is a maximum number of values of in and contains conditions.
+	MaxInListSize int
+	// MaxStringLength is a maximum length of string literals in characters, including regular expressions.
+	MaxStringLength int
+}
+
+// ParseFilteringWithLimits is a shortcut to parse a filtering expression using
+// default FilteringParser implementation that enforces limits l.
+func ParseFilteringWithLimits(text string, l FilteringLimits) (*Filtering, error) {
+	return (&filteringParser{limits: l}).Parse(text)
+}
+
+// NewFilteringParserWithLimits returns a default FilteringParser implementation
+// that rejects expressions exceeding limits l.
+func NewFilteringParserWithLimits(l FilteringLimits) FilteringParser {
+	return &filteringParser{limits: l}
+}
+
+// Validate returns an error if filtering expression f exceeds limits l.
+// Each violation is reported as a field error of the returned errors.Container with
+// codes.InvalidArgument, the target of a field error is the offending field path or
+// "_filter" for limits of the whole expression.
+func (l FilteringLimits) Validate(f *Filtering) error {
+	expr := rootExpression(f)
+	if expr == nil || l == (FilteringLimits{}) {
+		return nil
+	}
+	errC := errors.InitContainer()
+	if depth := filteringDepth(expr, nil); l.MaxDepth > 0 && depth > l.MaxDepth {
+		errC.WithField(filteringLimitsTarget, "Filtering expression depth %d exceeds the limit of %d.", depth, l.MaxDepth)
+	}
+	conditions := 0
+	l.validateConditions(errC, expr, &conditions)
+	if l.MaxConditions > 0 && conditions > l.MaxConditions {
+		errC.WithField(filteringLimitsTarget, "Filtering expression has %d conditions, the limit is %d.", conditions, l.MaxConditions)
+	}
+	return errC.IfSet(codes.InvalidArgument, "Filtering expression exceeds limits.")
+}
+
+func (l FilteringLimits) validateConditions(errC *errors.Container, expr FilteringExpression, conditions *int) {
+	if lop, ok := expr.(*LogicalOperator); ok {
+		l.validateConditions(errC, leftExpression(lop), conditions)
+		l.validateConditions(errC, rightExpression(lop), conditions)
+		return
+	}
+	*conditions++
+
+	var (
+		fieldPath []string
+		strs      []string
+		size      int
+	)
+	switch c := expr.(type) {
+	case *StringCondition:
+		fieldPath, strs = c.GetFieldPath(), []string{c.GetValue()}
+	case *StringArrayCondition:
+		fieldPath, strs, size = c.GetFieldPath(), c.GetValues(), len(c.GetValues())
+	case *NumberArrayCondition:
+		fieldPath, size = c.GetFieldPath(), len(c.GetValues())
+	case *ContainsCondition:
+		fieldPath, strs, size = c.GetFieldPath(), c.GetValues(), len(c.GetValues())
+	default:
+		return
+	}
+	fp := strings.Join(fieldPath, ".")
+	if l.MaxInListSize > 0 && size > l.MaxInListSize {
+		errC.WithField(fp, "In list of %d values exceeds the limit of %d.", size, l.MaxInListSize)
+	}
+	if l.MaxStringLength > 0 {
+		for _, s := range strs {
+			if n := utf8.RuneCountInString(s); n > l.MaxStringLength {
+				errC.WithField(fp, "String literal of %d characters exceeds the limit of %d.", n, l.MaxStringLength)
+				break
+			}
+		}
+	}
+}
+
+// filteringDepth returns nesting depth of expr, chains of the same logical operator
+// (e.g. a == 1 and b == 2 and c == 3) count as a single level.
+func filteringDepth(expr FilteringExpression, parent *LogicalOperator) int {
+	lop, ok := expr.(*LogicalOperator)
+	if !ok {
+		return 1
+	}
+	depth := filteringDepth(leftExpression(lop), lop)
+	if right := filteringDepth(rightExpression(lop), lop); right > depth {
+		depth = right
+	}
+	if parent != nil && !lop.GetIsNegative() && lop.GetType() == parent.GetType() {
+		return depth
+	}
+	return depth + 1
+}
+
+// limitsExceeded returns an error that reports a limit of the whole expression exceeded while parsing.
+func limitsExceeded(format string, args ...interface{}) error {
+	return errors.NewContainer(codes.InvalidArgument, "Filtering expression exceeds limits.").
+		WithField(filteringLimitsTarget, format, args...)
+}
@@ -0,0 +1,90 @@
+package query
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errfields"
+)
+
+func TestFilteringLimits(t *testing.T) {
+	l := FilteringLimits{MaxDepth: 3, MaxConditions: 4, MaxInListSize: 3, MaxStringLength: 5}
+
+	tests := []struct {
+		text   string
+		fields map[string][]string
+	}{
+		{
+			text: "a == 'abcde' and (b in [1, 2, 3] or c in ['x', 'y', 'z']) and d ~ 'ё.*'",
+		},
+		{
+			text: "((a == 1))",
+		},
+		{
+			text: "a == 1 and (b == 2 or not (c == 3 and d == 4))",
+			fields: map[string][]string{
+				"_filter": {"Filtering expression depth 4 exceeds the limit of 3."},
+			},
+		},
+		{
+			text: "a == 1 or b == 2 or c == 3 or d == 4 or e == 5",
+			fields: map[string][]string{
+				"_filter": {"Filtering expression has more conditions than the limit of 4."},
+			},
+		},
+		{
+			text: "((((a == 1))))",
+			fields: map[string][]string{
+				"_filter": {"Filtering expression is nested deeper than the limit of 3."},
+			},
+		},
+		{
+			text: "a in [1, 2, 3, 4] and b.c in ['a', 'b', 'c', 'd'] and d ~ 'abcdef' and e contains ['abcdef', 'a', 'b', 'c']",
+			fields: map[string][]string{
+				"a":   {"In list of 4 values exceeds the limit of 3."},
+				"b.c": {"In list of 4 values exceeds the limit of 3."},
+				"d":   {"String literal of 6 characters exceeds the limit of 5."},
+				"e":   {"In list of 4 values exceeds the limit of 3.", "String literal of 6 characters exceeds the limit of 5."},
+			},
+		},
+	}
+
+	p := NewFilteringParserWithLimits(l)
+	for _, test := range tests {
+		f, err := p.Parse(test.text)
+		if test.fields == nil {
+			assert.NoError(t, err, test.text)
+			assert.NotNil(t, f, test.text)
+			continue
+		}
+		assert.Nil(t, f, test.text)
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code(), test.text)
+		if assert.Len(t, st.Details(), 1, test.text) {
+			fields := map[string][]string{}
+			for k, v := range st.Details()[0].(*errfields.FieldInfo).GetFields() {
+				fields[k] = v.GetValues()
+			}
+			assert.Equal(t, test.fields, fields, test.text)
+		}
+	}
+
+	// limits are validated on expressions that were not parsed as well
+	f, err := ParseFiltering("a == 1 or b == 2 or c == 3 or d == 4 or e == '" + strings.Repeat("x", 10) + "'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := status.Convert(l.Validate(f))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Len(t, st.Details()[0].(*errfields.FieldInfo).GetFields(), 2)
+
+	assert.NoError(t, FilteringLimits{}.Validate(f))
+	assert.NoError(t, l.Validate(nil))
+
+	_, err = ParseFilteringWithLimits("a == 1 and b == 2", FilteringLimits{MaxConditions: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -3,6 +3,8 @@ package query
 import (
 	"fmt"
 	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // ParseFiltering is a shortcut to parse a filtering expression using default FilteringParser implementation
@@ -34,15 +36,21 @@ func (e *UnexpectedTokenError) Error() string {
 type filteringParser struct {
 	lexer    FilteringLexer
 	curToken Token
+	limits   FilteringLimits
+	// nesting and conditions track parentheses nesting and the number of conditions
+	// parsed so far, so that exceeding limits are detected before the whole expression is parsed.
+	nesting    int
+	conditions int
 }
 
 // Parse builds an AST from an expression in text according to the following grammar:
 // expr      : term (OR term)*
 // term      : factor (AND factor)*
 // factor    : ?NOT (LPAREN expr RPAREN | condition)
-// condition : FIELD ((== | !=) (STRING | NUMBER | NULL) | (~ | !~) STRING | (> | >= | < | <=) (NUMBER | STRING).
+// condition : FIELD ((== | !=) (STRING | NUMBER | TIME | BOOL | NULL) | (~ | !~) STRING | (> | >= | < | <=) (NUMBER | STRING | TIME) | IN (STRING_ARRAY | NUMBER_ARRAY) | CONTAINS (STRING | STRING_ARRAY) | HAS STRING).
 func (p *filteringParser) Parse(text string) (*Filtering, error) {
 	p.lexer = NewFilteringLexer(text)
+	p.nesting, p.conditions = 0, 0
 	token, err := p.lexer.NextToken()
 	if err != nil {
 		return nil, err
@@ -63,13 +71,16 @@ func (p *filteringParser) Parse(text string) (*Filtering, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := p.limits.Validate(f); err != nil {
+			return nil, err
+		}
 		return f, nil
 	default:
 		return nil, &UnexpectedTokenError{p.curToken}
 	}
 }
 
-func (p *filteringParser) negateNode(node FilteringExpression) {
+func negateExpression(node FilteringExpression) {
 	switch v := node.(type) {
 	case *LogicalOperator:
 		v.IsNegative = !v.IsNegative
@@ -83,6 +94,12 @@ func (p *filteringParser) negateNode(node FilteringExpression) {
 		v.IsNegative = !v.IsNegative
 	case *NumberArrayCondition:
 		v.IsNegative = !v.IsNegative
+	case *TimeCondition:
+		v.IsNegative = !v.IsNegative
+	case *BoolCondition:
+		v.IsNegative = !v.IsNegative
+	case *ContainsCondition:
+		v.IsNegative = !v.IsNegative
 	}
 }
 
@@ -164,6 +181,10 @@ func (p *filteringParser) factor() (FilteringExpression, error) {
 	}
 	switch p.curToken.(type) {
 	case LparenToken:
+		p.nesting++
+		if p.limits.MaxDepth > 0 && p.nesting > p.limits.MaxDepth {
+			return nil, limitsExceeded("Filtering expression is nested deeper than the limit of %d.", p.limits.MaxDepth)
+		}
 		if err := p.eatToken(); err != nil {
 			return nil, err
 		}
@@ -173,11 +194,12 @@ func (p *filteringParser) factor() (FilteringExpression, error) {
 		}
 		switch p.curToken.(type) {
 		case RparenToken:
+			p.nesting--
 			if err := p.eatToken(); err != nil {
 				return nil, err
 			}
 			if isNot {
-				p.negateNode(node)
+				negateExpression(node)
 			}
 			return node, nil
 		default:
@@ -189,7 +211,7 @@ func (p *filteringParser) factor() (FilteringExpression, error) {
 			return nil, err
 		}
 		if isNot {
-			p.negateNode(node)
+			negateExpression(node)
 		}
 		return node, nil
 	}
@@ -200,6 +222,10 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 	if !ok {
 		return nil, &UnexpectedTokenError{p.curToken}
 	}
+	p.conditions++
+	if p.limits.MaxConditions > 0 && p.conditions > p.limits.MaxConditions {
+		return nil, limitsExceeded("Filtering expression has more conditions than the limit of %d.", p.limits.MaxConditions)
+	}
 	if err := p.eatToken(); err != nil {
 		return nil, err
 	}
@@ -237,6 +263,27 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				FieldPath:  strings.Split(field.Value, "."),
 				IsNegative: false,
 			}, nil
+		case TimeToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &TimeCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Value:      timestamppb.New(token.Value),
+				Relative:   token.Relative,
+				Type:       TimeCondition_EQ,
+				IsNegative: false,
+			}, nil
+		case BoolToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &BoolCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Value:      token.Value,
+				Type:       BoolCondition_EQ,
+				IsNegative: false,
+			}, nil
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -273,6 +320,27 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				FieldPath:  strings.Split(field.Value, "."),
 				IsNegative: true,
 			}, nil
+		case TimeToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &TimeCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Value:      timestamppb.New(token.Value),
+				Relative:   token.Relative,
+				Type:       TimeCondition_EQ,
+				IsNegative: true,
+			}, nil
+		case BoolToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &BoolCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Value:      token.Value,
+				Type:       BoolCondition_EQ,
+				IsNegative: true,
+			}, nil
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -355,6 +423,17 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				Type:       StringCondition_GT,
 				IsNegative: false,
 			}, nil
+		case TimeToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &TimeCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Value:      timestamppb.New(token.Value),
+				Relative:   token.Relative,
+				Type:       TimeCondition_GT,
+				IsNegative: false,
+			}, nil
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -383,6 +462,17 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				Type:       StringCondition_GE,
 				IsNegative: false,
 			}, nil
+		case TimeToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &TimeCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Value:      timestamppb.New(token.Value),
+				Relative:   token.Relative,
+				Type:       TimeCondition_GE,
+				IsNegative: false,
+			}, nil
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -411,6 +501,17 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				Type:       StringCondition_LT,
 				IsNegative: false,
 			}, nil
+		case TimeToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &TimeCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Value:      timestamppb.New(token.Value),
+				Relative:   token.Relative,
+				Type:       TimeCondition_LT,
+				IsNegative: false,
+			}, nil
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -439,6 +540,17 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				Type:       StringCondition_LE,
 				IsNegative: false,
 			}, nil
+		case TimeToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+			return &TimeCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Value:      timestamppb.New(token.Value),
+				Relative:   token.Relative,
+				Type:       TimeCondition_LE,
+				IsNegative: false,
+			}, nil
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
@@ -472,6 +584,60 @@ func (p *filteringParser) condition() (FilteringExpression, error) {
 				IsNegative: false,
 			}, nil
 
+		default:
+			return nil, &UnexpectedTokenError{p.curToken}
+		}
+	case ContainsToken:
+		if err := p.eatToken(); err != nil {
+			return nil, err
+		}
+
+		switch token := p.curToken.(type) {
+		case StringToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+
+			return &ContainsCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Values:     []string{token.Value},
+				Type:       ContainsCondition_CONTAINS,
+				IsNegative: false,
+			}, nil
+
+		case StringArrayToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+
+			return &ContainsCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Values:     token.Values,
+				Type:       ContainsCondition_CONTAINS,
+				IsNegative: false,
+			}, nil
+
+		default:
+			return nil, &UnexpectedTokenError{p.curToken}
+		}
+	case HasToken:
+		if err := p.eatToken(); err != nil {
+			return nil, err
+		}
+
+		switch token := p.curToken.(type) {
+		case StringToken:
+			if err := p.eatToken(); err != nil {
+				return nil, err
+			}
+
+			return &ContainsCondition{
+				FieldPath:  strings.Split(field.Value, "."),
+				Values:     []string{token.Value},
+				Type:       ContainsCondition_HAS,
+				IsNegative: false,
+			}, nil
+
 		default:
 			return nil, &UnexpectedTokenError{p.curToken}
 		}
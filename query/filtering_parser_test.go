@@ -2,492 +2,627 @@ package query
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func TestFilteringParser(t *testing.T) {
-
-	tests := []struct {
-		text string
-		exp  *Filtering
-	}{
-		{
-			text: "not(not(not field1 == 'abc' or not field2 == 'bcd') and (field3 != 'cde'))",
-			exp: &Filtering{
-				Root: &Filtering_Operator{
-					&LogicalOperator{
-						Left: &LogicalOperator_LeftOperator{
-							&LogicalOperator{
-								Left: &LogicalOperator_LeftStringCondition{
-									&StringCondition{
-										FieldPath:  []string{"field1"},
-										Value:      "abc",
-										Type:       StringCondition_EQ,
-										IsNegative: true,
-									},
+var filteringParserTests = []struct {
+	text string
+	exp  *Filtering
+}{
+	{
+		text: "not(not(not field1 == 'abc' or not field2 == 'bcd') and (field3 != 'cde'))",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				&LogicalOperator{
+					Left: &LogicalOperator_LeftOperator{
+						&LogicalOperator{
+							Left: &LogicalOperator_LeftStringCondition{
+								&StringCondition{
+									FieldPath:  []string{"field1"},
+									Value:      "abc",
+									Type:       StringCondition_EQ,
+									IsNegative: true,
 								},
-								Right: &LogicalOperator_RightStringCondition{
-									&StringCondition{
-										FieldPath:  []string{"field2"},
-										Value:      "bcd",
-										Type:       StringCondition_EQ,
-										IsNegative: true,
-									},
+							},
+							Right: &LogicalOperator_RightStringCondition{
+								&StringCondition{
+									FieldPath:  []string{"field2"},
+									Value:      "bcd",
+									Type:       StringCondition_EQ,
+									IsNegative: true,
 								},
-								Type:       LogicalOperator_OR,
-								IsNegative: true,
 							},
+							Type:       LogicalOperator_OR,
+							IsNegative: true,
 						},
-						Right: &LogicalOperator_RightStringCondition{
-							&StringCondition{
-								FieldPath:  []string{"field3"},
-								Value:      "cde",
-								Type:       StringCondition_EQ,
-								IsNegative: true,
-							},
+					},
+					Right: &LogicalOperator_RightStringCondition{
+						&StringCondition{
+							FieldPath:  []string{"field3"},
+							Value:      "cde",
+							Type:       StringCondition_EQ,
+							IsNegative: true,
 						},
-						Type:       LogicalOperator_AND,
-						IsNegative: true,
 					},
+					Type:       LogicalOperator_AND,
+					IsNegative: true,
 				},
 			},
 		},
-		{
-			text: "field1 == 'abc' or field2 == 'cde' and not field3 == 'cdf'",
-			exp: &Filtering{
-				Root: &Filtering_Operator{
-					&LogicalOperator{
-						Left: &LogicalOperator_LeftStringCondition{
-							&StringCondition{
-								FieldPath:  []string{"field1"},
-								Value:      "abc",
-								Type:       StringCondition_EQ,
-								IsNegative: false,
-							},
+	},
+	{
+		text: "field1 == 'abc' or field2 == 'cde' and not field3 == 'cdf'",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				&LogicalOperator{
+					Left: &LogicalOperator_LeftStringCondition{
+						&StringCondition{
+							FieldPath:  []string{"field1"},
+							Value:      "abc",
+							Type:       StringCondition_EQ,
+							IsNegative: false,
 						},
-						Right: &LogicalOperator_RightOperator{
-							&LogicalOperator{
-								Left: &LogicalOperator_LeftStringCondition{
-									&StringCondition{
-										FieldPath:  []string{"field2"},
-										Value:      "cde",
-										Type:       StringCondition_EQ,
-										IsNegative: false,
-									},
+					},
+					Right: &LogicalOperator_RightOperator{
+						&LogicalOperator{
+							Left: &LogicalOperator_LeftStringCondition{
+								&StringCondition{
+									FieldPath:  []string{"field2"},
+									Value:      "cde",
+									Type:       StringCondition_EQ,
+									IsNegative: false,
 								},
-								Right: &LogicalOperator_RightStringCondition{
-									&StringCondition{
-										FieldPath:  []string{"field3"},
-										Value:      "cdf",
-										Type:       StringCondition_EQ,
-										IsNegative: true,
-									},
+							},
+							Right: &LogicalOperator_RightStringCondition{
+								&StringCondition{
+									FieldPath:  []string{"field3"},
+									Value:      "cdf",
+									Type:       StringCondition_EQ,
+									IsNegative: true,
 								},
-								Type:       LogicalOperator_AND,
-								IsNegative: false,
 							},
+							Type:       LogicalOperator_AND,
+							IsNegative: false,
 						},
-						Type:       LogicalOperator_OR,
-						IsNegative: false,
 					},
+					Type:       LogicalOperator_OR,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "(field1 == 'abc' or field2 == 'cde') and (field3 == 'fbg' or field4 == 'zux')",
-			exp: &Filtering{
-				Root: &Filtering_Operator{
-					&LogicalOperator{
-						Left: &LogicalOperator_LeftOperator{
-							&LogicalOperator{
-								Left: &LogicalOperator_LeftStringCondition{
-									&StringCondition{
-										FieldPath:  []string{"field1"},
-										Value:      "abc",
-										Type:       StringCondition_EQ,
-										IsNegative: false,
-									},
+	},
+	{
+		text: "(field1 == 'abc' or field2 == 'cde') and (field3 == 'fbg' or field4 == 'zux')",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				&LogicalOperator{
+					Left: &LogicalOperator_LeftOperator{
+						&LogicalOperator{
+							Left: &LogicalOperator_LeftStringCondition{
+								&StringCondition{
+									FieldPath:  []string{"field1"},
+									Value:      "abc",
+									Type:       StringCondition_EQ,
+									IsNegative: false,
 								},
-								Right: &LogicalOperator_RightStringCondition{
-									&StringCondition{
-										FieldPath:  []string{"field2"},
-										Value:      "cde",
-										Type:       StringCondition_EQ,
-										IsNegative: false,
-									},
+							},
+							Right: &LogicalOperator_RightStringCondition{
+								&StringCondition{
+									FieldPath:  []string{"field2"},
+									Value:      "cde",
+									Type:       StringCondition_EQ,
+									IsNegative: false,
 								},
-								Type:       LogicalOperator_OR,
-								IsNegative: false,
 							},
+							Type:       LogicalOperator_OR,
+							IsNegative: false,
 						},
-						Right: &LogicalOperator_RightOperator{
-							&LogicalOperator{
-								Left: &LogicalOperator_LeftStringCondition{
-									&StringCondition{
-										FieldPath:  []string{"field3"},
-										Value:      "fbg",
-										Type:       StringCondition_EQ,
-										IsNegative: false,
-									},
+					},
+					Right: &LogicalOperator_RightOperator{
+						&LogicalOperator{
+							Left: &LogicalOperator_LeftStringCondition{
+								&StringCondition{
+									FieldPath:  []string{"field3"},
+									Value:      "fbg",
+									Type:       StringCondition_EQ,
+									IsNegative: false,
 								},
-								Right: &LogicalOperator_RightStringCondition{
-									&StringCondition{
-										FieldPath:  []string{"field4"},
-										Value:      "zux",
-										Type:       StringCondition_EQ,
-										IsNegative: false,
-									},
+							},
+							Right: &LogicalOperator_RightStringCondition{
+								&StringCondition{
+									FieldPath:  []string{"field4"},
+									Value:      "zux",
+									Type:       StringCondition_EQ,
+									IsNegative: false,
 								},
-								Type:       LogicalOperator_OR,
-								IsNegative: false,
 							},
+							Type:       LogicalOperator_OR,
+							IsNegative: false,
 						},
-						Type:       LogicalOperator_AND,
-						IsNegative: false,
 					},
+					Type:       LogicalOperator_AND,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field == 'abc'",
-			exp: &Filtering{
-				Root: &Filtering_StringCondition{
-					&StringCondition{
-						FieldPath:  []string{"field"},
-						Value:      "abc",
-						Type:       StringCondition_EQ,
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field == 'abc'",
+		exp: &Filtering{
+			Root: &Filtering_StringCondition{
+				&StringCondition{
+					FieldPath:  []string{"field"},
+					Value:      "abc",
+					Type:       StringCondition_EQ,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field := 'AbC'",
-			exp: &Filtering{
-				Root: &Filtering_StringCondition{
-					&StringCondition{
-						FieldPath:  []string{"field"},
-						Value:      "AbC",
-						Type:       StringCondition_IEQ,
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field := 'AbC'",
+		exp: &Filtering{
+			Root: &Filtering_StringCondition{
+				&StringCondition{
+					FieldPath:  []string{"field"},
+					Value:      "AbC",
+					Type:       StringCondition_IEQ,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "not field := 'AbC'",
-			exp: &Filtering{
-				Root: &Filtering_StringCondition{
-					&StringCondition{
-						FieldPath:  []string{"field"},
-						Value:      "AbC",
-						Type:       StringCondition_IEQ,
-						IsNegative: true,
-					},
+	},
+	{
+		text: "not field := 'AbC'",
+		exp: &Filtering{
+			Root: &Filtering_StringCondition{
+				&StringCondition{
+					FieldPath:  []string{"field"},
+					Value:      "AbC",
+					Type:       StringCondition_IEQ,
+					IsNegative: true,
 				},
 			},
 		},
-		{
-			text: "(field := 'AbC') and (field1 := 'BcD')",
-			exp: &Filtering{
-				Root: &Filtering_Operator{
-					Operator: &LogicalOperator{
-						Left: &LogicalOperator_LeftStringCondition{
-							&StringCondition{
-								FieldPath:  []string{"field"},
-								Value:      "AbC",
-								Type:       StringCondition_IEQ,
-								IsNegative: false,
-							},
+	},
+	{
+		text: "(field := 'AbC') and (field1 := 'BcD')",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				Operator: &LogicalOperator{
+					Left: &LogicalOperator_LeftStringCondition{
+						&StringCondition{
+							FieldPath:  []string{"field"},
+							Value:      "AbC",
+							Type:       StringCondition_IEQ,
+							IsNegative: false,
 						},
-						Right: &LogicalOperator_RightStringCondition{
-							&StringCondition{
-								FieldPath:  []string{"field1"},
-								Value:      "BcD",
-								Type:       StringCondition_IEQ,
-								IsNegative: false,
-							},
+					},
+					Right: &LogicalOperator_RightStringCondition{
+						&StringCondition{
+							FieldPath:  []string{"field1"},
+							Value:      "BcD",
+							Type:       StringCondition_IEQ,
+							IsNegative: false,
 						},
 					},
 				},
 			},
 		},
-		{
-			text: "(field := 'AbC') and not(field1 := 'BcD')",
-			exp: &Filtering{
-				Root: &Filtering_Operator{
-					Operator: &LogicalOperator{
-						Left: &LogicalOperator_LeftStringCondition{
-							&StringCondition{
-								FieldPath:  []string{"field"},
-								Value:      "AbC",
-								Type:       StringCondition_IEQ,
-								IsNegative: false,
-							},
+	},
+	{
+		text: "(field := 'AbC') and not(field1 := 'BcD')",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				Operator: &LogicalOperator{
+					Left: &LogicalOperator_LeftStringCondition{
+						&StringCondition{
+							FieldPath:  []string{"field"},
+							Value:      "AbC",
+							Type:       StringCondition_IEQ,
+							IsNegative: false,
 						},
-						Right: &LogicalOperator_RightStringCondition{
-							&StringCondition{
-								FieldPath:  []string{"field1"},
-								Value:      "BcD",
-								Type:       StringCondition_IEQ,
-								IsNegative: true,
-							},
+					},
+					Right: &LogicalOperator_RightStringCondition{
+						&StringCondition{
+							FieldPath:  []string{"field1"},
+							Value:      "BcD",
+							Type:       StringCondition_IEQ,
+							IsNegative: true,
 						},
 					},
 				},
 			},
 		},
-		{
-			text: "field != \"abc cde\"",
-			exp: &Filtering{
-				Root: &Filtering_StringCondition{
-					&StringCondition{
-						FieldPath:  []string{"field"},
-						Value:      "abc cde",
-						Type:       StringCondition_EQ,
-						IsNegative: true,
-					},
+	},
+	{
+		text: "field != \"abc cde\"",
+		exp: &Filtering{
+			Root: &Filtering_StringCondition{
+				&StringCondition{
+					FieldPath:  []string{"field"},
+					Value:      "abc cde",
+					Type:       StringCondition_EQ,
+					IsNegative: true,
 				},
 			},
 		},
-		{
-			text: "field == 123",
-			exp: &Filtering{
-				Root: &Filtering_NumberCondition{
-					&NumberCondition{
-						FieldPath:  []string{"field"},
-						Value:      123,
-						Type:       NumberCondition_EQ,
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field == 123",
+		exp: &Filtering{
+			Root: &Filtering_NumberCondition{
+				&NumberCondition{
+					FieldPath:  []string{"field"},
+					Value:      123,
+					Type:       NumberCondition_EQ,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field != 0.2343",
-			exp: &Filtering{
-				Root: &Filtering_NumberCondition{
-					&NumberCondition{
-						FieldPath:  []string{"field"},
-						Value:      0.2343,
-						Type:       NumberCondition_EQ,
-						IsNegative: true,
-					},
+	},
+	{
+		text: "field != 0.2343",
+		exp: &Filtering{
+			Root: &Filtering_NumberCondition{
+				&NumberCondition{
+					FieldPath:  []string{"field"},
+					Value:      0.2343,
+					Type:       NumberCondition_EQ,
+					IsNegative: true,
 				},
 			},
 		},
-		{
-			text: "field == null",
-			exp: &Filtering{
-				Root: &Filtering_NullCondition{
-					&NullCondition{
-						FieldPath:  []string{"field"},
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field == null",
+		exp: &Filtering{
+			Root: &Filtering_NullCondition{
+				&NullCondition{
+					FieldPath:  []string{"field"},
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field != null",
-			exp: &Filtering{
-				Root: &Filtering_NullCondition{
-					&NullCondition{
-						FieldPath:  []string{"field"},
-						IsNegative: true,
-					},
+	},
+	{
+		text: "field != null",
+		exp: &Filtering{
+			Root: &Filtering_NullCondition{
+				&NullCondition{
+					FieldPath:  []string{"field"},
+					IsNegative: true,
 				},
 			},
 		},
-		{
-			text: "not field != null",
-			exp: &Filtering{
-				Root: &Filtering_NullCondition{
-					&NullCondition{
-						FieldPath:  []string{"field"},
-						IsNegative: false,
-					},
+	},
+	{
+		text: "not field != null",
+		exp: &Filtering{
+			Root: &Filtering_NullCondition{
+				&NullCondition{
+					FieldPath:  []string{"field"},
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field ~ 'regex'",
-			exp: &Filtering{
-				Root: &Filtering_StringCondition{
-					&StringCondition{
-						FieldPath:  []string{"field"},
-						Value:      "regex",
-						Type:       StringCondition_MATCH,
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field ~ 'regex'",
+		exp: &Filtering{
+			Root: &Filtering_StringCondition{
+				&StringCondition{
+					FieldPath:  []string{"field"},
+					Value:      "regex",
+					Type:       StringCondition_MATCH,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field !~ 'regex'",
-			exp: &Filtering{
-				Root: &Filtering_StringCondition{
-					&StringCondition{
-						FieldPath:  []string{"field"},
-						Value:      "regex",
-						Type:       StringCondition_MATCH,
-						IsNegative: true,
-					},
+	},
+	{
+		text: "field !~ 'regex'",
+		exp: &Filtering{
+			Root: &Filtering_StringCondition{
+				&StringCondition{
+					FieldPath:  []string{"field"},
+					Value:      "regex",
+					Type:       StringCondition_MATCH,
+					IsNegative: true,
 				},
 			},
 		},
-		{
-			text: "field < 123",
-			exp: &Filtering{
-				Root: &Filtering_NumberCondition{
-					&NumberCondition{
-						FieldPath:  []string{"field"},
-						Value:      123,
-						Type:       NumberCondition_LT,
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field < 123",
+		exp: &Filtering{
+			Root: &Filtering_NumberCondition{
+				&NumberCondition{
+					FieldPath:  []string{"field"},
+					Value:      123,
+					Type:       NumberCondition_LT,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "not field <= 123",
-			exp: &Filtering{
-				Root: &Filtering_NumberCondition{
-					&NumberCondition{
-						FieldPath:  []string{"field"},
-						Value:      123,
-						Type:       NumberCondition_LE,
-						IsNegative: true,
-					},
+	},
+	{
+		text: "not field <= 123",
+		exp: &Filtering{
+			Root: &Filtering_NumberCondition{
+				&NumberCondition{
+					FieldPath:  []string{"field"},
+					Value:      123,
+					Type:       NumberCondition_LE,
+					IsNegative: true,
 				},
 			},
 		},
-		{
-			text: "field > 123",
-			exp: &Filtering{
-				Root: &Filtering_NumberCondition{
-					&NumberCondition{
-						FieldPath:  []string{"field"},
-						Value:      123,
-						Type:       NumberCondition_GT,
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field > 123",
+		exp: &Filtering{
+			Root: &Filtering_NumberCondition{
+				&NumberCondition{
+					FieldPath:  []string{"field"},
+					Value:      123,
+					Type:       NumberCondition_GT,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field >= 123",
-			exp: &Filtering{
-				Root: &Filtering_NumberCondition{
-					&NumberCondition{
-						FieldPath:  []string{"field"},
-						Value:      123,
-						Type:       NumberCondition_GE,
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field >= 123",
+		exp: &Filtering{
+			Root: &Filtering_NumberCondition{
+				&NumberCondition{
+					FieldPath:  []string{"field"},
+					Value:      123,
+					Type:       NumberCondition_GE,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field in [1 , 9 ,21]",
-			exp: &Filtering{
-				Root: &Filtering_NumberArrayCondition{
-					&NumberArrayCondition{
-						FieldPath:  []string{"field"},
-						Values:     []float64{1, 9, 21},
-						Type:       NumberArrayCondition_IN,
-						IsNegative: false,
-					},
+	},
+	{
+		text: "field in [1 , 9 ,21]",
+		exp: &Filtering{
+			Root: &Filtering_NumberArrayCondition{
+				&NumberArrayCondition{
+					FieldPath:  []string{"field"},
+					Values:     []float64{1, 9, 21},
+					Type:       NumberArrayCondition_IN,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "not (field in [1 , 9 ,21])",
-			exp: &Filtering{
-				Root: &Filtering_NumberArrayCondition{
-					&NumberArrayCondition{
-						FieldPath:  []string{"field"},
-						Values:     []float64{1, 9, 21},
-						Type:       NumberArrayCondition_IN,
-						IsNegative: true,
-					},
+	},
+	{
+		text: "not (field in [1 , 9 ,21])",
+		exp: &Filtering{
+			Root: &Filtering_NumberArrayCondition{
+				&NumberArrayCondition{
+					FieldPath:  []string{"field"},
+					Values:     []float64{1, 9, 21},
+					Type:       NumberArrayCondition_IN,
+					IsNegative: true,
 				},
 			},
 		},
-		{
-			text: "field in ['Hello' , 'World']",
-			exp: &Filtering{
-				Root: &Filtering_StringArrayCondition{
-					&StringArrayCondition{
-						FieldPath:  []string{"field"},
-						Values:     []string{"Hello", "World"},
-						Type:       StringArrayCondition_IN,
-						IsNegative: false,
+	},
+	{
+		text: "field in ['Hello' , 'World']",
+		exp: &Filtering{
+			Root: &Filtering_StringArrayCondition{
+				&StringArrayCondition{
+					FieldPath:  []string{"field"},
+					Values:     []string{"Hello", "World"},
+					Type:       StringArrayCondition_IN,
+					IsNegative: false,
+				},
+			},
+		},
+	},
+	{
+		text: "not (field in ['Hello' , 'World'])",
+		exp: &Filtering{
+			Root: &Filtering_StringArrayCondition{
+				&StringArrayCondition{
+					FieldPath:  []string{"field"},
+					Values:     []string{"Hello", "World"},
+					Type:       StringArrayCondition_IN,
+					IsNegative: true,
+				},
+			},
+		},
+	},
+	{
+		text: "(not (field in ['Hello' , 'World']) and (field := 'Mike'))",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				&LogicalOperator{
+					Left: &LogicalOperator_LeftStringArrayCondition{
+						&StringArrayCondition{
+							FieldPath:  []string{"field"},
+							Values:     []string{"Hello", "World"},
+							Type:       StringArrayCondition_IN,
+							IsNegative: true,
+						},
 					},
+					Right: &LogicalOperator_RightStringCondition{
+						&StringCondition{
+							FieldPath:  []string{"field"},
+							Value:      "Mike",
+							Type:       StringCondition_IEQ,
+							IsNegative: false,
+						},
+					},
+					Type:       LogicalOperator_AND,
+					IsNegative: false,
+				},
+			},
+		},
+	},
+	{
+		text: "field ieq 'HeLLo'",
+		exp: &Filtering{
+			Root: &Filtering_StringCondition{
+				&StringCondition{
+					FieldPath:  []string{"field"},
+					Value:      "HeLLo",
+					Type:       StringCondition_IEQ,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "not (field in ['Hello' , 'World'])",
-			exp: &Filtering{
-				Root: &Filtering_StringArrayCondition{
-					&StringArrayCondition{
-						FieldPath:  []string{"field"},
-						Values:     []string{"Hello", "World"},
-						Type:       StringArrayCondition_IN,
-						IsNegative: true,
+	},
+	{
+		text: "created >= 2024-01-01T00:00:00Z and not created == 2024-02-01",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				&LogicalOperator{
+					Left: &LogicalOperator_LeftTimeCondition{
+						&TimeCondition{
+							FieldPath:  []string{"created"},
+							Value:      timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+							Type:       TimeCondition_GE,
+							IsNegative: false,
+						},
 					},
+					Right: &LogicalOperator_RightTimeCondition{
+						&TimeCondition{
+							FieldPath:  []string{"created"},
+							Value:      timestamppb.New(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+							Type:       TimeCondition_EQ,
+							IsNegative: true,
+						},
+					},
+					Type:       LogicalOperator_AND,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "(not (field in ['Hello' , 'World']) and (field := 'Mike'))",
-			exp: &Filtering{
-				Root: &Filtering_Operator{
-					&LogicalOperator{
-						Left: &LogicalOperator_LeftStringArrayCondition{
-							&StringArrayCondition{
-								FieldPath:  []string{"field"},
-								Values:     []string{"Hello", "World"},
-								Type:       StringArrayCondition_IN,
-								IsNegative: true,
-							},
+	},
+	{
+		text: "enabled == true or not deleted != false",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				&LogicalOperator{
+					Left: &LogicalOperator_LeftBoolCondition{
+						&BoolCondition{
+							FieldPath:  []string{"enabled"},
+							Value:      true,
+							Type:       BoolCondition_EQ,
+							IsNegative: false,
 						},
-						Right: &LogicalOperator_RightStringCondition{
-							&StringCondition{
-								FieldPath:  []string{"field"},
-								Value:      "Mike",
-								Type:       StringCondition_IEQ,
-								IsNegative: false,
-							},
+					},
+					Right: &LogicalOperator_RightBoolCondition{
+						&BoolCondition{
+							FieldPath:  []string{"deleted"},
+							Value:      false,
+							Type:       BoolCondition_EQ,
+							IsNegative: false,
 						},
-						Type:       LogicalOperator_AND,
-						IsNegative: false,
 					},
+					Type:       LogicalOperator_OR,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "field ieq 'HeLLo'",
-			exp: &Filtering{
-				Root: &Filtering_StringCondition{
-					&StringCondition{
-						FieldPath:  []string{"field"},
-						Value:      "HeLLo",
-						Type:       StringCondition_IEQ,
-						IsNegative: false,
+	},
+	{
+		text: "enabled != true",
+		exp: &Filtering{
+			Root: &Filtering_BoolCondition{
+				&BoolCondition{
+					FieldPath:  []string{"enabled"},
+					Value:      true,
+					Type:       BoolCondition_EQ,
+					IsNegative: true,
+				},
+			},
+		},
+	},
+	{
+		text: "created != 2024-01-01T00:00:00Z",
+		exp: &Filtering{
+			Root: &Filtering_TimeCondition{
+				&TimeCondition{
+					FieldPath:  []string{"created"},
+					Value:      timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+					Type:       TimeCondition_EQ,
+					IsNegative: true,
+				},
+			},
+		},
+	},
+	{
+		text: "tags contains 'a' and not tags contains ['b', 'c'] or labels has 'env'",
+		exp: &Filtering{
+			Root: &Filtering_Operator{
+				&LogicalOperator{
+					Left: &LogicalOperator_LeftOperator{
+						&LogicalOperator{
+							Left: &LogicalOperator_LeftContainsCondition{
+								&ContainsCondition{
+									FieldPath:  []string{"tags"},
+									Values:     []string{"a"},
+									Type:       ContainsCondition_CONTAINS,
+									IsNegative: false,
+								},
+							},
+							Right: &LogicalOperator_RightContainsCondition{
+								&ContainsCondition{
+									FieldPath:  []string{"tags"},
+									Values:     []string{"b", "c"},
+									Type:       ContainsCondition_CONTAINS,
+									IsNegative: true,
+								},
+							},
+							Type:       LogicalOperator_AND,
+							IsNegative: false,
+						},
 					},
+					Right: &LogicalOperator_RightContainsCondition{
+						&ContainsCondition{
+							FieldPath:  []string{"labels"},
+							Values:     []string{"env"},
+							Type:       ContainsCondition_HAS,
+							IsNegative: false,
+						},
+					},
+					Type:       LogicalOperator_OR,
+					IsNegative: false,
 				},
 			},
 		},
-		{
-			text: "",
-			exp:  nil,
+	},
+	{
+		text: "not labels has 'env'",
+		exp: &Filtering{
+			Root: &Filtering_ContainsCondition{
+				&ContainsCondition{
+					FieldPath:  []string{"labels"},
+					Values:     []string{"env"},
+					Type:       ContainsCondition_HAS,
+					IsNegative: true,
+				},
+			},
 		},
-	}
+	},
+	{
+		text: "",
+		exp:  nil,
+	},
+}
 
+func TestFilteringParser(t *testing.T) {
 	p := NewFilteringParser()
-	for _, test := range tests {
+	for _, test := range filteringParserTests {
 		result, err := p.Parse(test.text)
 		assert.Equal(t, test.exp, result)
 		assert.Nil(t, err)
@@ -509,6 +644,15 @@ func TestFilteringParserNegative(t *testing.T) {
 		"field1 < or",
 		"field1 <= null",
 		"field1 or field2",
+		"field1 ~ 2024-01-01",
+		"field1 in 2024-01-01",
+		"field1 > true",
+		"field1 ~ false",
+		"true == field1",
+		"field1 contains 1",
+		"field1 contains [1, 2]",
+		"field1 has ['a']",
+		"field1 has null",
 	}
 
 	for _, test := range tests {
@@ -3,16 +3,22 @@ package query
 import (
 	"regexp/syntax"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type TestObject struct {
-	Str   string  `json:"str"`
-	Float float64 `json:"float"`
-	Uint  uint    `json:"uint"`
-	Ptr   *struct{}
+	Str     string  `json:"str"`
+	Float   float64 `json:"float"`
+	Uint    uint    `json:"uint"`
+	Bool    bool    `json:"bool"`
+	BoolPtr *bool   `json:"bool_ptr"`
+	Ptr     *struct{}
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
 }
 
 type TestProtoMessage struct {
@@ -170,6 +176,36 @@ func TestFiltering(t *testing.T) {
 			filter: "nestedJSON == null",
 			res:    false,
 		},
+		{
+			obj:    &TestObject{Bool: true},
+			filter: "bool == true and not bool == false",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Bool: true},
+			filter: "bool != true or bool == false",
+			res:    false,
+		},
+		{
+			obj:    &TestObject{BoolPtr: new(bool)},
+			filter: "bool_ptr == false and bool_ptr != null",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Tags: []string{"a", "b", "c"}},
+			filter: "tags contains ['c', 'a'] and tags has 'b' and not tags contains ['a', 'd']",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{Labels: map[string]string{"env": "prod"}},
+			filter: "labels has 'env' and not labels has 'prod'",
+			res:    true,
+		},
+		{
+			obj:    &TestObject{},
+			filter: "tags has 'a' or labels has 'env'",
+			res:    false,
+		},
 		{
 			obj:    &TestProtoMessage{},
 			filter: "",
@@ -216,6 +252,26 @@ func TestFilteringNegative(t *testing.T) {
 			filter: "missingField == 11.11",
 			err:    &TypeMismatchError{},
 		},
+		{
+			obj:    &TestObject{Str: "true"},
+			filter: "str == true",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{},
+			filter: "bool_ptr == true",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{Str: "a"},
+			filter: "str has 'a'",
+			err:    &TypeMismatchError{},
+		},
+		{
+			obj:    &TestObject{Labels: map[string]string{"env": "prod"}},
+			filter: "labels contains 'env'",
+			err:    &UnsupportedOperatorError{},
+		},
 		{
 			obj:    &TestObject{Str: "111"},
 			filter: "str ~ '11[1'",
@@ -230,3 +286,46 @@ func TestFilteringNegative(t *testing.T) {
 	}
 
 }
+
+type TestTimeObject struct {
+	Time           time.Time              `json:"time"`
+	TimePtr        *time.Time             `json:"time_ptr"`
+	Timestamp      *timestamppb.Timestamp `json:"timestamp"`
+	TimestampValue timestamppb.Timestamp  `json:"timestamp_value"`
+	Str            string                 `json:"str"`
+}
+
+func TestTimeFiltering(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	obj := &TestTimeObject{Time: ts, TimePtr: &ts, Timestamp: timestamppb.New(ts), Str: ts.Format(time.RFC3339)}
+
+	tests := []struct {
+		filter string
+		res    bool
+	}{
+		{"time == 2024-01-15T10:00:00Z", true},
+		{"time == 2024-01-15T12:00:00+02:00", true},
+		{"time != 2024-01-15T10:00:00Z", false},
+		{"time > 2024-01-01", true},
+		{"time < 2024-01-01", false},
+		{"time_ptr >= 2024-01-15T10:00:00Z and time_ptr <= 2024-01-15T10:00:00Z", true},
+		{"timestamp > 2024-01-15T09:59:59Z and timestamp < 2024-01-15T10:00:01Z", true},
+		{"str > 2024-01-16", false},
+		{"time > now()", false},
+		{"not time > now()-7d", true},
+	}
+
+	for _, test := range tests {
+		res, err := Filter(obj, test.filter)
+		assert.Equal(t, test.res, res, "filter: %s", test.filter)
+		assert.Nil(t, err, "filter: %s", test.filter)
+	}
+
+	// fields of a struct passed by value are not addressable
+	res, err := Filter(TestTimeObject{TimestampValue: timestamppb.Timestamp{Seconds: ts.Unix()}}, "timestamp_value == 2024-01-15T10:00:00Z")
+	assert.True(t, res)
+	assert.Nil(t, err)
+
+	_, err = Filter(&TestObject{Float: 1}, "float > 2024-01-01")
+	assert.IsType(t, &TypeMismatchError{}, err)
+}
@@ -1,12 +1,18 @@
 package query
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
+	protov2 "google.golang.org/protobuf/proto"
 
 	"github.com/infobloxopen/atlas-app-toolkit/v2/errors"
 )
@@ -53,3 +59,214 @@ func EncodePageToken(offset, limit int32) string {
 	data := fmt.Sprintf("%d:%d", offset, limit)
 	return base64.StdEncoding.EncodeToString([]byte(data))
 }
+
+// PageCursor represents a position in a collection ordered by a Sorting.
+// Sorting is a string representation of the sort criteria the cursor was
+// issued for and Values are the sort key values of the last returned row.
+type PageCursor struct {
+	Sorting string        `json:"s"`
+	Values  []interface{} `json:"v"`
+}
+
+// NewPageCursor returns a cursor that points right after the row with the
+// given sort key values in a collection ordered by s.
+func NewPageCursor(s *Sorting, values ...interface{}) *PageCursor {
+	var sorting string
+	if s != nil {
+		sorting = s.GoString()
+	}
+	return &PageCursor{Sorting: sorting, Values: values}
+}
+
+// Validate returns an error if the cursor was issued for a sort criteria
+// other than s or the number of sort key values does not match it.
+func (c *PageCursor) Validate(s *Sorting) error {
+	var sorting string
+	if s != nil {
+		sorting = s.GoString()
+	}
+	if c.Sorting != sorting || len(c.Values) != len(s.GetCriterias()) {
+		return errors.NewContainer(codes.InvalidArgument, "Page token validation failed.").
+			WithField("page_token", "Page token was issued for a different sort order.")
+	}
+	return nil
+}
+
+// IsCursorToken reports whether ptoken is a cursor page token produced by
+// EncodeCursorToken.
+func IsCursorToken(ptoken string) bool {
+	data, err := base64.StdEncoding.DecodeString(ptoken)
+	if err != nil {
+		return false
+	}
+	return len(data) > 0 && data[0] == '{'
+}
+
+// DecodeCursorToken decodes a cursor page token from the user's request.
+// Return error if provided token is malformed.
+// Numeric values are decoded as json.Number to preserve their precision.
+func DecodeCursorToken(ptoken string) (*PageCursor, error) {
+	data, err := base64.StdEncoding.DecodeString(ptoken)
+	if err != nil {
+		return nil, errors.NewContainer(codes.InvalidArgument, "Invalid page token %q.", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	c := new(PageCursor)
+	if err := dec.Decode(c); err != nil {
+		return nil, errors.NewContainer(codes.InvalidArgument, "Malformed page token.")
+	}
+	return c, nil
+}
+
+// EncodeCursorToken encodes cursor c to a string in application specific
+// format (JSON) in base64 encoding.
+func EncodeCursorToken(c *PageCursor) (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// PageToken represents a server-driven pagination token.
+// Either Offset and Limit or Cursor identify the next page.
+// Scope is a hash of the collection operators the token was issued for (see
+// PageTokenScope) and ExpiresAt is a Unix time after which the token is no
+// longer valid, zero value means the token never expires.
+type PageToken struct {
+	Offset    int32       `json:"o,omitempty"`
+	Limit     int32       `json:"l,omitempty"`
+	Cursor    *PageCursor `json:"c,omitempty"`
+	Scope     string      `json:"h,omitempty"`
+	ExpiresAt int64       `json:"e,omitempty"`
+}
+
+// Validate returns an error if the token was issued for collection operators
+// with a scope other than scope.
+func (t *PageToken) Validate(scope string) error {
+	if t.Scope != scope {
+		return errors.NewContainer(codes.InvalidArgument, "Page token validation failed.").
+			WithField("page_token", "Page token was issued for different collection operators.")
+	}
+	return nil
+}
+
+// PageTokenCodec is implemented by types that encode page tokens to strings
+// returned to the user and decode them back.
+type PageTokenCodec interface {
+	Encode(t *PageToken) (string, error)
+	Decode(ptoken string) (*PageToken, error)
+}
+
+// PageTokenScope returns a hash of filtering, sorting and field selection
+// collection operators that is used to bind a page token to the request it
+// was issued for. Relative time conditions, e.g. created_at > now()-7d,
+// are hashed by their relative literals rather than the resolved values,
+// so the scope does not change between requests.
+func PageTokenScope(f *Filtering, s *Sorting, fs *FieldSelection) string {
+	f = withoutRelativeTimes(f)
+	h := sha256.New()
+	opts := protov2.MarshalOptions{Deterministic: true}
+	for _, m := range []protov2.Message{f, s, fs} {
+		data, _ := opts.Marshal(m)
+		fmt.Fprintf(h, "%d:", len(data))
+		h.Write(data)
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:16])
+}
+
+// withoutRelativeTimes returns a copy of f with resolved values of relative time conditions cleared,
+// or f itself if it has none.
+func withoutRelativeTimes(f *Filtering) *Filtering {
+	var relative []*TimeCondition
+	var walk func(expr FilteringExpression)
+	walk = func(expr FilteringExpression) {
+		switch e := expr.(type) {
+		case *LogicalOperator:
+			walk(leftExpression(e))
+			walk(rightExpression(e))
+		case *TimeCondition:
+			if e.GetRelative() != "" {
+				relative = append(relative, e)
+			}
+		}
+	}
+	walk(rootExpression(f))
+	if len(relative) == 0 {
+		return f
+	}
+	res := protov2.Clone(f).(*Filtering)
+	relative = relative[:0]
+	walk(rootExpression(res))
+	for _, c := range relative {
+		c.Value = nil
+	}
+	return res
+}
+
+type hmacPageTokenCodec struct {
+	key []byte
+	ttl time.Duration
+	now func() time.Time
+}
+
+// NewHMACPageTokenCodec returns a PageTokenCodec that signs page tokens with
+// HMAC-SHA256 using key, so tokens cannot be forged or modified by the user.
+// If ttl is positive, encoded tokens expire after ttl.
+// It panics if key is empty, since tokens signed with an empty key can be forged.
+func NewHMACPageTokenCodec(key []byte, ttl time.Duration) PageTokenCodec {
+	if len(key) == 0 {
+		panic("query: HMAC page token codec requires a non-empty key")
+	}
+	return &hmacPageTokenCodec{key: key, ttl: ttl, now: time.Now}
+}
+
+// Encode signs t and encodes it to a string in "payload.signature" format
+// in URL-safe base64 encoding.
+func (c *hmacPageTokenCodec) Encode(t *PageToken) (string, error) {
+	tc := *t
+	if c.ttl > 0 {
+		tc.ExpiresAt = c.now().Add(c.ttl).Unix()
+	}
+	data, err := json.Marshal(&tc)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(c.sign(payload)), nil
+}
+
+// Decode verifies signature and expiration time of ptoken and decodes it.
+func (c *hmacPageTokenCodec) Decode(ptoken string) (*PageToken, error) {
+	vals := strings.SplitN(ptoken, ".", 2)
+	if len(vals) != 2 {
+		return nil, errors.NewContainer(codes.InvalidArgument, "Malformed page token.")
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(vals[1])
+	if err != nil || !hmac.Equal(sig, c.sign(vals[0])) {
+		return nil, errors.NewContainer(codes.InvalidArgument, "Page token validation failed.").
+			WithField("page_token", "Page token signature is invalid.")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(vals[0])
+	if err != nil {
+		return nil, errors.NewContainer(codes.InvalidArgument, "Invalid page token %q.", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	t := new(PageToken)
+	if err := dec.Decode(t); err != nil {
+		return nil, errors.NewContainer(codes.InvalidArgument, "Malformed page token.")
+	}
+	if t.ExpiresAt != 0 && c.now().Unix() > t.ExpiresAt {
+		return nil, errors.NewContainer(codes.InvalidArgument, "Page token validation failed.").
+			WithField("page_token", "Page token has expired.")
+	}
+	return t, nil
+}
+
+func (c *hmacPageTokenCodec) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
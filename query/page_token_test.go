@@ -1,7 +1,11 @@
 package query
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDecodePageToken(t *testing.T) {
@@ -82,3 +86,152 @@ func TestEncodePageToken(t *testing.T) {
 		}
 	}
 }
+
+func TestCursorToken(t *testing.T) {
+	s := &Sorting{Criterias: []*SortCriteria{
+		{Tag: "name", Order: SortCriteria_ASC},
+		{Tag: "id", Order: SortCriteria_DESC},
+	}}
+
+	ptoken, err := EncodeCursorToken(NewPageCursor(s, "Mike", int64(9007199254740993)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !IsCursorToken(ptoken) {
+		t.Fatalf("token %q is expected to be a cursor token", ptoken)
+	}
+	if IsCursorToken(EncodePageToken(12, 34)) {
+		t.Fatalf("offset token is not expected to be a cursor token")
+	}
+
+	c, err := DecodeCursorToken(ptoken)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := c.Validate(s); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(c.Values) != 2 || c.Values[0] != "Mike" || c.Values[1].(json.Number).String() != "9007199254740993" {
+		t.Fatalf("invalid cursor values %v", c.Values)
+	}
+
+	other := &Sorting{Criterias: []*SortCriteria{{Tag: "name", Order: SortCriteria_DESC}}}
+	if err := c.Validate(other); err == nil || err.Error() != "Page token validation failed." {
+		t.Fatalf("invalid error %v, expected %q", err, "Page token validation failed.")
+	}
+
+	// valid base64 of invalid JSON
+	if _, err := DecodeCursorToken(base64.StdEncoding.EncodeToString([]byte(`{"v":`))); err == nil || err.Error() != "Malformed page token." {
+		t.Fatalf("invalid error %v, expected %q", err, "Malformed page token.")
+	}
+}
+
+func TestHMACPageTokenCodec(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	codec := NewHMACPageTokenCodec([]byte("secret"), time.Minute).(*hmacPageTokenCodec)
+	codec.now = func() time.Time { return now }
+
+	f, err := ParseFiltering("name == 'Mike'")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	scope := PageTokenScope(f, nil, nil)
+
+	ptoken, err := codec.Encode(&PageToken{Offset: 20, Limit: 10, Scope: scope})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pt, err := codec.Decode(ptoken)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if pt.Offset != 20 || pt.Limit != 10 || pt.ExpiresAt != now.Add(time.Minute).Unix() {
+		t.Fatalf("invalid page token %+v", pt)
+	}
+	if err := pt.Validate(scope); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	other, err := ParseFiltering("name == 'John'")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := pt.Validate(PageTokenScope(other, nil, nil)); err == nil {
+		t.Fatalf("expected error for page token issued for a different filter")
+	}
+
+	// tamper with the limit
+	forged, err := (&hmacPageTokenCodec{key: []byte("other"), now: codec.now}).Encode(&PageToken{Offset: 20, Limit: 10000, Scope: scope})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	forged = forged[:strings.Index(forged, ".")] + ptoken[strings.Index(ptoken, "."):]
+	if _, err := codec.Decode(forged); err == nil || err.Error() != "Page token validation failed." {
+		t.Fatalf("invalid error %v, expected %q", err, "Page token validation failed.")
+	}
+
+	if _, err := codec.Decode(EncodePageToken(20, 10)); err == nil || err.Error() != "Malformed page token." {
+		t.Fatalf("invalid error %v, expected %q", err, "Malformed page token.")
+	}
+
+	codec.now = func() time.Time { return now.Add(2 * time.Minute) }
+	if _, err := codec.Decode(ptoken); err == nil || err.Error() != "Page token validation failed." {
+		t.Fatalf("invalid error %v, expected %q", err, "Page token validation failed.")
+	}
+}
+
+func TestHMACPageTokenCodecEmptyKey(t *testing.T) {
+	for _, key := range [][]byte{nil, {}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for key %q", key)
+				}
+			}()
+			NewHMACPageTokenCodec(key, time.Minute)
+		}()
+	}
+}
+
+func TestPageTokenScopeRelativeTime(t *testing.T) {
+	codec := NewHMACPageTokenCodec([]byte("secret"), 0)
+	filter := "name == 'Mike' and created_at > now()-7d"
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	f, err := ParseFiltering(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	scope := PageTokenScope(f, nil, nil)
+	ptoken, err := codec.Encode(&PageToken{Offset: 20, Limit: 10, Scope: scope})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if f.GetOperator().GetRightTimeCondition().GetValue() == nil {
+		t.Fatalf("resolved value of the relative time condition is cleared")
+	}
+
+	// the next page is requested a minute later
+	timeNow = func() time.Time { return now.Add(time.Minute) }
+	next, err := ParseFiltering(filter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	pt, err := codec.Decode(ptoken)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := pt.Validate(PageTokenScope(next, nil, nil)); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	other, err := ParseFiltering("name == 'Mike' and created_at > now()-1d")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := pt.Validate(PageTokenScope(other, nil, nil)); err == nil {
+		t.Fatalf("expected error for page token issued for a different filter")
+	}
+}
@@ -0,0 +1,202 @@
+package query
+
+import (
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/errors"
+)
+
+// filteringOperatorAliases maps literal aliases of filtering operators to their symbolic form.
+var filteringOperatorAliases = map[string]string{
+	"eq":      "==",
+	"ne":      "!=",
+	"gt":      ">",
+	"ge":      ">=",
+	"lt":      "<",
+	"le":      "<=",
+	"match":   "~",
+	"nomatch": "!~",
+	"ieq":     ":=",
+}
+
+// CollectionPolicy declares which field paths may be used in filtering and sorting
+// collection operators and which filtering operators each field path allows.
+// A nil policy allows everything, a non-nil policy allows only what was declared.
+// Including soft deleted records by include_deleted of filtering is denied unless allowed by AllowIncludeDeleted.
+//
+//	policy := query.NewCollectionPolicy().
+//		Filterable("name", "==", ":=", "in").
+//		Filterable("labels.*").
+//		Sortable("name", "created_time")
+type CollectionPolicy struct {
+	filterable     map[string]map[string]struct{}
+	sortable       map[string]struct{}
+	includeDeleted bool
+}
+
+// NewCollectionPolicy returns a policy that allows neither filtering nor sorting.
+func NewCollectionPolicy() *CollectionPolicy {
+	return &CollectionPolicy{
+		filterable: make(map[string]map[string]struct{}),
+		sortable:   make(map[string]struct{}),
+	}
+}
+
+// Filterable allows filtering by fieldPath with operators, e.g. "==", "!=", "~", "!~",
+// ">", ">=", "<", "<=", ":=", "in", "contains", "has" or their literal aliases ("eq", "match", ...).
+// All operators are allowed if none is specified.
+// fieldPath is dot-notated, "prefix.*" matches any nested field path of prefix.
+func (p *CollectionPolicy) Filterable(fieldPath string, operators ...string) *CollectionPolicy {
+	ops, ok := p.filterable[fieldPath]
+	if !ok || len(operators) == 0 {
+		ops = make(map[string]struct{})
+		p.filterable[fieldPath] = ops
+	}
+	for _, o := range operators {
+		if alias, ok := filteringOperatorAliases[o]; ok {
+			o = alias
+		}
+		ops[o] = struct{}{}
+	}
+	return p
+}
+
+// Sortable allows sorting by fieldPaths.
+func (p *CollectionPolicy) Sortable(fieldPaths ...string) *CollectionPolicy {
+	for _, fp := range fieldPaths {
+		p.sortable[fp] = struct{}{}
+	}
+	return p
+}
+
+// AllowIncludeDeleted allows including soft deleted records by include_deleted of filtering.
+func (p *CollectionPolicy) AllowIncludeDeleted() *CollectionPolicy {
+	p.includeDeleted = true
+	return p
+}
+
+// Validate returns an error if filtering f or sorting s violates the policy.
+// Each violation is reported as a field error of the returned errors.Container,
+// the target of a field error is the offending field path.
+func (p *CollectionPolicy) Validate(f *Filtering, s *Sorting) error {
+	if p == nil {
+		return nil
+	}
+	errC := errors.InitContainer()
+	if f.GetIncludeDeleted() && !p.includeDeleted {
+		errC.WithField("include_deleted", "Including deleted records is not allowed.")
+	}
+	p.validateFiltering(errC, rootExpression(f))
+	p.validateSorting(errC, s)
+	return errC.IfSet(codes.InvalidArgument, "Collection operators validation failed.")
+}
+
+// ValidateFiltering returns an error if filtering f violates the policy, see Validate.
+func (p *CollectionPolicy) ValidateFiltering(f *Filtering) error {
+	return p.Validate(f, nil)
+}
+
+// ValidateSorting returns an error if sorting s violates the policy, see Validate.
+func (p *CollectionPolicy) ValidateSorting(s *Sorting) error {
+	return p.Validate(nil, s)
+}
+
+func (p *CollectionPolicy) validateFiltering(errC *errors.Container, expr FilteringExpression) {
+	if lop, ok := expr.(*LogicalOperator); ok {
+		p.validateFiltering(errC, leftExpression(lop))
+		p.validateFiltering(errC, rightExpression(lop))
+		return
+	}
+	fieldPath, o := filteringOperator(expr)
+	if fieldPath == nil {
+		return
+	}
+	fp := strings.Join(fieldPath, ".")
+	ops, ok := p.filterableOperators(fieldPath)
+	switch {
+	case !ok:
+		errC.WithField(fp, "Filtering by %s is not allowed.", fp)
+	case len(ops) == 0:
+	default:
+		if _, ok := ops[o]; !ok {
+			errC.WithField(fp, "Operator %s is not allowed for %s.", o, fp)
+		}
+	}
+}
+
+func (p *CollectionPolicy) filterableOperators(fieldPath []string) (map[string]struct{}, bool) {
+	if ops, ok := p.filterable[strings.Join(fieldPath, ".")]; ok {
+		return ops, true
+	}
+	for i := len(fieldPath) - 1; i > 0; i-- {
+		if ops, ok := p.filterable[strings.Join(fieldPath[:i], ".")+".*"]; ok {
+			return ops, true
+		}
+	}
+	return nil, false
+}
+
+func (p *CollectionPolicy) validateSorting(errC *errors.Container, s *Sorting) {
+	for _, c := range s.GetCriterias() {
+		if _, ok := p.sortable[c.GetTag()]; !ok {
+			errC.WithField(c.GetTag(), "Sorting by %s is not allowed.", c.GetTag())
+		}
+	}
+}
+
+// filteringOperator returns the field path and the symbolic operator of condition expr.
+// Negated == and ~ conditions are reported as != and !~ respectively, other negated
+// conditions are reported by their operator.
+func filteringOperator(expr FilteringExpression) ([]string, string) {
+	switch c := expr.(type) {
+	case *StringCondition:
+		switch c.GetType() {
+		case StringCondition_EQ:
+			return c.GetFieldPath(), equalityOperator(c.GetIsNegative())
+		case StringCondition_MATCH:
+			if c.GetIsNegative() {
+				return c.GetFieldPath(), "!~"
+			}
+			return c.GetFieldPath(), "~"
+		case StringCondition_IEQ:
+			return c.GetFieldPath(), ":="
+		default:
+			return c.GetFieldPath(), relationalOperator(c.GetType().String())
+		}
+	case *NumberCondition:
+		if c.GetType() == NumberCondition_EQ {
+			return c.GetFieldPath(), equalityOperator(c.GetIsNegative())
+		}
+		return c.GetFieldPath(), relationalOperator(c.GetType().String())
+	case *TimeCondition:
+		if c.GetType() == TimeCondition_EQ {
+			return c.GetFieldPath(), equalityOperator(c.GetIsNegative())
+		}
+		return c.GetFieldPath(), relationalOperator(c.GetType().String())
+	case *NullCondition:
+		return c.GetFieldPath(), equalityOperator(c.GetIsNegative())
+	case *BoolCondition:
+		return c.GetFieldPath(), equalityOperator(c.GetIsNegative())
+	case *StringArrayCondition:
+		return c.GetFieldPath(), "in"
+	case *NumberArrayCondition:
+		return c.GetFieldPath(), "in"
+	case *ContainsCondition:
+		return c.GetFieldPath(), strings.ToLower(c.GetType().String())
+	default:
+		return nil, ""
+	}
+}
+
+func equalityOperator(neg bool) string {
+	if neg {
+		return "!="
+	}
+	return "=="
+}
+
+func relationalOperator(t string) string {
+	return filteringOperatorAliases[strings.ToLower(t)]
+}
@@ -0,0 +1,108 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/infobloxopen/atlas-app-toolkit/v2/rpc/errfields"
+)
+
+func TestCollectionPolicy(t *testing.T) {
+	p := NewCollectionPolicy().
+		Filterable("name", "==", "ieq", "in").
+		Filterable("age").
+		Filterable("labels.*", "==").
+		Filterable("tags", "contains").
+		Sortable("name", "age")
+
+	tests := []struct {
+		filter string
+		sort   string
+		fields map[string][]string
+	}{
+		{
+			filter: "name == 'x' or name := 'X' and name in ['a', 'b'] and age > 1 and not age ~ '1' and labels.env == 'prod'",
+			sort:   "name, age desc",
+		},
+		{
+			filter: "name ~ 'x' and not (id == 1 or labels.env != 'prod' or labels == null)",
+			sort:   "id",
+			fields: map[string][]string{
+				"name":       {"Operator ~ is not allowed for name."},
+				"id":         {"Filtering by id is not allowed.", "Sorting by id is not allowed."},
+				"labels.env": {"Operator != is not allowed for labels.env."},
+				"labels":     {"Filtering by labels is not allowed."},
+			},
+		},
+		{
+			filter: "not name == 'x' and name > 'a'",
+			fields: map[string][]string{
+				"name": {"Operator != is not allowed for name.", "Operator > is not allowed for name."},
+			},
+		},
+		{
+			filter: "tags contains ['a', 'b'] and not tags contains 'c'",
+		},
+		{
+			filter: "tags has 'a'",
+			fields: map[string][]string{
+				"tags": {"Operator has is not allowed for tags."},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		f, err := ParseFiltering(test.filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s *Sorting
+		if test.sort != "" {
+			if s, err = ParseSorting(test.sort); err != nil {
+				t.Fatal(err)
+			}
+		}
+		err = p.Validate(f, s)
+		if test.fields == nil {
+			assert.NoError(t, err, test.filter)
+			continue
+		}
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code(), test.filter)
+		if assert.Len(t, st.Details(), 1, test.filter) {
+			fields := map[string][]string{}
+			for k, v := range st.Details()[0].(*errfields.FieldInfo).GetFields() {
+				fields[k] = v.GetValues()
+			}
+			assert.Equal(t, test.fields, fields, test.filter)
+		}
+	}
+
+	var nilPolicy *CollectionPolicy
+	assert.NoError(t, nilPolicy.Validate(&Filtering{Root: &Filtering_NullCondition{&NullCondition{FieldPath: []string{"any"}}}}, nil))
+	assert.NoError(t, nilPolicy.Validate(&Filtering{IncludeDeleted: true}, nil))
+	assert.NoError(t, NewCollectionPolicy().Validate(nil, nil))
+	assert.Error(t, NewCollectionPolicy().ValidateSorting(&Sorting{Criterias: []*SortCriteria{{Tag: "name"}}}))
+}
+
+func TestCollectionPolicyIncludeDeleted(t *testing.T) {
+	f, err := ParseFiltering("name == 'x'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.IncludeDeleted = true
+
+	p := NewCollectionPolicy().Filterable("name")
+	st := status.Convert(p.ValidateFiltering(f))
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		fields := st.Details()[0].(*errfields.FieldInfo).GetFields()
+		assert.Len(t, fields, 1)
+		assert.Equal(t, []string{"Including deleted records is not allowed."}, fields["include_deleted"].GetValues())
+	}
+
+	assert.NoError(t, p.AllowIncludeDeleted().ValidateFiltering(f))
+}
@@ -18,8 +18,11 @@ func (c SortCriteria) IsDesc() bool {
 
 // GoString implements fmt.GoStringer interface
 // return string representation of a sort criteria in next form:
-// "<tag_name> (ASC|DESC)".
+// "<tag_name> (ASC|DESC) [NULLS (FIRST|LAST)]".
 func (c SortCriteria) GoString() string {
+	if c.Nulls != SortCriteria_NULLS_DEFAULT {
+		return fmt.Sprintf("%s %s %s", c.Tag, c.Order, strings.Replace(c.Nulls.String(), "_", " ", 1))
+	}
 	return fmt.Sprintf("%s %s", c.Tag, c.Order)
 }
 
@@ -29,7 +32,7 @@ func (c SortCriteria) GoString() string {
 var FieldIdentifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_\.]*$`)
 
 // ParseSorting parses raw string that represent sort criteria into a Sorting
-// data structure.
+// data structure, e.g. "name desc nulls last, age".
 // Provided string is supposed to be in accordance with the sorting collection
 // operator from REST API Syntax.
 // See: https://github.com/infobloxopen/atlas-app-toolkit#sorting
@@ -40,6 +43,17 @@ func ParseSorting(s string) (*Sorting, error) {
 		v := strings.Fields(craw)
 
 		var c SortCriteria
+		if n := len(v); n > 2 && strings.ToLower(v[n-2]) == "nulls" {
+			switch strings.ToLower(v[n-1]) {
+			case "first":
+				c.Nulls = SortCriteria_NULLS_FIRST
+			case "last":
+				c.Nulls = SortCriteria_NULLS_LAST
+			default:
+				return nil, fmt.Errorf("invalid nulls position - %q in %q", v[n-1], craw)
+			}
+			v = v[:n-2]
+		}
 		switch len(v) {
 		case 1:
 			c.Tag, c.Order = v[0], SortCriteria_ASC
@@ -69,7 +83,7 @@ func ParseSorting(s string) (*Sorting, error) {
 
 // GoString implements fmt.GoStringer interface
 // Returns string representation of sorting in next form:
-// "<name> (ASC|DESC) [, <tag_name> (ASC|DESC)]"
+// "<name> (ASC|DESC) [NULLS (FIRST|LAST)] [, <tag_name> (ASC|DESC) [NULLS (FIRST|LAST)]]"
 func (s Sorting) GoString() string {
 	var l []string
 
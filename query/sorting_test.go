@@ -52,6 +52,26 @@ func TestParseSorting(t *testing.T) {
 		t.Errorf("invalid sorting: %v - expected: %s", s, "name DESC, age ASC")
 	}
 
+	s, err = ParseSorting("name desc nulls last, owner.name NULLS FIRST, age")
+	if err != nil {
+		t.Fatalf("failed to parse sort parameters: %s", err)
+	}
+	if c := s.GetCriterias()[0]; !c.IsDesc() || c.Tag != "name" || c.Nulls != SortCriteria_NULLS_LAST {
+		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{Tag: "name", Order: SortCriteria_DESC, Nulls: SortCriteria_NULLS_LAST})
+	}
+	if c := s.GetCriterias()[1]; !c.IsAsc() || c.Tag != "owner.name" || c.Nulls != SortCriteria_NULLS_FIRST {
+		t.Errorf("invalid sort criteria: %v - expected: %v", c, SortCriteria{Tag: "owner.name", Order: SortCriteria_ASC, Nulls: SortCriteria_NULLS_FIRST})
+	}
+	if s.GoString() != "name DESC NULLS LAST, owner.name ASC NULLS FIRST, age ASC" {
+		t.Errorf("invalid sorting: %v - expected: %s", s, "name DESC NULLS LAST, owner.name ASC NULLS FIRST, age ASC")
+	}
+
+	for _, raw := range []string{"name nulls", "name desc nulls middle", "nulls last", "name desc last nulls first"} {
+		if _, err = ParseSorting(raw); err == nil {
+			t.Errorf("expected error for %q - got nil", raw)
+		}
+	}
+
 	_, err = ParseSorting("name dask")
 	if err == nil {
 		t.Fatal("expected error - got nil")